@@ -34,6 +34,9 @@ docker buildx build \
   --platform "${PLATFORM}" \
   -t "${ECR_REG}:${TAG}" \
   -f "docker/Dockerfile" \
+  --build-arg APP_VERSION="${TAG}" \
+  --build-arg GIT_COMMIT="$(git rev-parse --short HEAD)" \
+  --build-arg BUILD_DATE="$(date -u +%Y-%m-%dT%H:%M:%SZ)" \
   --push \
   .
 
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"database/sql"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Pool defaults for a single SQLite file. One connection serialises access,
+// so writers never wait on each other for the database lock.
+const (
+	defaultMaxOpenConns = 1
+	defaultMaxIdleConns = 1
+)
+
+// poolConfig holds the connection pool limits applied to the database
+type poolConfig struct {
+	maxOpenConns    int
+	maxIdleConns    int
+	connMaxLifetime time.Duration // zero keeps connections open indefinitely
+}
+
+// poolConfigFromEnv reads the pool limits from env variables
+// DB_MAX_OPEN_CONNS, DB_MAX_IDLE_CONNS and DB_CONN_MAX_LIFETIME (a Go
+// duration such as "30m"). Missing or invalid values keep the defaults.
+func poolConfigFromEnv() poolConfig {
+	cfg := poolConfig{
+		maxOpenConns: defaultMaxOpenConns,
+		maxIdleConns: defaultMaxIdleConns,
+	}
+	if v, err := strconv.Atoi(os.Getenv("DB_MAX_OPEN_CONNS")); err == nil && v > 0 {
+		cfg.maxOpenConns = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("DB_MAX_IDLE_CONNS")); err == nil && v >= 0 {
+		cfg.maxIdleConns = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("DB_CONN_MAX_LIFETIME")); err == nil && v > 0 {
+		cfg.connMaxLifetime = v
+	}
+	return cfg
+}
+
+// configureDB applies the pool limits from the environment to db and returns
+// them. Idle connections are capped at the open limit.
+func configureDB(db *sql.DB) poolConfig {
+	cfg := poolConfigFromEnv()
+	if cfg.maxIdleConns > cfg.maxOpenConns {
+		cfg.maxIdleConns = cfg.maxOpenConns
+	}
+	db.SetMaxOpenConns(cfg.maxOpenConns)
+	db.SetMaxIdleConns(cfg.maxIdleConns)
+	db.SetConnMaxLifetime(cfg.connMaxLifetime)
+	return cfg
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureDB(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxOpen     string
+		maxIdle     string
+		maxLifetime string
+		want        poolConfig
+	}{
+		{"defaults", "", "", "", poolConfig{maxOpenConns: 1, maxIdleConns: 1}},
+		{"from env", "4", "2", "30m", poolConfig{maxOpenConns: 4, maxIdleConns: 2, connMaxLifetime: 30 * time.Minute}},
+		{"no idle connections", "4", "0", "", poolConfig{maxOpenConns: 4, maxIdleConns: 0}},
+		{"idle capped at open", "2", "8", "", poolConfig{maxOpenConns: 2, maxIdleConns: 2}},
+		{"invalid values keep the defaults", "0", "-1", "soon", poolConfig{maxOpenConns: 1, maxIdleConns: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DB_MAX_OPEN_CONNS", tt.maxOpen)
+			t.Setenv("DB_MAX_IDLE_CONNS", tt.maxIdle)
+			t.Setenv("DB_CONN_MAX_LIFETIME", tt.maxLifetime)
+
+			db, err := sql.Open("sqlite3", ":memory:")
+			require.NoError(t, err)
+			defer db.Close()
+
+			assert.Equal(t, tt.want, configureDB(db))
+			assert.Equal(t, tt.want.maxOpenConns, db.Stats().MaxOpenConnections)
+		})
+	}
+}
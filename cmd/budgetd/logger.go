@@ -0,0 +1,51 @@
+package main
+
+import (
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// logLevels maps the accepted LOG_LEVEL values to zap levels
+var logLevels = map[string]zapcore.Level{
+	"debug": zapcore.DebugLevel,
+	"info":  zapcore.InfoLevel,
+	"warn":  zapcore.WarnLevel,
+	"error": zapcore.ErrorLevel,
+}
+
+// buildLogger builds the production logger at the given level (debug, info,
+// warn or error) writing json or console output. Empty or unknown values fall
+// back to info and json; unknown ones are reported once the logger exists.
+func buildLogger(level, format string) (*zap.Logger, error) {
+	cfg := zap.NewProductionConfig()
+
+	zapLevel, levelOK := logLevels[strings.ToLower(level)]
+	if !levelOK {
+		zapLevel = zapcore.InfoLevel
+	}
+	cfg.Level = zap.NewAtomicLevelAt(zapLevel)
+
+	formatOK := true
+	switch strings.ToLower(format) {
+	case "", "json":
+	case "console":
+		cfg.Encoding = "console"
+		cfg.EncoderConfig = zap.NewDevelopmentEncoderConfig()
+	default:
+		formatOK = false
+	}
+
+	logger, err := cfg.Build()
+	if err != nil {
+		return nil, err
+	}
+	if level != "" && !levelOK {
+		logger.Warn("unknown LOG_LEVEL, using info", zap.String("level", level))
+	}
+	if !formatOK {
+		logger.Warn("unknown LOG_FORMAT, using json", zap.String("format", format))
+	}
+	return logger, nil
+}
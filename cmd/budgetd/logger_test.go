@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestBuildLogger(t *testing.T) {
+	tests := []struct {
+		name   string
+		level  string
+		format string
+		want   zapcore.Level
+	}{
+		{"defaults", "", "", zapcore.InfoLevel},
+		{"debug", "debug", "json", zapcore.DebugLevel},
+		{"warn in console format", "warn", "console", zapcore.WarnLevel},
+		{"case insensitive", "ERROR", "Console", zapcore.ErrorLevel},
+		{"unknown level falls back to info", "verbose", "json", zapcore.InfoLevel},
+		{"levels outside the accepted set fall back to info", "fatal", "", zapcore.InfoLevel},
+		{"unknown format keeps the level", "debug", "xml", zapcore.DebugLevel},
+		{"unknown level and format", "loud", "xml", zapcore.InfoLevel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, err := buildLogger(tt.level, tt.format)
+			require.NoError(t, err)
+			require.NotNil(t, logger)
+
+			assert.True(t, logger.Core().Enabled(tt.want))
+			if tt.want > zapcore.DebugLevel {
+				assert.False(t, logger.Core().Enabled(tt.want-1))
+			}
+		})
+	}
+}
@@ -16,11 +16,11 @@ import (
 	"github.com/gin-gonic/gin"
 	_ "github.com/mattn/go-sqlite3"
 	"go.uber.org/zap"
-	"golang.org/x/crypto/bcrypt"
 
 	_ "github.com/piotrzalecki/budget-api/internal/docs" // This is the generated docs
 	"github.com/piotrzalecki/budget-api/internal/handler"
 	"github.com/piotrzalecki/budget-api/internal/repo"
+	"github.com/piotrzalecki/budget-api/pkg/model"
 )
 
 // @title           Budget API
@@ -58,11 +58,9 @@ import (
 // @tag.name admin
 // @tag.description Administrative operations
 
-var version = "dev"
-
 func main() {
 	// Initialize logger
-	logger, err := zap.NewProduction()
+	logger, err := buildLogger(os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT"))
 	if err != nil {
 		log.Fatalf("Failed to initialize logger: %v", err)
 	}
@@ -74,19 +72,24 @@ func main() {
 		dbPath = "dev.db" // Default to dev.db in current directory
 	}
 
-	db, err := sql.Open("sqlite3", dbPath)
+	db, err := sql.Open(repo.SQLiteDriver, repo.SQLiteDSN(dbPath))
 	if err != nil {
 		logger.Fatal("Failed to open database", zap.Error(err))
 	}
 	defer db.Close()
+	pool := configureDB(db)
+	logger.Info("database pool configured",
+		zap.Int("max_open_conns", pool.maxOpenConns),
+		zap.Int("max_idle_conns", pool.maxIdleConns),
+		zap.Duration("conn_max_lifetime", pool.connMaxLifetime))
 
 	// Test database connection
 	if err := db.Ping(); err != nil {
 		logger.Fatal("Failed to ping database", zap.Error(err))
 	}
 
-	// Initialize repository
-	repository := repo.NewRepository(db)
+	// Initialize repository, logging its SQL when LOG_LEVEL=debug
+	repository := repo.NewRepositoryWithQueryLog(db, logger)
 
 	// Seed service user if env vars are set
 	seedServiceUser(context.Background(), repository, logger)
@@ -130,9 +133,11 @@ func main() {
 	router.Use(gin.Recovery())
 	router.Use(ginzap.Ginzap(logger, time.RFC3339, true))
 	router.Use(ginzap.RecoveryWithZap(logger, true))
+	router.Use(handler.RequestTimeout())
+	router.Use(handler.MaxBodySize())
 
 	// Setup routes
-	setupRoutes(router, logger, handlers, repository, version)
+	setupRoutes(router, logger, handlers, repository)
 
 	// Create HTTP server
 	port := os.Getenv("PORT")
@@ -199,7 +204,7 @@ func seedDefaultUser(ctx context.Context, r repo.Repository, logger *zap.Logger)
 		return
 	}
 
-	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hash, err := model.HashPassword(password)
 	if err != nil {
 		logger.Error("seedDefaultUser: failed to hash password", zap.Error(err))
 		return
@@ -207,7 +212,7 @@ func seedDefaultUser(ctx context.Context, r repo.Repository, logger *zap.Logger)
 
 	_, err = r.CreateUser(ctx, repo.CreateUserParams{
 		Email:     email,
-		PwHash:    string(hash),
+		PwHash:    hash,
 		IsService: false,
 	})
 	if err != nil {
@@ -231,14 +236,14 @@ func seedServiceUser(ctx context.Context, r repo.Repository, logger *zap.Logger)
 	user, err := r.GetUserByEmail(ctx, email)
 	if err != nil {
 		// User doesn't exist yet — create with a placeholder password hash
-		hash, hashErr := bcrypt.GenerateFromPassword([]byte(token), bcrypt.DefaultCost)
+		hash, hashErr := model.HashPassword(token)
 		if hashErr != nil {
 			logger.Error("seedServiceUser: failed to hash token", zap.Error(hashErr))
 			return
 		}
 		user, err = r.CreateUser(ctx, repo.CreateUserParams{
 			Email:     email,
-			PwHash:    string(hash),
+			PwHash:    hash,
 			IsService: true,
 		})
 		if err != nil {
@@ -9,27 +9,34 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"go.uber.org/zap"
 
+	"github.com/piotrzalecki/budget-api/internal/buildinfo"
+	"github.com/piotrzalecki/budget-api/internal/docs"
 	"github.com/piotrzalecki/budget-api/internal/handler"
 	"github.com/piotrzalecki/budget-api/internal/repo"
 	"github.com/piotrzalecki/budget-api/pkg/model"
 )
 
-func setupRoutes(router *gin.Engine, logger *zap.Logger, handlers *handler.Handler, repository repo.Repository, version string) {
-	// Swagger documentation
-	router.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+func setupRoutes(router *gin.Engine, logger *zap.Logger, handlers *handler.Handler, repository repo.Repository) {
+	// Swagger documentation, plus the raw spec for codegen tooling
+	router.GET("/docs/*any", docsHandler(ginSwagger.WrapHandler(swaggerFiles.Handler)))
 
 	// Health endpoint (no auth required)
-	router.GET("/health", healthHandler(logger, version))
+	router.GET("/health", healthHandler(logger))
+	router.GET("/version", versionHandler)
 
-	// Public auth routes (no middleware)
+	// Public auth routes (no session required)
 	authGroup := router.Group("/api/v1/auth")
 	{
 		authGroup.POST("/login", handler.ValidateRequest[model.LoginRequest](), handlers.Login)
+		if handler.RegistrationEnabled() {
+			authGroup.POST("/register", handler.ValidateRequest[model.RegisterRequest](), handlers.Register)
+		}
+		authGroup.GET("/check", handler.APIKeyAuth(), handlers.CheckAuth)
 	}
 
-	// API v1 routes (protected by session token)
+	// API v1 routes (protected by API key or session token)
 	v1 := router.Group("/api/v1")
-	v1.Use(handler.SessionAuth(repository))
+	v1.Use(handler.APIKeyOrSessionAuth(repository))
 	{
 		// Auth
 		v1.POST("/auth/logout", handlers.Logout)
@@ -40,6 +47,9 @@ func setupRoutes(router *gin.Engine, logger *zap.Logger, handlers *handler.Handl
 		v1.GET("/users/:id", handlers.GetUserByID)
 		v1.PATCH("/users/:id", handler.ValidateRequest[model.UpdateUserRequest](), handlers.UpdateUser)
 		v1.DELETE("/users/:id", handlers.DeleteUser)
+		v1.GET("/me", handlers.GetProfile)
+		v1.PATCH("/me", handler.ValidateRequest[model.UpdateProfileRequest](), handlers.UpdateProfile)
+		v1.DELETE("/me", handler.ValidateRequest[model.DeleteAccountRequest](), handlers.DeleteProfile)
 
 		// Transaction routes with validation
 		v1.POST("/transactions", handler.ValidateRequest[model.CreateTransactionRequest](), handlers.CreateTransaction)
@@ -49,13 +59,36 @@ func setupRoutes(router *gin.Engine, logger *zap.Logger, handlers *handler.Handl
 		v1.DELETE("/transactions/:id", handlers.HardDeleteTransaction) //Commented out until Admin user will be implemented
 		v1.GET("/transactions/by-recurring/:recurring_id", handlers.GetTransactionsByRecurringID)
 		v1.GET("/transactions/by-tag/:tag_id", handlers.GetTransactionsByTag)
+		v1.GET("/transactions/by-tag-grouped", handlers.GetTransactionsGroupedByTag)
+		v1.GET("/transactions/activity", handlers.GetTransactionActivity)
 		v1.POST("/transactions/purge", handler.ValidateRequest[model.PurgeTransactionsRequest](), handlers.PurgeSoftDeletedTransactions)
+		v1.POST("/transactions/clear", handler.ValidateRequest[model.ClearTransactionsRequest](), handlers.ClearTransactions)
+		v1.POST("/transactions/reconcile", handler.ValidateRequest[model.ReconcileTransactionsRequest](), handlers.ReconcileTransactions)
+		v1.POST("/transactions/bulk-delete", handler.ValidateRequest[model.BulkDeleteTransactionsRequest](), handlers.BulkDeleteTransactions)
+		v1.POST("/transactions/trash/empty", handlers.EmptyTrash)
+		v1.PATCH("/transactions/:id/toggle-cleared", handlers.ToggleTransactionCleared)
+		v1.DELETE("/transactions/:id/purge", handlers.PurgeSoftDeletedTransaction)
+		v1.POST("/transactions/:id/make-recurring", handler.ValidateRequest[model.MakeRecurringRequest](), handlers.MakeTransactionRecurring)
+		v1.POST("/transactions/:id/tags/:tag_id", handlers.AddTransactionTag)
+		v1.DELETE("/transactions/:id/tags/:tag_id", handlers.RemoveTransactionTag)
+		v1.POST("/transactions/:id/receipts", handler.ValidateRequest[model.CreateReceiptRequest](), handlers.CreateReceipt)
+		v1.GET("/transactions/:id/receipts", handlers.GetReceipts)
+		v1.DELETE("/transactions/:id/receipts/:receipt_id", handlers.DeleteReceipt)
 		
 		// Tag routes with validation
 		v1.POST("/tags", handler.ValidateRequest[model.CreateTagRequest](), handlers.CreateTag)
 		v1.GET("/tags", handlers.GetTags)
+		v1.GET("/tags/search", handlers.SearchTags)
 		v1.PATCH("/tags/:id", handler.ValidateRequest[model.UpdateTagRequest](), handlers.UpdateTag)
 		v1.DELETE("/tags/:id", handlers.DeleteTag)
+		v1.POST("/tags/:id/reassign", handler.ValidateRequest[model.ReassignTagRequest](), handlers.ReassignTag)
+
+		// Tag alert routes
+		v1.GET("/tag-alerts", handlers.GetTagAlerts)
+		v1.POST("/tag-alerts", handler.ValidateRequest[model.CreateTagAlertRequest](), handlers.CreateTagAlert)
+		v1.POST("/tag-alerts/evaluate", handlers.EvaluateTagAlerts)
+		v1.PATCH("/tag-alerts/:id", handler.ValidateRequest[model.UpdateTagAlertRequest](), handlers.UpdateTagAlert)
+		v1.DELETE("/tag-alerts/:id", handlers.DeleteTagAlert)
 		
 		// Recurring routes with validation
 		v1.POST("/recurring", handler.ValidateRequest[model.CreateRecurringRequest](), handlers.CreateRecurring)
@@ -65,12 +98,32 @@ func setupRoutes(router *gin.Engine, logger *zap.Logger, handlers *handler.Handl
 		v1.DELETE("/recurring/:id", handlers.DeleteRecurring)
 		v1.GET("/recurring/by-tag/:tag_id", handlers.GetRecurringByTag)
 		v1.GET("/recurring/active", handlers.ListActiveRecurring)
+		v1.GET("/recurring/groups", handlers.GetRecurringGroups)
+		v1.GET("/recurring/upcoming", handlers.GetUpcomingRecurring)
+		v1.GET("/recurring/calendar.ics", handlers.GetRecurringCalendarFeed)
+		v1.GET("/recurring/stale", handlers.GetStaleRecurring)
 		v1.PATCH("/recurring/:id/toggle", handlers.ToggleRecurringActive)
+		v1.GET("/recurring/:id/pause-history", handlers.GetRecurringPauseHistory)
+		v1.GET("/recurring/:id/transactions", handlers.GetRecurringTransactions)
+		v1.GET("/recurring/:id/calendar.ics", handlers.GetRecurringCalendar)
+		v1.POST("/recurring/:id/clone", handler.ValidateOptionalRequest[model.CloneRecurringRequest](), handlers.CloneRecurring)
+		v1.POST("/recurring/:id/reschedule", handler.ValidateOptionalRequest[model.RescheduleRecurringRequest](), handlers.RescheduleRecurring)
+		v1.POST("/recurring/:id/tags/:tag_id", handlers.AddRecurringTag)
+		v1.DELETE("/recurring/:id/tags/:tag_id", handlers.RemoveRecurringTag)
 		v1.GET("/recurring/due", handlers.GetRecurringDueOnDate)
 		
 		// Reports routes
 		v1.GET("/reports/monthly", handlers.GetMonthlyReport)
 		v1.GET("/reports/monthly/totals", handlers.GetMonthlyTotals)
+		v1.GET("/reports/compare", handlers.GetMonthlyComparison)
+		v1.GET("/reports/top-tags", handlers.GetTopTags)
+		v1.GET("/reports/balance", handlers.GetBalanceReport)
+		v1.GET("/reports/net", handlers.GetNetByPeriod)
+		v1.GET("/reports/net-by-month", handlers.GetNetByPeriod)
+		v1.GET("/reports/forecast", handlers.GetForecast)
+		
+		// Meta routes
+		v1.GET("/meta/frequencies", handlers.GetFrequencies)
 		
 		// Placeholder route to use v1 variable
 		v1.GET("/", func(c *gin.Context) {
@@ -87,6 +140,11 @@ func setupRoutes(router *gin.Engine, logger *zap.Logger, handlers *handler.Handl
 	{
 		// Scheduler endpoint
 		admin.POST("/run-scheduler", handlers.RunScheduler)
+		admin.GET("/migrations", handlers.GetMigrationStatus)
+		admin.POST("/migrate", handlers.RunMigrations)
+		admin.GET("/db/stats", handlers.GetDBStats)
+		admin.DELETE("/recurring/:id", handlers.HardDeleteRecurring)
+		admin.POST("/recurring/repair", handlers.RepairRecurring)
 		
 		// Placeholder route to use admin variable
 		admin.GET("/", func(c *gin.Context) {
@@ -104,6 +162,35 @@ func setupRoutes(router *gin.Engine, logger *zap.Logger, handlers *handler.Handl
 			"data":  nil,
 		})
 	})
+
+	// Answer a known path with the wrong method with 405 rather than 404
+	router.HandleMethodNotAllowed = true
+	router.NoMethod(methodNotAllowedHandler)
+}
+
+// methodNotAllowedHandler answers requests whose path exists under another
+// method. Gin has already set the Allow header to the methods the path takes.
+func methodNotAllowedHandler(c *gin.Context) {
+	c.JSON(http.StatusMethodNotAllowed, gin.H{
+		"error": "Method not allowed; allowed methods: " + c.Writer.Header().Get("Allow"),
+		"data":  nil,
+	})
+}
+
+// docsHandler serves /docs/swagger.json and /docs/swagger.yaml from the
+// generated spec and passes every other /docs path to the Swagger UI. Gin
+// can't register those paths next to the /docs/*any catch-all.
+func docsHandler(ui gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Param("any") {
+		case "/swagger.json":
+			c.Data(http.StatusOK, "application/json; charset=utf-8", docs.SwaggerJSON)
+		case "/swagger.yaml":
+			c.Data(http.StatusOK, "application/yaml; charset=utf-8", docs.SwaggerYAML)
+		default:
+			ui(c)
+		}
+	}
 }
 
 // @Summary Health check
@@ -113,16 +200,29 @@ func setupRoutes(router *gin.Engine, logger *zap.Logger, handlers *handler.Handl
 // @Produce json
 // @Success 200 {object} map[string]interface{} "Health status"
 // @Router /health [get]
-func healthHandler(logger *zap.Logger, version string) gin.HandlerFunc {
+func healthHandler(logger *zap.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		logger.Debug("Health check requested")
 		c.JSON(http.StatusOK, gin.H{
 			"data": gin.H{
 				"status":    "healthy",
 				"timestamp": time.Now().UTC().Format(time.RFC3339),
-				"version":   version,
+				"version":   buildinfo.Version,
 			},
 			"error": nil,
 		})
 	}
-} 
\ No newline at end of file
+}
+
+// @Summary Build information
+// @Description Get the version, git commit and build date of the running binary. Values not set at build time read "dev".
+// @Tags health
+// @Produce json
+// @Success 200 {object} buildinfo.Info "Build information"
+// @Router /version [get]
+func versionHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"data":  buildinfo.Get(),
+		"error": nil,
+	})
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piotrzalecki/budget-api/internal/buildinfo"
+	"github.com/piotrzalecki/budget-api/internal/handler"
+)
+
+func TestUnmatchedRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("BUDGET_API_KEY", "test-key")
+	router := gin.New()
+	setupRoutes(router, zap.NewNop(), handler.NewHandler(nil, zap.NewNop()), nil)
+
+	t.Run("wrong method on an existing path", func(t *testing.T) {
+		req := httptest.NewRequest("DELETE", "/health", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+		assert.Equal(t, "GET", w.Header().Get("Allow"))
+		assert.JSONEq(t, `{"error": "Method not allowed; allowed methods: GET", "data": null}`, w.Body.String())
+	})
+
+	t.Run("unknown path", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/no-such-endpoint", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.JSONEq(t, `{"error": "Endpoint not found", "data": null}`, w.Body.String())
+	})
+}
+
+func TestSwaggerSpecEndpoints(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("BUDGET_API_KEY", "test-key")
+	router := gin.New()
+	setupRoutes(router, zap.NewNop(), handler.NewHandler(nil, zap.NewNop()), nil)
+
+	t.Run("json", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/docs/swagger.json", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+
+		var spec struct {
+			Swagger string                     `json:"swagger"`
+			Paths   map[string]json.RawMessage `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &spec))
+		assert.Equal(t, "2.0", spec.Swagger)
+		assert.Contains(t, spec.Paths, "/transactions")
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/docs/swagger.yaml", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "application/yaml")
+		assert.Contains(t, w.Body.String(), "\n  /transactions:\n")
+	})
+
+	t.Run("ui is still served", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/docs/index.html", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestVersionEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("BUDGET_API_KEY", "test-key")
+	router := gin.New()
+	setupRoutes(router, zap.NewNop(), handler.NewHandler(nil, zap.NewNop()), nil)
+
+	get := func(path string) map[string]string {
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data map[string]string `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data
+	}
+
+	t.Run("defaults to dev", func(t *testing.T) {
+		info := get("/version")
+		assert.Equal(t, "dev", info["version"])
+		assert.Equal(t, "dev", info["commit"])
+		assert.Equal(t, "dev", info["build_date"])
+		assert.Equal(t, "dev", get("/health")["version"])
+	})
+
+	t.Run("reports injected values", func(t *testing.T) {
+		version, commit, buildDate := buildinfo.Version, buildinfo.Commit, buildinfo.BuildDate
+		t.Cleanup(func() {
+			buildinfo.Version, buildinfo.Commit, buildinfo.BuildDate = version, commit, buildDate
+		})
+		// As set by -ldflags "-X github.com/piotrzalecki/budget-api/internal/buildinfo.Version=..."
+		buildinfo.Version = "0.1.2"
+		buildinfo.Commit = "abc1234"
+		buildinfo.BuildDate = "2031-06-01T12:00:00Z"
+
+		info := get("/version")
+		assert.Equal(t, "0.1.2", info["version"])
+		assert.Equal(t, "abc1234", info["commit"])
+		assert.Equal(t, "2031-06-01T12:00:00Z", info["build_date"])
+		assert.Equal(t, "0.1.2", get("/health")["version"])
+	})
+}
+
+func TestRegistrationIsOptIn(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("BUDGET_API_KEY", "test-key")
+
+	register := func() int {
+		router := gin.New()
+		setupRoutes(router, zap.NewNop(), handler.NewHandler(nil, zap.NewNop()), nil)
+
+		req := httptest.NewRequest("POST", "/api/v1/auth/register", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	t.Run("off by default", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, register())
+	})
+
+	t.Run("served when enabled", func(t *testing.T) {
+		t.Setenv("ALLOW_REGISTRATION", "true")
+		// Reaches validation, which rejects the empty body
+		assert.Equal(t, http.StatusBadRequest, register())
+	})
+}
@@ -0,0 +1,34 @@
+// Package buildinfo holds the metadata of the running build. The variables
+// are set at link time, for example:
+//
+//	go build -ldflags "-X github.com/piotrzalecki/budget-api/internal/buildinfo.Version=0.1.2 \
+//	  -X github.com/piotrzalecki/budget-api/internal/buildinfo.Commit=$(git rev-parse --short HEAD) \
+//	  -X github.com/piotrzalecki/budget-api/internal/buildinfo.BuildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)" ./cmd/budgetd
+//
+// Values that are not injected read "dev".
+package buildinfo
+
+var (
+	// Version is the release version, from the version file
+	Version = "dev"
+	// Commit is the git commit the binary was built from
+	Commit = "dev"
+	// BuildDate is when the binary was built, in RFC 3339
+	BuildDate = "dev"
+)
+
+// Info describes the running build
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+}
+
+// Get returns the metadata of the running build
+func Get() Info {
+	return Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+	}
+}
@@ -24,34 +24,30 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/admin/run-scheduler": {
-            "post": {
+        "/admin/db/stats": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Manually trigger the scheduler to process recurring transactions due today",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the connection pool statistics of the database handle, for diagnosing pool exhaustion",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "Run the scheduler",
+                "summary": "Get database pool statistics",
                 "responses": {
                     "200": {
-                        "description": "Scheduler execution result",
+                        "description": "Connection pool statistics",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.DBStatsResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "503": {
+                        "description": "Database unavailable",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -60,96 +56,69 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/login": {
+        "/admin/migrate": {
             "post": {
-                "description": "Authenticate with email and password, receive a session token",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Apply any migrations from MIGRATIONS_DIR that the database has not had yet, for controlled rollouts",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
-                ],
-                "summary": "Login",
-                "parameters": [
-                    {
-                        "description": "Login credentials",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.LoginRequest"
-                        }
-                    }
+                    "admin"
                 ],
+                "summary": "Run pending migrations",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Resulting schema version",
                         "schema": {
-                            "$ref": "#/definitions/model.LoginResponse"
+                            "$ref": "#/definitions/model.MigrateResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "500": {
+                        "description": "Migration failed",
                         "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "503": {
+                        "description": "Database unavailable",
                         "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
             }
         },
-        "/auth/logout": {
-            "post": {
+        "/admin/migrations": {
+            "get": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "ApiKeyAuth": []
                     }
                 ],
-                "description": "Invalidate the current session token",
+                "description": "Get the database schema version and the migrations applied to it",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Logout",
+                "summary": "Get migration status",
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                    "200": {
+                        "description": "Migration status",
                         "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
+                            "$ref": "#/definitions/model.MigrationStatusResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/health": {
-            "get": {
-                "description": "Check if the API is healthy and running",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "health"
-                ],
-                "summary": "Health check",
-                "responses": {
-                    "200": {
-                        "description": "Health status",
+                    },
+                    "503": {
+                        "description": "Database unavailable",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -158,14 +127,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/recurring": {
-            "get": {
+        "/admin/recurring/repair": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get all recurring transaction rules for the authenticated user",
+                "description": "Move every active rule whose next due date is in the past to its first occurrence strictly after today (in the owner's timezone), without creating the missed transactions",
                 "consumes": [
                     "application/json"
                 ],
@@ -173,15 +142,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "recurring"
+                    "admin"
                 ],
-                "summary": "Get all recurring transactions",
+                "summary": "Repair overdue recurring rules",
                 "responses": {
                     "200": {
-                        "description": "List of recurring transactions",
+                        "description": "Number of rules checked and repaired",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.RepairRecurringResponse"
                         }
                     },
                     "500": {
@@ -192,14 +160,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/recurring/{id}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new recurring transaction rule with optional tag associations",
+                "description": "Permanently delete a recurring transaction rule, including soft-deleted ones, with its tags and history. Transactions it generated are kept but unlinked from it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -207,30 +177,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "recurring"
+                    "admin"
                 ],
-                "summary": "Create a new recurring transaction",
+                "summary": "Permanently delete a recurring transaction",
                 "parameters": [
                     {
-                        "description": "Recurring transaction data",
-                        "name": "recurring",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.CreateRecurringRequest"
-                        }
+                        "type": "integer",
+                        "description": "Recurring transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Recurring transaction created successfully",
+                    "204": {
+                        "description": "Recurring transaction deleted successfully"
+                    },
+                    "400": {
+                        "description": "Invalid recurring transaction ID",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Invalid request data",
+                    "404": {
+                        "description": "Recurring transaction not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -246,14 +217,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/recurring/active": {
-            "get": {
+        "/admin/run-scheduler": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get all active recurring transaction rules for the authenticated user",
+                "description": "Manually trigger the scheduler to process recurring transactions due today",
                 "consumes": [
                     "application/json"
                 ],
@@ -261,12 +232,19 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "recurring"
+                    "admin"
                 ],
-                "summary": "Get active recurring transactions",
+                "summary": "Run the scheduler",
                 "responses": {
                     "200": {
-                        "description": "List of active recurring transactions",
+                        "description": "Scheduler execution result",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Scheduler already running",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -282,66 +260,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/recurring/by-tag/{tag_id}": {
+        "/auth/check": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get all recurring transaction rules associated with a specific tag",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Confirm the X-API-Key header is valid without fetching any data, returning the user it acts as",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "recurring"
-                ],
-                "summary": "Get recurring transactions by tag",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Tag ID",
-                        "name": "tag_id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "auth"
                 ],
+                "summary": "Check API key",
                 "responses": {
                     "200": {
-                        "description": "List of recurring transactions",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.UserResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid tag ID",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/recurring/due": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Get all recurring transaction rules that are due on a specific date",
+        "/auth/login": {
+            "post": {
+                "description": "Authenticate with email and password, receive a session token",
                 "consumes": [
                     "application/json"
                 ],
@@ -349,50 +307,73 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "recurring"
+                    "auth"
                 ],
-                "summary": "Get recurring transactions due on a date",
+                "summary": "Login",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Date to check (YYYY-MM-DD format, defaults to today)",
-                        "name": "date",
-                        "in": "query"
+                        "description": "Login credentials",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LoginRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of recurring transactions due on the date",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.LoginResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid date format",
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/recurring/{id}": {
-            "get": {
+        "/auth/logout": {
+            "post": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Get a specific recurring transaction rule by its ID",
+                "description": "Invalidate the current session token",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Logout",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Create a user account with an email and password; the password must meet the password policy. Only available when ALLOW_REGISTRATION is true.",
                 "consumes": [
                     "application/json"
                 ],
@@ -400,56 +381,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "recurring"
+                    "auth"
                 ],
-                "summary": "Get recurring transaction by ID",
+                "summary": "Register",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Recurring transaction ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Account details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RegisterRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Recurring transaction details",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.UserResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid recurring transaction ID",
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Recurring transaction not found",
+                    "409": {
+                        "description": "Email already registered",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "422": {
+                        "description": "Password does not meet policy",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Delete a recurring transaction rule",
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Check if the API is healthy and running",
                 "consumes": [
                     "application/json"
                 ],
@@ -457,52 +433,63 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "recurring"
+                    "health"
                 ],
-                "summary": "Delete a recurring transaction",
-                "parameters": [
+                "summary": "Health check",
+                "responses": {
+                    "200": {
+                        "description": "Health status",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/me": {
+            "get": {
+                "security": [
                     {
-                        "type": "integer",
-                        "description": "Recurring transaction ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "BearerAuth": []
                     }
                 ],
+                "description": "Get the authenticated user's account",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get profile",
                 "responses": {
-                    "204": {
-                        "description": "Recurring transaction deleted successfully"
-                    },
-                    "400": {
-                        "description": "Invalid recurring transaction ID",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.UserResponse"
                         }
                     },
-                    "404": {
-                        "description": "Recurring transaction not found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
             },
-            "patch": {
+            "delete": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing recurring transaction rule",
+                "description": "Permanently delete the authenticated user and all their data; password must match the existing password",
                 "consumes": [
                     "application/json"
                 ],
@@ -510,67 +497,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "recurring"
+                    "users"
                 ],
-                "summary": "Update a recurring transaction",
+                "summary": "Delete account",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Recurring transaction ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update data",
-                        "name": "recurring",
+                        "description": "Password confirmation",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UpdateRecurringRequest"
+                            "$ref": "#/definitions/model.DeleteAccountRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Recurring transaction updated successfully",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request data",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Recurring transaction not found",
+                    "403": {
+                        "description": "Password is incorrect",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/recurring/{id}/toggle": {
+            },
             "patch": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Toggle the active status of a recurring transaction rule",
+                "description": "Change the authenticated user's email and/or password; current_password must match the existing password. A password change signs out every other session.",
                 "consumes": [
                     "application/json"
                 ],
@@ -578,109 +555,99 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "recurring"
+                    "users"
                 ],
-                "summary": "Toggle recurring transaction active status",
+                "summary": "Update profile",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Recurring transaction ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Fields to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateProfileRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Recurring transaction status toggled successfully",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid recurring transaction ID",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Current password is incorrect",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Recurring transaction not found",
+                        "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "409": {
+                        "description": "Email already registered",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Password does not meet policy",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/reports/monthly": {
+        "/meta/frequencies": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get a detailed monthly report with totals and breakdown by tags",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List the frequencies recurring rules accept, the unit interval_n counts in, the largest allowed interval_n, and an example of the next due date",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reports"
-                ],
-                "summary": "Get monthly report",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Year-month in YYYY-MM format (defaults to current month)",
-                        "name": "ym",
-                        "in": "query"
-                    }
+                    "meta"
                 ],
+                "summary": "List recurring frequencies",
                 "responses": {
                     "200": {
-                        "description": "Monthly report data",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid year-month format",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
+                        "description": "Supported frequencies",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.FrequencyResponse"
+                            }
                         }
                     }
                 }
             }
         },
-        "/reports/monthly/totals": {
+        "/recurring": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get monthly income/expense totals and transaction count",
+                "description": "Get all recurring transaction rules for the authenticated user",
                 "consumes": [
                     "application/json"
                 ],
@@ -688,63 +655,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reports"
+                    "recurring"
                 ],
-                "summary": "Get monthly totals",
+                "summary": "Get all recurring transactions",
                 "parameters": [
                     {
+                        "enum": [
+                            "daily",
+                            "weekly",
+                            "monthly",
+                            "yearly"
+                        ],
                         "type": "string",
-                        "description": "Year-month in YYYY-MM format (defaults to current month)",
-                        "name": "ym",
+                        "description": "Only return rules with this frequency",
+                        "name": "frequency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of rules to return (max 100 or the page_max setting; default the page_default setting, else page_max if set, else all)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rules to skip (default 0)",
+                        "name": "offset",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Monthly totals data",
+                        "description": "List of recurring transactions",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Number of rules matching the filters, ignoring limit and offset"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid year-month format",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    }
-                }
-            }
-        },
-        "/tags": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Get all available tags for the authenticated user",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "tags"
-                ],
-                "summary": "Get all tags",
-                "responses": {
-                    "200": {
-                        "description": "List of tags",
+                        "description": "Invalid filter or pagination parameters",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -765,7 +720,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new tag for categorizing transactions",
+                "description": "Create a new recurring transaction rule with optional tag associations",
                 "consumes": [
                     "application/json"
                 ],
@@ -773,23 +728,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tags"
+                    "recurring"
                 ],
-                "summary": "Create a new tag",
+                "summary": "Create a new recurring transaction",
                 "parameters": [
                     {
-                        "description": "Tag data",
-                        "name": "tag",
+                        "description": "Recurring transaction data",
+                        "name": "recurring",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CreateTagRequest"
+                            "$ref": "#/definitions/model.CreateRecurringRequest"
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Allow a first_due_date more than 90 days (or the recurring_max_backfill_days setting) ago",
+                        "name": "backfill",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Tag created successfully",
+                    "201": {
+                        "description": "ID of the created recurring transaction",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -812,14 +773,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tags/{id}": {
-            "delete": {
+        "/recurring/active": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Delete an existing tag",
+                "description": "Get all active recurring transaction rules for the authenticated user",
                 "consumes": [
                     "application/json"
                 ],
@@ -827,31 +788,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tags"
+                    "recurring"
                 ],
-                "summary": "Delete a tag",
+                "summary": "Get active recurring transactions",
                 "parameters": [
+                    {
+                        "enum": [
+                            "daily",
+                            "weekly",
+                            "monthly",
+                            "yearly"
+                        ],
+                        "type": "string",
+                        "description": "Only return rules with this frequency",
+                        "name": "frequency",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Tag ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Maximum number of rules to return (max 100 or the page_max setting; default the page_default setting, else page_max if set, else all)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rules to skip (default 0)",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Tag deleted successfully"
-                    },
-                    "400": {
-                        "description": "Invalid tag ID",
+                    "200": {
+                        "description": "List of active recurring transactions",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Number of rules matching the filters, ignoring limit and offset"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Tag not found",
+                    "400": {
+                        "description": "Invalid filter or pagination parameters",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -865,14 +846,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/recurring/by-tag/{tag_id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update an existing tag's name",
+                "description": "Get all recurring transaction rules associated with a specific tag",
                 "consumes": [
                     "application/json"
                 ],
@@ -880,48 +863,64 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tags"
+                    "recurring"
                 ],
-                "summary": "Update a tag",
+                "summary": "Get recurring transactions by tag",
                 "parameters": [
                     {
                         "type": "integer",
                         "description": "Tag ID",
-                        "name": "id",
+                        "name": "tag_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Tag update data",
-                        "name": "tag",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.UpdateTagRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Tag updated successfully",
+                        "description": "List of recurring transactions",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid request data",
+                        "description": "Invalid tag ID",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Tag not found",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
+                    }
+                }
+            }
+        },
+        "/recurring/calendar.ics": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get every active rule of the user as one calendar with a VEVENT series per rule, suitable for subscribing to upcoming bills. Each event summary carries the description and amount.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "recurring"
+                ],
+                "summary": "Export all active recurring transactions as an iCalendar feed",
+                "responses": {
+                    "200": {
+                        "description": "iCalendar feed",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
                     "500": {
                         "description": "Internal server error",
@@ -933,14 +932,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/transactions": {
+        "/recurring/due": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get all transactions for the authenticated user, optionally filtered by date range",
+                "description": "Get all recurring transaction rules that are due on a specific date",
                 "consumes": [
                     "application/json"
                 ],
@@ -948,26 +947,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "recurring"
                 ],
-                "summary": "Get transactions",
+                "summary": "Get recurring transactions due on a date",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Start date (YYYY-MM-DD format)",
-                        "name": "from",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "End date (YYYY-MM-DD format)",
-                        "name": "to",
+                        "description": "Date to check (YYYY-MM-DD format, defaults to today)",
+                        "name": "date",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of transactions",
+                        "description": "List of recurring transactions due on the date",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -988,48 +981,31 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/recurring/groups": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new transaction with optional tag associations",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Summarise active recurring rules by group with their count and monthly-equivalent cost over the next twelve months, prorating rules that end within them. Ungrouped rules are reported last with a null group.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
-                ],
-                "summary": "Create a new transaction",
-                "parameters": [
-                    {
-                        "description": "Transaction data",
-                        "name": "transaction",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.CreateTransactionRequest"
-                        }
-                    }
+                    "recurring"
                 ],
+                "summary": "Get recurring rule groups",
                 "responses": {
                     "200": {
-                        "description": "Transaction created successfully",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request data",
+                        "description": "Recurring groups",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.RecurringGroupSummary"
+                            }
                         }
                     },
                     "500": {
@@ -1042,43 +1018,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/transactions/by-recurring/{recurring_id}": {
+        "/recurring/stale": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get all transactions that were created from a specific recurring rule",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get active rules whose most recent generated transaction is older than the given number of days, or that have never generated one despite being first due before then. These are often forgotten subscriptions. Rules that never generated a transaction are listed first, then the longest-idle ones.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "recurring"
                 ],
-                "summary": "Get transactions by recurring ID",
+                "summary": "Find stale recurring transactions",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Recurring rule ID",
-                        "name": "recurring_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Days without a generated transaction (1-3650, default 90)",
+                        "name": "days",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of transactions",
+                        "description": "Stale recurring transactions",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.StaleRecurringResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid recurring ID",
+                        "description": "Invalid days",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1094,43 +1065,44 @@ const docTemplate = `{
                 }
             }
         },
-        "/transactions/by-tag/{tag_id}": {
+        "/recurring/upcoming": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get all transactions associated with a specific tag",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Project each active rule's due dates between from and from+days (inclusive) with a running total. Overdue occurrences before from are not included.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "recurring"
                 ],
-                "summary": "Get transactions by tag",
+                "summary": "Forecast upcoming recurring transactions",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Tag ID",
-                        "name": "tag_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Number of days to look ahead (1-366, default 30)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the window (YYYY-MM-DD, defaults to today)",
+                        "name": "from",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of transactions",
+                        "description": "Projected occurrences",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.UpcomingRecurringResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid tag ID",
+                        "description": "Invalid parameters",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1146,14 +1118,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/transactions/purge": {
-            "post": {
+        "/recurring/{id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Permanently delete transactions that were soft deleted before a specified date",
+                "description": "Get a specific recurring transaction rule by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1161,53 +1133,56 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "recurring"
                 ],
-                "summary": "Purge soft deleted transactions",
+                "summary": "Get recurring transaction by ID",
                 "parameters": [
                     {
-                        "description": "Purge request data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.PurgeTransactionsRequest"
-                        }
+                        "type": "integer",
+                        "description": "Recurring transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Purge completed successfully",
+                        "description": "Recurring transaction details",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid request data",
+                        "description": "Invalid recurring transaction ID",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Recurring transaction not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
-                    }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
                 }
-            }
-        },
-        "/transactions/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get a specific transaction by its ID",
+                "description": "Soft-delete a recurring transaction rule. It stops generating transactions and is hidden from listings, but transactions it generated can still look it up by ID.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1215,35 +1190,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "recurring"
                 ],
-                "summary": "Get transaction by ID",
+                "summary": "Delete a recurring transaction",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Transaction ID",
+                        "description": "Recurring transaction ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Transaction details",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
+                    "204": {
+                        "description": "Recurring transaction deleted successfully"
                     },
                     "400": {
-                        "description": "Invalid transaction ID",
+                        "description": "Invalid recurring transaction ID",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "Transaction not found",
+                        "description": "Recurring transaction not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1264,7 +1235,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update an existing transaction's note, tags, or soft delete it",
+                "description": "Update an existing recurring transaction rule",
                 "consumes": [
                     "application/json"
                 ],
@@ -1272,37 +1243,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "recurring"
                 ],
-                "summary": "Update a transaction",
+                "summary": "Update a recurring transaction",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Transaction ID",
+                        "description": "Recurring transaction ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "description": "Update data",
-                        "name": "transaction",
+                        "name": "recurring",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UpdateTransactionRequest"
+                            "$ref": "#/definitions/model.UpdateRecurringRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Transaction updated successfully",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
                     "204": {
-                        "description": "Transaction deleted successfully"
+                        "description": "Recurring transaction updated successfully"
                     },
                     "400": {
                         "description": "Invalid request data",
@@ -1312,7 +1276,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Transaction not found",
+                        "description": "Recurring transaction not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1328,46 +1292,69 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
+        "/recurring/{id}/calendar.ics": {
             "get": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "ApiKeyAuth": []
                     }
                 ],
-                "description": "List all users",
+                "description": "Get the rule as an all-day VEVENT series that calendar apps can import or subscribe to. The RRULE follows the rule's frequency and interval, starting on first_due_date and ending on end_date if set.",
                 "produces": [
-                    "application/json"
+                    "text/calendar"
                 ],
                 "tags": [
-                    "users"
+                    "recurring"
+                ],
+                "summary": "Export a recurring transaction as an iCalendar feed",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Recurring transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List users",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "iCalendar feed",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.UserResponse"
-                            }
+                            "type": "string"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Invalid recurring transaction ID",
                         "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Recurring transaction not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
-            },
+            }
+        },
+        "/recurring/{id}/clone": {
             "post": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new user with hashed password",
+                "description": "Copy a recurring rule, including its tags, into a new active rule whose next due date is its first due date. Fields in the optional body override the copied values; tag_ids replaces the copied tags.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1375,347 +1362,4163 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "recurring"
                 ],
-                "summary": "Create user",
+                "summary": "Clone a recurring rule",
                 "parameters": [
                     {
-                        "description": "User details",
-                        "name": "request",
+                        "type": "integer",
+                        "description": "Recurring rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to override",
+                        "name": "overrides",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CreateUserRequest"
+                            "$ref": "#/definitions/model.CloneRecurringRequest"
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Allow a first due date more than 90 days (or the recurring_max_backfill_days setting) ago",
+                        "name": "backfill",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Created",
+                        "description": "ID of the new recurring rule",
                         "schema": {
-                            "$ref": "#/definitions/model.UserResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid request data",
                         "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Recurring rule not found",
                         "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
             }
         },
-        "/users/{id}": {
+        "/recurring/{id}/pause-history": {
             "get": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get a user by ID",
+                "description": "Get the pause/resume/skip actions taken on a recurring transaction rule, oldest first",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "recurring"
                 ],
-                "summary": "Get user",
+                "summary": "Get pause history of a recurring transaction",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Recurring transaction ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of entries to return (default 50 or the page_default setting, max 100 or the page_max setting)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entries to skip (default 0)",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Recurring transaction history",
                         "schema": {
-                            "$ref": "#/definitions/model.UserResponse"
+                            "$ref": "#/definitions/model.RecurringHistoryResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Invalid recurring transaction ID or pagination parameters",
                         "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Recurring transaction not found",
                         "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/recurring/{id}/reschedule": {
+            "post": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "ApiKeyAuth": []
                     }
                 ],
-                "description": "Delete a user and invalidate all their sessions",
+                "description": "Set the rule's next due date, defaulting to its first due date. The date must be today or later.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "recurring"
                 ],
-                "summary": "Delete user",
+                "summary": "Reset a recurring rule's schedule",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Recurring rule ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "New next due date",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.RescheduleRecurringRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "Recurring rule rescheduled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Invalid request data",
                         "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Recurring rule not found",
                         "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/recurring/{id}/tags/{tag_id}": {
+            "post": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update a user's email and/or password",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Attach one tag to a recurring rule, leaving its other tags in place. Adding a tag the rule already has is a no-op.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "recurring"
                 ],
-                "summary": "Update user",
+                "summary": "Add a tag to a recurring transaction",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Recurring transaction ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Fields to update",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.UpdateUserRequest"
-                        }
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "tag_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "The rule's tag IDs after the change",
                         "schema": {
-                            "$ref": "#/definitions/model.UserResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid recurring rule or tag ID",
                         "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Recurring transaction not found",
                         "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Detach one tag from a recurring rule, leaving its other tags in place",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring"
+                ],
+                "summary": "Remove a tag from a recurring transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Recurring transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "tag_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Tag removed"
+                    },
+                    "400": {
+                        "description": "Invalid recurring rule or tag ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Recurring transaction not found or tag not on it",
                         "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "model.CreateRecurringRequest": {
+        },
+        "/recurring/{id}/toggle": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Toggle the active status of a recurring transaction rule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring"
+                ],
+                "summary": "Toggle recurring transaction active status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Recurring transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Recurring transaction status toggled successfully"
+                    },
+                    "400": {
+                        "description": "Invalid recurring transaction ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Recurring transaction not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/recurring/{id}/transactions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the transactions created from a recurring transaction rule along with their count and total",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring"
+                ],
+                "summary": "Get transactions generated by a recurring transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Recurring transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Generated transactions with totals",
+                        "schema": {
+                            "$ref": "#/definitions/model.RecurringTransactionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid recurring transaction ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Recurring transaction not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/balance": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the running balance at the end of each day with transactions between from and to (inclusive), starting from the balance of all earlier transactions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get balance over time",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date in YYYY-MM-DD format (defaults to the first day of the current month)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date in YYYY-MM-DD format (defaults to today)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Balance series",
+                        "schema": {
+                            "$ref": "#/definitions/model.BalanceReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date range",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/compare": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a month's report alongside the previous month's, with the change in totals overall and per tag (this month minus last month). January is compared with the previous December.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Compare a month with the previous month",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Year-month in YYYY-MM format (defaults to current month)",
+                        "name": "ym",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Month-over-month comparison",
+                        "schema": {
+                            "$ref": "#/definitions/model.MonthlyComparisonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid year-month format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/forecast": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Estimate a month's totals as the transactions booked so far plus the occurrences of active recurring rules still due before the month ends. Projection starts today for the current month and on the 1st for future months; past months are not projected.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Forecast the end-of-month totals",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Year-month in YYYY-MM format (defaults to current month)",
+                        "name": "ym",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Month forecast",
+                        "schema": {
+                            "$ref": "#/definitions/model.ForecastResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid year-month format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/monthly": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a detailed monthly report with totals and breakdown by tags",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get monthly report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Year-month in YYYY-MM format (defaults to current month)",
+                        "name": "ym",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only count transactions carrying this tag; by_tag then lists every tag those transactions carry",
+                        "name": "tag_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "in",
+                            "out",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Only count income (in) or spending (out) (default all)",
+                        "name": "direction",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Set to csv for a CSV with one row per tag and a totals row, told apart by the row column (Accept: text/csv works too)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Monthly report data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid year-month format, tag ID or direction",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/monthly/totals": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get monthly income/expense totals, transaction count, and the smallest, largest and average expense (0 when the month has no expenses)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get monthly totals",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Year-month in YYYY-MM format (defaults to current month)",
+                        "name": "ym",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "in",
+                            "out",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Only count income (in) or spending (out) (default all)",
+                        "name": "direction",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Monthly totals data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid year-month format or direction",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/net": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get income, spending and net (in minus out) for each period from from to to (inclusive). Periods without transactions are reported as zero. Weeks are ISO weeks (Monday to Sunday); the first and last may extend past the range, but only transactions inside it are counted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get net income per month, week or day",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start of the range as YYYY-MM (its first day) or YYYY-MM-DD (defaults to 11 months before to)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range as YYYY-MM (its last day) or YYYY-MM-DD (defaults to current month)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "month",
+                            "week",
+                            "day"
+                        ],
+                        "type": "string",
+                        "description": "Period to total by (default month)",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "in",
+                            "out",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Only count income (in) or spending (out) (default all)",
+                        "name": "direction",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Net per period",
+                        "schema": {
+                            "$ref": "#/definitions/model.NetByPeriodResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid range, group_by or direction",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/net-by-month": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get income, spending and net (in minus out) for each period from from to to (inclusive). Periods without transactions are reported as zero. Weeks are ISO weeks (Monday to Sunday); the first and last may extend past the range, but only transactions inside it are counted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get net income per month, week or day",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start of the range as YYYY-MM (its first day) or YYYY-MM-DD (defaults to 11 months before to)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range as YYYY-MM (its last day) or YYYY-MM-DD (defaults to current month)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "month",
+                            "week",
+                            "day"
+                        ],
+                        "type": "string",
+                        "description": "Period to total by (default month)",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "in",
+                            "out",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Only count income (in) or spending (out) (default all)",
+                        "name": "direction",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Net per period",
+                        "schema": {
+                            "$ref": "#/definitions/model.NetByPeriodResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid range, group_by or direction",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/top-tags": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the tags with the highest outgoing spend in a month, highest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get top spending tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Year-month in YYYY-MM format (defaults to current month)",
+                        "name": "ym",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of tags to return (1-50, default 5)",
+                        "name": "n",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Top spending tags",
+                        "schema": {
+                            "$ref": "#/definitions/model.TopTagsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/tag-alerts": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the monthly spending thresholds set on tags",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "List tag spending alerts",
+                "responses": {
+                    "200": {
+                        "description": "Tag alerts",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.TagAlertResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Alert (via the webhook) when the month's spending on a tag reaches the threshold. A tag can have one alert.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Create a tag spending alert",
+                "parameters": [
+                    {
+                        "description": "Tag and threshold",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateTagAlertRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Tag alert created",
+                        "schema": {
+                            "$ref": "#/definitions/model.TagAlertResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Tag already has an alert",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/tag-alerts/evaluate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Compare the month's spending on each alerted tag with its threshold. Alerts that have reached it and not yet fired that month are sent to the webhook, if configured, and returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Evaluate tag spending alerts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Year-month in YYYY-MM format (defaults to current month)",
+                        "name": "ym",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Alerts that fired",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.TagAlertEventResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid year-month format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/tag-alerts/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a tag alert",
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Delete a tag spending alert",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tag alert ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Tag alert deleted"
+                    },
+                    "400": {
+                        "description": "Invalid tag alert ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Tag alert not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Change a tag alert's monthly threshold",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Update a tag spending alert",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tag alert ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New threshold",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateTagAlertRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Tag alert updated"
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Tag alert not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get all available tags for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Get all tags",
+                "responses": {
+                    "200": {
+                        "description": "List of tags",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new tag for categorizing transactions. The name \"Untagged\" is reserved for reports.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Create a new tag",
+                "parameters": [
+                    {
+                        "description": "Tag data",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateTagRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return the existing tag with 200 when the name is taken, instead of 409",
+                        "name": "upsert",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Existing tag returned (upsert only)",
+                        "schema": {
+                            "$ref": "#/definitions/model.TagResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Tag created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.TagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Tag already exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Find tags whose name contains the query, ignoring case, for autocomplete. Tags starting with the query come first; at most 20 are returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Search tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Text to search tag names for",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Matching tags",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Missing query",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete an existing tag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Delete a tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Tag deleted successfully"
+                    },
+                    "400": {
+                        "description": "Invalid tag ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Tag not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update an existing tag's name. Reports group by tag ID, so they show the new name straight away. The name \"Untagged\" is reserved for reports.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Update a tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag update data",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Tag updated successfully"
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Tag not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{id}/reassign": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Move every transaction from this tag to another tag. The source tag is kept. Transactions that already carry the target tag are skipped.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Reassign a tag's transactions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Source tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target tag",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReassignTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Number of transactions moved",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReassignTagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Tag not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get all transactions for the authenticated user, optionally filtered by date range and by when they were entered",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Get transactions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD format)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD format)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only transactions entered on or after this date (YYYY-MM-DD format)",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only transactions entered on or before this date (YYYY-MM-DD format)",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only cleared (true) or uncleared (false) transactions",
+                        "name": "cleared",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tag IDs, e.g. 1,2,3",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "any (default) matches transactions with at least one of the tags, all those with every tag",
+                        "name": "tag_mode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of transactions, with the number returned in meta.count",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Number of transactions matching the filters"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new transaction with optional tag associations",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Create a new transaction",
+                "parameters": [
+                    {
+                        "description": "Transaction data",
+                        "name": "transaction",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateTransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ID of the existing transaction, with skipped true, when external_id was already imported",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "201": {
+                        "description": "ID of the created transaction",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/activity": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get each date in the range that has at least one transaction, with that day's transaction count and net amount, for a calendar heatmap. Days without transactions are not listed. The range may span up to 366 days.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Get days with transactions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD, defaults to the first of the current month)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD, defaults to today)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Days with transactions",
+                        "schema": {
+                            "$ref": "#/definitions/model.ActivityResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date range",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/bulk-delete": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Soft delete several transactions at once. Every ID must be a live transaction of the user; if any is not, nothing is deleted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Soft delete many transactions",
+                "parameters": [
+                    {
+                        "description": "Transaction IDs",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BulkDeleteTransactionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Number of transactions deleted",
+                        "schema": {
+                            "$ref": "#/definitions/model.BulkDeleteTransactionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/by-recurring/{recurring_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get all transactions that were created from a specific recurring rule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Get transactions by recurring ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Recurring rule ID",
+                        "name": "recurring_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of transactions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid recurring ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/by-tag-grouped": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the user's transactions grouped by tag, optionally filtered by date range. A transaction with several tags appears in each of their groups; transactions without tags are returned in a final \"untagged\" group.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Get transactions grouped by tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD format)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD format)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transactions grouped by tag",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.TagTransactionsGroup"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/by-tag/{tag_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get all transactions associated with a specific tag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Get transactions by tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "tag_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of transactions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tag ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/clear": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Soft delete all of the user's transactions (recurring rules are kept). Requires an explicit confirm flag.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Clear all transactions",
+                "parameters": [
+                    {
+                        "description": "Confirmation",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ClearTransactionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Number of transactions cleared",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Missing confirmation",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/purge": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Permanently delete the user's transactions that were soft deleted before a specified date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Purge soft deleted transactions",
+                "parameters": [
+                    {
+                        "description": "Purge request data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PurgeTransactionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Purge completed successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/reconcile": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Set the cleared flag on many transactions at once. Every ID must belong to the user; if any does not, nothing is changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Mark transactions cleared or uncleared",
+                "parameters": [
+                    {
+                        "description": "Transaction IDs and cleared state",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReconcileTransactionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Number of transactions updated",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReconcileTransactionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/trash/empty": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Permanently delete all of the user's soft-deleted transactions, regardless of when they were deleted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Empty the transaction trash",
+                "responses": {
+                    "200": {
+                        "description": "Number of transactions purged",
+                        "schema": {
+                            "$ref": "#/definitions/model.EmptyTrashResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a specific transaction by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Get transaction by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transaction details",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid transaction ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update an existing transaction's note, tags or cleared flag, or soft delete it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Update a transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update data",
+                        "name": "transaction",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateTransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transaction updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "204": {
+                        "description": "Transaction deleted successfully"
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Version does not match the stored transaction",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/make-recurring": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a recurring rule with the transaction's amount, note and tags on the given schedule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Convert a transaction into a recurring rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Recurring schedule",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MakeRecurringRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Allow a first_due_date more than 90 days (or the recurring_max_backfill_days setting) ago",
+                        "name": "backfill",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "ID of the created recurring rule",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/purge": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Permanently delete a single soft-deleted transaction straight away. Live transactions cannot be purged.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Purge one soft deleted transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Transaction purged"
+                    },
+                    "400": {
+                        "description": "Invalid transaction ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Soft deleted transaction not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/receipts": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the receipt references attached to a transaction, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "List a transaction's receipts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Receipts",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ReceiptResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid transaction ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Store a reference (URL and content type) to a receipt kept elsewhere. The file itself is not uploaded.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Attach a receipt to a transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Receipt reference",
+                        "name": "receipt",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateReceiptRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Receipt attached",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReceiptResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/receipts/{receipt_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a receipt reference. The file it points to is not touched.",
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Remove a receipt from a transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Receipt ID",
+                        "name": "receipt_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Receipt deleted"
+                    },
+                    "400": {
+                        "description": "Invalid transaction or receipt ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction or receipt not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/tags/{tag_id}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Attach one tag to a transaction, leaving its other tags in place. Adding a tag the transaction already has is a no-op.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Add a tag to a transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "tag_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The transaction's tag IDs after the change",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid transaction or tag ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction or tag not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Detach one tag from a transaction, leaving its other tags in place",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Remove a tag from a transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "tag_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Tag removed"
+                    },
+                    "400": {
+                        "description": "Invalid transaction or tag ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction or tag not found, or tag not on the transaction",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/toggle-cleared": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mark a transaction as cleared against a bank statement, or uncleared if it already was",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Toggle transaction cleared status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Cleared status toggled"
+                    },
+                    "400": {
+                        "description": "Invalid transaction ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List all users",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List users",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.UserResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new user with hashed password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Create user",
+                "parameters": [
+                    {
+                        "description": "User details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Password does not meet policy",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a user by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a user and invalidate all their sessions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a user's email, password and/or timezone (an IANA name such as Europe/London)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Password does not meet policy",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/version": {
+            "get": {
+                "description": "Get the version, git commit and build date of the running binary. Values not set at build time read \"dev\".",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Build information",
+                "responses": {
+                    "200": {
+                        "description": "Build information",
+                        "schema": {
+                            "$ref": "#/definitions/buildinfo.Info"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "buildinfo.Info": {
+            "type": "object",
+            "properties": {
+                "build_date": {
+                    "type": "string"
+                },
+                "commit": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ActivityResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DailyActivity"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AppliedMigration": {
+            "type": "object",
+            "properties": {
+                "applied_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.BalancePoint": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "string"
+                },
+                "balance_pence": {
+                    "type": "integer"
+                },
+                "change": {
+                    "type": "string"
+                },
+                "change_pence": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BalanceReportResponse": {
+            "type": "object",
+            "properties": {
+                "closing_balance": {
+                    "type": "string"
+                },
+                "closing_balance_pence": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "opening_balance": {
+                    "type": "string"
+                },
+                "opening_balance_pence": {
+                    "type": "integer"
+                },
+                "series": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BalancePoint"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BulkDeleteTransactionsRequest": {
+            "type": "object",
+            "required": [
+                "transaction_ids"
+            ],
+            "properties": {
+                "transaction_ids": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "model.BulkDeleteTransactionsResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ClearTransactionsRequest": {
+            "type": "object",
+            "required": [
+                "confirm"
+            ],
+            "properties": {
+                "confirm": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.CloneRecurringRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "first_due_date": {
+                    "type": "string"
+                },
+                "frequency": {
+                    "type": "string"
+                },
+                "group": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "interval_n": {
+                    "type": "integer",
+                    "maximum": 365,
+                    "minimum": 1
+                },
+                "tag_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "model.CreateReceiptRequest": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "content_type": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048
+                }
+            }
+        },
+        "model.CreateRecurringRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "description",
+                "first_due_date",
+                "frequency",
+                "interval_n"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "first_due_date": {
+                    "type": "string"
+                },
+                "frequency": {
+                    "type": "string"
+                },
+                "group": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "interval_n": {
+                    "type": "integer",
+                    "maximum": 365,
+                    "minimum": 1
+                },
+                "tag_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "model.CreateTagAlertRequest": {
+            "type": "object",
+            "required": [
+                "tag_id",
+                "threshold"
+            ],
+            "properties": {
+                "tag_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "threshold": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CreateTagRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                }
+            }
+        },
+        "model.CreateTransactionRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "t_date"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "external_id": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "t_date": {
+                    "type": "string"
+                },
+                "tag_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "model.CreateUserRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "is_service": {
+                    "type": "boolean"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.DBStatsResponse": {
+            "type": "object",
+            "properties": {
+                "idle": {
+                    "type": "integer"
+                },
+                "in_use": {
+                    "type": "integer"
+                },
+                "max_idle_closed": {
+                    "type": "integer"
+                },
+                "max_idle_time_closed": {
+                    "type": "integer"
+                },
+                "max_lifetime_closed": {
+                    "type": "integer"
+                },
+                "max_open_connections": {
+                    "type": "integer"
+                },
+                "open_connections": {
+                    "type": "integer"
+                },
+                "wait_count": {
+                    "type": "integer"
+                },
+                "wait_duration_ms": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.DailyActivity": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "net": {
+                    "type": "string"
+                },
+                "net_pence": {
+                    "type": "integer"
+                },
+                "transaction_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.DeleteAccountRequest": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.EmptyTrashResponse": {
+            "type": "object",
+            "properties": {
+                "purged": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ForecastResponse": {
+            "type": "object",
+            "properties": {
+                "actual": {
+                    "$ref": "#/definitions/model.ForecastTotals"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "estimated": {
+                    "$ref": "#/definitions/model.ForecastTotals"
+                },
+                "occurrences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.UpcomingOccurrence"
+                    }
+                },
+                "projected": {
+                    "$ref": "#/definitions/model.ForecastTotals"
+                },
+                "projected_from": {
+                    "type": "string"
+                },
+                "year_month": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ForecastTotals": {
+            "type": "object",
+            "properties": {
+                "net": {
+                    "type": "string"
+                },
+                "net_pence": {
+                    "type": "integer"
+                },
+                "total_in": {
+                    "type": "string"
+                },
+                "total_in_pence": {
+                    "type": "integer"
+                },
+                "total_out": {
+                    "type": "string"
+                },
+                "total_out_pence": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.FrequencyExample": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "interval_n": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FrequencyResponse": {
+            "type": "object",
+            "properties": {
+                "example": {
+                    "$ref": "#/definitions/model.FrequencyExample"
+                },
+                "max_interval": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 1
+                }
+            }
+        },
+        "model.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.MakeRecurringRequest": {
+            "type": "object",
+            "required": [
+                "first_due_date",
+                "frequency",
+                "interval_n"
+            ],
+            "properties": {
+                "end_date": {
+                    "type": "string"
+                },
+                "first_due_date": {
+                    "type": "string"
+                },
+                "frequency": {
+                    "type": "string"
+                },
+                "interval_n": {
+                    "type": "integer",
+                    "maximum": 365,
+                    "minimum": 1
+                }
+            }
+        },
+        "model.MigrateResponse": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.MigrationStatusResponse": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AppliedMigration"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.MonthlyComparisonResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "current": {
+                    "$ref": "#/definitions/model.MonthlyReportResponse"
+                },
+                "delta": {
+                    "$ref": "#/definitions/model.TagReportEntry"
+                },
+                "delta_by_tag": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/model.TagReportEntry"
+                    }
+                },
+                "previous": {
+                    "$ref": "#/definitions/model.MonthlyReportResponse"
+                },
+                "previous_year_month": {
+                    "type": "string"
+                },
+                "year_month": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.MonthlyReportResponse": {
+            "type": "object",
+            "properties": {
+                "by_tag": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/model.TagReportEntry"
+                    }
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "total_in": {
+                    "type": "string"
+                },
+                "total_in_pence": {
+                    "type": "integer"
+                },
+                "total_out": {
+                    "type": "string"
+                },
+                "total_out_pence": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.NetByPeriodResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "group_by": {
+                    "type": "string"
+                },
+                "periods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PeriodNet"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PeriodNet": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "string"
+                },
+                "net": {
+                    "type": "string"
+                },
+                "net_pence": {
+                    "type": "integer"
+                },
+                "period": {
+                    "type": "string"
+                },
+                "start": {
+                    "type": "string"
+                },
+                "total_in": {
+                    "type": "string"
+                },
+                "total_in_pence": {
+                    "type": "integer"
+                },
+                "total_out": {
+                    "type": "string"
+                },
+                "total_out_pence": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PurgeTransactionsRequest": {
+            "type": "object",
+            "required": [
+                "cutoff_date"
+            ],
+            "properties": {
+                "cutoff_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReassignTagRequest": {
+            "type": "object",
+            "required": [
+                "to_tag_id"
+            ],
+            "properties": {
+                "to_tag_id": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "model.ReassignTagResponse": {
+            "type": "object",
+            "properties": {
+                "moved": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ReceiptResponse": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "transaction_id": {
+                    "type": "integer"
+                },
+                "uploaded_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReconcileTransactionsRequest": {
+            "type": "object",
+            "required": [
+                "cleared",
+                "transaction_ids"
+            ],
+            "properties": {
+                "cleared": {
+                    "type": "boolean"
+                },
+                "transaction_ids": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "model.ReconcileTransactionsResponse": {
+            "type": "object",
+            "properties": {
+                "cleared": {
+                    "type": "boolean"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.RecurringGroupSummary": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "monthly_total": {
+                    "type": "string"
+                },
+                "monthly_total_pence": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.RecurringHistoryEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.RecurringHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.RecurringHistoryEntry"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.RecurringTransactionsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "string"
+                },
+                "total_pence": {
+                    "type": "integer"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TransactionResponse"
+                    }
+                }
+            }
+        },
+        "model.RegisterRequest": {
             "type": "object",
             "required": [
-                "amount",
-                "description",
-                "first_due_date",
-                "frequency",
-                "interval_n"
+                "email",
+                "password"
             ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.RepairRecurringResponse": {
+            "type": "object",
+            "properties": {
+                "checked": {
+                    "type": "integer"
+                },
+                "repaired": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.RescheduleRecurringRequest": {
+            "type": "object",
+            "properties": {
+                "next_due_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.StaleRecurring": {
+            "type": "object",
             "properties": {
                 "amount": {
                     "type": "string"
                 },
+                "amount_pence": {
+                    "type": "integer"
+                },
+                "days_since_last": {
+                    "type": "integer"
+                },
                 "description": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
+                    "type": "string"
                 },
-                "end_date": {
+                "frequency": {
                     "type": "string"
                 },
-                "first_due_date": {
+                "last_generated_date": {
                     "type": "string"
                 },
-                "frequency": {
-                    "type": "string",
-                    "enum": [
-                        "daily",
-                        "weekly",
-                        "monthly",
-                        "yearly"
-                    ]
+                "next_due_date": {
+                    "type": "string"
                 },
-                "interval_n": {
-                    "type": "integer",
-                    "maximum": 365,
-                    "minimum": 1
+                "recurring_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.StaleRecurringResponse": {
+            "type": "object",
+            "properties": {
+                "cutoff": {
+                    "type": "string"
                 },
-                "tag_ids": {
+                "days": {
+                    "type": "integer"
+                },
+                "rules": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "$ref": "#/definitions/model.StaleRecurring"
                     }
                 }
             }
         },
-        "model.CreateTagRequest": {
+        "model.TagAlertEventResponse": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 1
+                "spend": {
+                    "type": "string"
+                },
+                "spend_pence": {
+                    "type": "integer"
+                },
+                "tag_id": {
+                    "type": "integer"
+                },
+                "tag_name": {
+                    "type": "string"
+                },
+                "threshold": {
+                    "type": "string"
+                },
+                "threshold_pence": {
+                    "type": "integer"
+                },
+                "year_month": {
+                    "type": "string"
                 }
             }
         },
-        "model.CreateTransactionRequest": {
+        "model.TagAlertResponse": {
             "type": "object",
-            "required": [
-                "amount",
-                "t_date"
-            ],
             "properties": {
-                "amount": {
+                "id": {
+                    "type": "integer"
+                },
+                "last_notified": {
                     "type": "string"
                 },
-                "note": {
+                "tag_id": {
+                    "type": "integer"
+                },
+                "threshold": {
                     "type": "string"
                 },
-                "t_date": {
+                "threshold_pence": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.TagReportEntry": {
+            "type": "object",
+            "properties": {
+                "total_in": {
                     "type": "string"
                 },
-                "tag_ids": {
+                "total_in_pence": {
+                    "type": "integer"
+                },
+                "total_out": {
+                    "type": "string"
+                },
+                "total_out_pence": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.TagResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.TagTransactionsGroup": {
+            "type": "object",
+            "properties": {
+                "tag_id": {
+                    "type": "integer"
+                },
+                "tag_name": {
+                    "type": "string"
+                },
+                "transactions": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "$ref": "#/definitions/model.TransactionResponse"
                     }
                 }
             }
         },
-        "model.CreateUserRequest": {
+        "model.TopTagEntry": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "spend": {
                     "type": "string"
                 },
-                "is_service": {
-                    "type": "boolean"
+                "spend_pence": {
+                    "type": "integer"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 8
+                "tag_id": {
+                    "type": "integer"
+                },
+                "tag_name": {
+                    "type": "string"
+                },
+                "transaction_count": {
+                    "type": "integer"
                 }
             }
         },
-        "model.ErrorResponse": {
+        "model.TopTagsResponse": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "object",
-                    "additionalProperties": true
+                "currency": {
+                    "type": "string"
                 },
-                "error": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TopTagEntry"
+                    }
+                },
+                "year_month": {
                     "type": "string"
                 }
             }
         },
-        "model.LoginRequest": {
+        "model.TransactionResponse": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "amount": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 1
+                "cleared": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "external_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "source_recurring": {
+                    "type": "integer"
+                },
+                "t_date": {
+                    "type": "string"
+                },
+                "tag_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "model.LoginResponse": {
+        "model.UpcomingOccurrence": {
             "type": "object",
             "properties": {
-                "email": {
+                "amount": {
                     "type": "string"
                 },
-                "expires_at": {
+                "amount_pence": {
+                    "type": "integer"
+                },
+                "description": {
                     "type": "string"
                 },
-                "token": {
+                "due_date": {
                     "type": "string"
                 },
-                "user_id": {
+                "recurring_id": {
+                    "type": "integer"
+                },
+                "running_total": {
+                    "type": "string"
+                },
+                "running_total_pence": {
                     "type": "integer"
                 }
             }
         },
-        "model.PurgeTransactionsRequest": {
+        "model.UpcomingRecurringResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "occurrences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.UpcomingOccurrence"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "string"
+                },
+                "total_pence": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.UpdateProfileRequest": {
             "type": "object",
             "required": [
-                "cutoff_date"
+                "current_password"
             ],
             "properties": {
-                "cutoff_date": {
+                "current_password": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "password": {
                     "type": "string"
                 }
             }
@@ -1741,13 +5544,11 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "frequency": {
+                    "type": "string"
+                },
+                "group": {
                     "type": "string",
-                    "enum": [
-                        "daily",
-                        "weekly",
-                        "monthly",
-                        "yearly"
-                    ]
+                    "maxLength": 100
                 },
                 "interval_n": {
                     "type": "integer",
@@ -1762,6 +5563,17 @@ const docTemplate = `{
                 }
             }
         },
+        "model.UpdateTagAlertRequest": {
+            "type": "object",
+            "required": [
+                "threshold"
+            ],
+            "properties": {
+                "threshold": {
+                    "type": "string"
+                }
+            }
+        },
         "model.UpdateTagRequest": {
             "type": "object",
             "required": [
@@ -1778,17 +5590,26 @@ const docTemplate = `{
         "model.UpdateTransactionRequest": {
             "type": "object",
             "properties": {
+                "cleared": {
+                    "type": "boolean"
+                },
                 "deleted": {
                     "type": "boolean"
                 },
                 "note": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 500
                 },
                 "tag_ids": {
                     "type": "array",
                     "items": {
                         "type": "integer"
                     }
+                },
+                "version": {
+                    "description": "Version, when set, must match the stored version or the update is rejected with 409",
+                    "type": "integer",
+                    "minimum": 1
                 }
             }
         },
@@ -1799,8 +5620,10 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "password": {
-                    "type": "string",
-                    "minLength": 8
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
                 }
             }
         },
@@ -1818,6 +5641,9 @@ const docTemplate = `{
                 },
                 "is_service": {
                     "type": "boolean"
+                },
+                "timezone": {
+                    "type": "string"
                 }
             }
         }
@@ -0,0 +1,14 @@
+package docs
+
+import _ "embed"
+
+// SwaggerJSON is the generated spec, served as-is for client codegen. swag
+// regenerates the file alongside docs.go.
+//
+//go:embed swagger.json
+var SwaggerJSON []byte
+
+// SwaggerYAML is the same spec in YAML
+//
+//go:embed swagger.yaml
+var SwaggerYAML []byte
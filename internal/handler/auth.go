@@ -4,15 +4,18 @@ import (
 	"crypto/rand"
 	"database/sql"
 	"encoding/hex"
+	"errors"
 	"net/http"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 
 	"github.com/piotrzalecki/budget-api/internal/repo"
 	"github.com/piotrzalecki/budget-api/pkg/model"
-	"golang.org/x/crypto/bcrypt"
 )
 
 // Login authenticates a user and returns a session token.
@@ -40,7 +43,7 @@ func (h *Handler) Login(c *gin.Context) {
 		return
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(user.PwHash), []byte(req.Password)); err != nil {
+	if !model.CheckPassword(user.PwHash, req.Password) {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
 		return
 	}
@@ -52,7 +55,7 @@ func (h *Handler) Login(c *gin.Context) {
 		return
 	}
 
-	expiresAt := time.Now().UTC().Add(30 * 24 * time.Hour)
+	expiresAt := h.clock.Now().UTC().Add(30 * 24 * time.Hour)
 	session, err := h.repo.CreateSession(c.Request.Context(), repo.CreateSessionParams{
 		UserID:    user.ID,
 		Token:     token,
@@ -81,6 +84,76 @@ func (h *Handler) Login(c *gin.Context) {
 	})
 }
 
+// RegistrationEnabled reports whether env variable ALLOW_REGISTRATION turns on
+// self-registration. It defaults to off: most handlers still act as user 1
+// rather than the caller, so a registered stranger would see the owner's data.
+func RegistrationEnabled() bool {
+	enabled, err := strconv.ParseBool(os.Getenv("ALLOW_REGISTRATION"))
+	return err == nil && enabled
+}
+
+// Register creates a user account without requiring authentication. The
+// route is only served when RegistrationEnabled.
+//
+// @Summary Register
+// @Description Create a user account with an email and password; the password must meet the password policy. Only available when ALLOW_REGISTRATION is true.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body model.RegisterRequest true "Account details"
+// @Success 201 {object} model.UserResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse "Email already registered"
+// @Failure 422 {object} model.ErrorResponse "Password does not meet policy"
+// @Router /auth/register [post]
+func (h *Handler) Register(c *gin.Context) {
+	req, ok := GetValidatedRequest[model.RegisterRequest](c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	if err := PasswordPolicyFromEnv().Validate(req.Password); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	_, err := h.repo.GetUserByEmail(c.Request.Context(), req.Email)
+	if err == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "email already registered"})
+		return
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		h.logger.Error("failed to look up user", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	hash, err := model.HashPassword(req.Password)
+	if err != nil {
+		h.logger.Error("failed to hash password", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	user, err := h.repo.CreateUser(c.Request.Context(), repo.CreateUserParams{
+		Email:  req.Email,
+		PwHash: hash,
+	})
+	if repo.IsUniqueViolation(err) {
+		// Registered concurrently since the lookup above
+		c.JSON(http.StatusConflict, gin.H{"error": "email already registered"})
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to create user", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": userToResponse(user), "error": nil})
+}
+
 // Logout invalidates the current session token.
 //
 // @Summary Logout
@@ -106,6 +179,39 @@ func (h *Handler) Logout(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// CheckAuth reports whether the request's API key is valid. APIKeyAuth has
+// already rejected bad keys, so this only looks up the user it authenticated.
+//
+// @Summary Check API key
+// @Description Confirm the X-API-Key header is valid without fetching any data, returning the user it acts as
+// @Tags auth
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} model.UserResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Router /auth/check [get]
+func (h *Handler) CheckAuth(c *gin.Context) {
+	userID := GetUserID(c)
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	user, err := h.repo.GetUserByID(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+			return
+		}
+		h.logger.Error("failed to get user", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": userToResponse(user), "error": nil})
+}
+
 func generateToken() (string, error) {
 	b := make([]byte, 32)
 	if _, err := rand.Read(b); err != nil {
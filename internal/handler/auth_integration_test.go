@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+	"github.com/piotrzalecki/budget-api/pkg/model"
+)
+
+func TestSessionTokenAuthIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("BUDGET_API_KEY", "integration-test-key")
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ctx := context.Background()
+
+	hash, err := model.HashPassword("correct horse")
+	require.NoError(t, err)
+	user, err := repository.CreateUser(ctx, repo.CreateUserParams{Email: "token@example.com", PwHash: hash})
+	require.NoError(t, err)
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.POST("/auth/login", ValidateRequest[model.LoginRequest](), h.Login)
+	protected := router.Group("/", APIKeyOrSessionAuth(repository))
+	protected.GET("/whoami", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"data": c.GetInt64("user_id"), "error": nil})
+	})
+	protected.POST("/auth/logout", h.Logout)
+
+	whoami := func(header, value string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/whoami", nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	var token string
+
+	t.Run("login issues an expiring token", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]string{"email": user.Email, "password": "correct horse"})
+		req := httptest.NewRequest("POST", "/auth/login", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data model.LoginResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, user.ID, response.Data.UserID)
+		require.NotEmpty(t, response.Data.Token)
+		require.NotNil(t, response.Data.ExpiresAt)
+		assert.True(t, response.Data.ExpiresAt.After(time.Now()))
+		token = response.Data.Token
+	})
+
+	t.Run("token authenticates as its user", func(t *testing.T) {
+		w := whoami("Authorization", "Bearer "+token)
+		require.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Data int64 `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, user.ID, response.Data)
+	})
+
+	t.Run("expired token is rejected", func(t *testing.T) {
+		_, err := repository.CreateSession(ctx, repo.CreateSessionParams{
+			UserID:    user.ID,
+			Token:     "expired-token",
+			ExpiresAt: sql.NullTime{Time: time.Now().UTC().Add(-time.Hour), Valid: true},
+		})
+		require.NoError(t, err)
+
+		w := whoami("Authorization", "Bearer expired-token")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid or expired token")
+	})
+
+	t.Run("unknown token is rejected", func(t *testing.T) {
+		w := whoami("Authorization", "Bearer no-such-token")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("API key is accepted instead", func(t *testing.T) {
+		w := whoami("X-API-Key", "integration-test-key")
+		require.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Data int64 `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, int64(1), response.Data, "the API key acts as the owner")
+
+		w = whoami("X-API-Key", "wrong-key")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid API key")
+	})
+
+	t.Run("logout revokes the token", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/auth/logout", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusNoContent, w.Code)
+		assert.Empty(t, w.Body.String())
+
+		w = whoami("Authorization", "Bearer "+token)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid or expired token")
+	})
+
+	t.Run("no credentials", func(t *testing.T) {
+		w := whoami("", "")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
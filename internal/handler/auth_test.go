@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+	"github.com/piotrzalecki/budget-api/pkg/model"
+)
+
+func TestCheckAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("BUDGET_API_KEY", "test-key-123")
+
+	tests := []struct {
+		name           string
+		headerKey      string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "valid API key",
+			headerKey:      "test-key-123",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data": {"id": 1, "email": "user@example.com", "is_service": false, "timezone": "UTC"}, "error": null}`,
+		},
+		{
+			name:           "invalid API key",
+			headerKey:      "wrong-key",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error": "invalid API key"}`,
+		},
+		{
+			name:           "missing API key",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error": "invalid API key"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockRepository)
+			if tt.expectedStatus == http.StatusOK {
+				mockRepo.On("GetUserByID", mock.Anything, int64(1)).Return(repo.User{ID: 1, Email: "user@example.com", Timezone: "UTC"}, nil)
+			}
+
+			h := NewHandler(mockRepo, zap.NewNop())
+			router := gin.New()
+			router.GET("/auth/check", APIKeyAuth(), h.CheckAuth)
+
+			req := httptest.NewRequest("GET", "/auth/check", nil)
+			if tt.headerKey != "" {
+				req.Header.Set("X-API-Key", tt.headerKey)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			mockRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("no authenticated user", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		h := NewHandler(mockRepo, zap.NewNop())
+		router := gin.New()
+		router.GET("/auth/check", h.CheckAuth)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/auth/check", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.JSONEq(t, `{"error": "not authenticated"}`, w.Body.String())
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestLogin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	hash, err := model.HashPassword("correct horse")
+	require.NoError(t, err)
+	user := repo.User{ID: 7, Email: "user@example.com", PwHash: hash, Timezone: "UTC"}
+
+	tests := []struct {
+		name           string
+		email          string
+		password       string
+		expectedStatus int
+	}{
+		{"correct password", "user@example.com", "correct horse", http.StatusOK},
+		{"incorrect password", "user@example.com", "wrong horse", http.StatusUnauthorized},
+		{"unknown email", "nobody@example.com", "correct horse", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockRepository)
+			if tt.email == user.Email {
+				mockRepo.On("GetUserByEmail", mock.Anything, tt.email).Return(user, nil)
+			} else {
+				mockRepo.On("GetUserByEmail", mock.Anything, tt.email).Return(repo.User{}, sql.ErrNoRows)
+			}
+			if tt.expectedStatus == http.StatusOK {
+				mockRepo.On("CreateSession", mock.Anything, mock.MatchedBy(func(arg repo.CreateSessionParams) bool {
+					return arg.UserID == user.ID && arg.Token != ""
+				})).Return(repo.Session{UserID: user.ID, Token: "session-token"}, nil)
+			}
+
+			h := NewHandler(mockRepo, zap.NewNop())
+			router := gin.New()
+			router.POST("/auth/login", ValidateRequest[model.LoginRequest](), h.Login)
+
+			body, _ := json.Marshal(map[string]string{"email": tt.email, "password": tt.password})
+			req := httptest.NewRequest("POST", "/auth/login", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response struct {
+					Data model.LoginResponse `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, user.ID, response.Data.UserID)
+				assert.Equal(t, "session-token", response.Data.Token)
+			} else {
+				assert.JSONEq(t, `{"error": "invalid credentials"}`, w.Body.String())
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		body           string
+		setup          func(m *MockRepository)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name: "valid registration",
+			body: `{"email": "new@example.com", "password": "correct horse"}`,
+			setup: func(m *MockRepository) {
+				m.On("GetUserByEmail", mock.Anything, "new@example.com").Return(repo.User{}, sql.ErrNoRows)
+				m.On("CreateUser", mock.Anything, mock.MatchedBy(func(arg repo.CreateUserParams) bool {
+					return arg.Email == "new@example.com" && !arg.IsService && model.CheckPassword(arg.PwHash, "correct horse")
+				})).Return(repo.User{ID: 5, Email: "new@example.com", Timezone: "UTC"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "duplicate email",
+			body: `{"email": "taken@example.com", "password": "correct horse"}`,
+			setup: func(m *MockRepository) {
+				m.On("GetUserByEmail", mock.Anything, "taken@example.com").Return(repo.User{ID: 2, Email: "taken@example.com"}, nil)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError:  "email already registered",
+		},
+		{
+			name: "email registered concurrently",
+			body: `{"email": "raced@example.com", "password": "correct horse"}`,
+			setup: func(m *MockRepository) {
+				m.On("GetUserByEmail", mock.Anything, "raced@example.com").Return(repo.User{}, sql.ErrNoRows)
+				m.On("CreateUser", mock.Anything, mock.Anything).
+					Return(repo.User{}, sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintUnique})
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError:  "email already registered",
+		},
+		{
+			name:           "password too short",
+			body:           `{"email": "new@example.com", "password": "short"}`,
+			setup:          func(m *MockRepository) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "password must be at least 8 characters",
+		},
+		{
+			name:           "invalid email",
+			body:           `{"email": "not-an-email", "password": "correct horse"}`,
+			setup:          func(m *MockRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockRepository)
+			tt.setup(mockRepo)
+
+			h := NewHandler(mockRepo, zap.NewNop())
+			router := gin.New()
+			router.POST("/auth/register", ValidateRequest[model.RegisterRequest](), h.Register)
+
+			req := httptest.NewRequest("POST", "/auth/register", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var response struct {
+					Data model.UserResponse `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, int64(5), response.Data.ID)
+				assert.Equal(t, "new@example.com", response.Data.Email)
+			}
+			if tt.expectedError != "" {
+				assert.JSONEq(t, `{"error": "`+tt.expectedError+`"}`, w.Body.String())
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/piotrzalecki/budget-api/internal/scheduler"
+)
+
+// Clock tells handlers the current time. Handlers use it instead of
+// time.Now so that tests can fix "today".
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock reads the system clock
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// userToday returns today's date in the user's timezone, falling back to
+// UTC when the user or their timezone can't be loaded
+func (h *Handler) userToday(ctx context.Context, userID int64) time.Time {
+	loc := time.UTC
+	user, err := h.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		h.logger.Warn("failed to get user timezone, using UTC", zap.Error(err), zap.Int64("user_id", userID))
+	} else if userLoc, err := time.LoadLocation(user.Timezone); err == nil {
+		loc = userLoc
+	}
+	return scheduler.LocalDate(h.clock.Now(), loc)
+}
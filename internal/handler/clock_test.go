@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+)
+
+// fixedClock always reports the same time
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestHandlersDefaultToClockDate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	now := fixedClock(time.Date(2031, 2, 14, 18, 30, 0, 0, time.UTC))
+
+	t.Run("due recurring rules default to today", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("GetRecurringDueOnDate", mock.Anything, time.Date(2031, 2, 14, 0, 0, 0, 0, time.UTC)).
+			Return([]repo.Recurring(nil), nil)
+
+		h := NewHandler(mockRepo, zap.NewNop())
+		h.clock = now
+		router := gin.New()
+		router.GET("/recurring/due", h.GetRecurringDueOnDate)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/recurring/due", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("monthly report defaults to the current month", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("GetMonthlyTotals", mock.Anything, repo.GetMonthlyTotalsParams{UserID: 1, Ym: "2031-02", Direction: "all"}).
+			Return(repo.GetMonthlyTotalsRow{}, nil)
+		mockRepo.On("GetMonthlyReport", mock.Anything, repo.GetMonthlyReportParams{UserID: 1, Ym: "2031-02", Direction: "all"}).
+			Return([]repo.GetMonthlyReportRow(nil), nil)
+		mockRepo.On("GetSetting", mock.Anything, "default_currency").Return(repo.Setting{}, sql.ErrNoRows)
+
+		h := NewHandler(mockRepo, zap.NewNop())
+		h.clock = now
+		router := gin.New()
+		router.GET("/reports/monthly", h.GetMonthlyReport)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/reports/monthly", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("monthly totals default to the current month", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("GetMonthlyTotals", mock.Anything, repo.GetMonthlyTotalsParams{UserID: 1, Ym: "2031-02", Direction: "all"}).
+			Return(repo.GetMonthlyTotalsRow{}, nil)
+		mockRepo.On("GetSetting", mock.Anything, "default_currency").Return(repo.Setting{}, sql.ErrNoRows)
+
+		h := NewHandler(mockRepo, zap.NewNop())
+		h.clock = now
+		router := gin.New()
+		router.GET("/reports/monthly/totals", h.GetMonthlyTotals)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/reports/monthly/totals", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"year_month":"2031-02"`)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestUserToday(t *testing.T) {
+	// 18:30 UTC is already the next day at UTC+12
+	now := fixedClock(time.Date(2031, 2, 14, 18, 30, 0, 0, time.UTC))
+
+	tests := []struct {
+		name     string
+		user     repo.User
+		err      error
+		expected time.Time
+	}{
+		{name: "utc user", user: repo.User{ID: 1, Timezone: "UTC"}, expected: time.Date(2031, 2, 14, 0, 0, 0, 0, time.UTC)},
+		{name: "utc+12 user", user: repo.User{ID: 1, Timezone: "Etc/GMT-12"}, expected: time.Date(2031, 2, 15, 0, 0, 0, 0, time.UTC)},
+		{name: "invalid timezone", user: repo.User{ID: 1, Timezone: "Mars/Olympus"}, expected: time.Date(2031, 2, 14, 0, 0, 0, 0, time.UTC)},
+		{name: "unknown user", err: sql.ErrNoRows, expected: time.Date(2031, 2, 14, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockRepository)
+			mockRepo.On("GetUserByID", mock.Anything, int64(1)).Return(tt.user, tt.err)
+
+			h := NewHandler(mockRepo, zap.NewNop())
+			h.clock = now
+			assert.Equal(t, tt.expected, h.userToday(context.Background(), 1))
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/piotrzalecki/budget-api/pkg/model"
+)
+
+// GetDBStats handles GET /admin/db/stats
+// @Summary Get database pool statistics
+// @Description Get the connection pool statistics of the database handle, for diagnosing pool exhaustion
+// @Tags admin
+// @Produce json
+// @Success 200 {object} model.DBStatsResponse "Connection pool statistics"
+// @Failure 503 {object} map[string]interface{} "Database unavailable"
+// @Security ApiKeyAuth
+// @Router /admin/db/stats [get]
+func (h *Handler) GetDBStats(c *gin.Context) {
+	db := h.repo.GetDB()
+	if db == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "database connection not available",
+			"data":  nil,
+		})
+		return
+	}
+
+	stats := db.Stats()
+	c.JSON(http.StatusOK, gin.H{
+		"data": model.DBStatsResponse{
+			MaxOpenConnections: stats.MaxOpenConnections,
+			OpenConnections:    stats.OpenConnections,
+			InUse:              stats.InUse,
+			Idle:               stats.Idle,
+			WaitCount:          stats.WaitCount,
+			WaitDurationMs:     stats.WaitDuration.Milliseconds(),
+			MaxIdleClosed:      stats.MaxIdleClosed,
+			MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+			MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+		},
+		"error": nil,
+	})
+}
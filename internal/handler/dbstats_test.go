@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+)
+
+func TestGetDBStatsIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	h := NewHandler(repo.NewRepository(db), zap.NewNop())
+	router := gin.New()
+	router.GET("/admin/db/stats", h.GetDBStats)
+
+	req := httptest.NewRequest("GET", "/admin/db/stats", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data map[string]json.Number `json:"data"`
+	}
+	decoder := json.NewDecoder(w.Body)
+	decoder.UseNumber()
+	require.NoError(t, decoder.Decode(&response))
+
+	for _, field := range []string{
+		"max_open_connections",
+		"open_connections",
+		"in_use",
+		"idle",
+		"wait_count",
+		"wait_duration_ms",
+		"max_idle_closed",
+		"max_idle_time_closed",
+		"max_lifetime_closed",
+	} {
+		assert.Contains(t, response.Data, field)
+	}
+
+	// setupTestDB pins the pool to the one migrated connection
+	assert.Equal(t, json.Number("1"), response.Data["max_open_connections"])
+	assert.Equal(t, json.Number("1"), response.Data["open_connections"])
+}
@@ -1,25 +1,63 @@
 package handler
 
 import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
 	"github.com/piotrzalecki/budget-api/internal/repo"
+	"github.com/piotrzalecki/budget-api/internal/scheduler"
 	"go.uber.org/zap"
 )
 
 // Handler holds all dependencies needed by HTTP handlers
 type Handler struct {
-	repo   repo.Repository
-	logger *zap.Logger
+	repo         repo.Repository
+	logger       *zap.Logger
+	queryTimeout time.Duration
+	notifier     scheduler.Notifier
+	clock        Clock
 }
 
 // NewHandler creates a new Handler instance with the given dependencies
-func NewHandler(repo repo.Repository, logger *zap.Logger) *Handler {
+func NewHandler(repository repo.Repository, logger *zap.Logger) *Handler {
 	return &Handler{
-		repo:   repo,
-		logger: logger,
+		repo:         repository,
+		logger:       logger,
+		queryTimeout: repo.QueryTimeoutFromEnv(),
+		notifier:     scheduler.NotifierFromEnv(),
+		clock:        realClock{},
 	}
 }
 
 // GetRepository returns the repository instance
 func (h *Handler) GetRepository() repo.Repository {
 	return h.repo
-} 
\ No newline at end of file
+}
+
+// queryContext bounds the request context by the database query timeout, for
+// handlers that run expensive or several queries outside a transaction
+func (h *Handler) queryContext(c *gin.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(c.Request.Context(), h.queryTimeout)
+}
+
+// invalidTagError is returned from inside a WithTx callback when a referenced
+// tag does not exist, so the handler can answer 400 instead of 500
+type invalidTagError struct {
+	tagID int64
+}
+
+func (e *invalidTagError) Error() string {
+	return "invalid tag ID: " + strconv.FormatInt(e.tagID, 10)
+}
+
+// transactionNotFoundError is returned from inside a WithTx callback when a
+// referenced transaction does not exist or belongs to another user
+type transactionNotFoundError struct {
+	transactionID int64
+}
+
+func (e *transactionNotFoundError) Error() string {
+	return "transaction not found: " + strconv.FormatInt(e.transactionID, 10)
+}
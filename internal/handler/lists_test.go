@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+)
+
+// TestListEndpointsReturnEmptyArrays checks that list endpoints answer 200
+// with "data": [] (never null or 204) when the repository finds no rows.
+// sqlc returns nil slices for empty result sets, so the mocks do the same.
+func TestListEndpointsReturnEmptyArrays(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name    string
+		route   string
+		url     string
+		handler func(h *Handler) gin.HandlerFunc
+		setup   func(m *MockRepository)
+		body    string
+	}{
+		{
+			name:    "transactions",
+			route:   "/transactions",
+			url:     "/transactions",
+			handler: func(h *Handler) gin.HandlerFunc { return h.GetTransactions },
+			setup: func(m *MockRepository) {
+				m.On("ListTransactions", mock.Anything, mock.Anything).Return([]repo.Transaction(nil), nil)
+				m.On("CountTransactions", mock.Anything, mock.Anything).Return(int64(0), nil)
+			},
+			body: `{"data": [], "error": null, "total": 0, "meta": {"count": 0}}`,
+		},
+		{
+			name:    "transactions by tag",
+			route:   "/transactions/by-tag/:tag_id",
+			url:     "/transactions/by-tag/1",
+			handler: func(h *Handler) gin.HandlerFunc { return h.GetTransactionsByTag },
+			setup: func(m *MockRepository) {
+				m.On("GetTagByID", mock.Anything, int64(1)).Return(repo.Tag{ID: 1, Name: "Tag1"}, nil)
+				m.On("GetTransactionsByTag", mock.Anything, int64(1)).Return([]repo.Transaction(nil), nil)
+			},
+		},
+		{
+			name:    "transactions by recurring rule",
+			route:   "/transactions/by-recurring/:recurring_id",
+			url:     "/transactions/by-recurring/1",
+			handler: func(h *Handler) gin.HandlerFunc { return h.GetTransactionsByRecurringID },
+			setup: func(m *MockRepository) {
+				m.On("GetTransactionsByRecurringID", mock.Anything, mock.Anything).Return([]repo.Transaction(nil), nil)
+			},
+		},
+		{
+			name:    "recurring",
+			route:   "/recurring",
+			url:     "/recurring",
+			handler: func(h *Handler) gin.HandlerFunc { return h.GetRecurring },
+			setup: func(m *MockRepository) {
+				m.On("GetSetting", mock.Anything, mock.Anything).Return(repo.Setting{}, sql.ErrNoRows)
+				m.On("ListRecurring", mock.Anything, repo.ListRecurringParams{UserID: 1, Limit: -1}).Return([]repo.Recurring(nil), nil)
+				m.On("CountRecurring", mock.Anything, repo.CountRecurringParams{UserID: 1}).Return(int64(0), nil)
+			},
+			body: `{"data": [], "error": null, "total": 0}`,
+		},
+		{
+			name:    "active recurring",
+			route:   "/recurring/active",
+			url:     "/recurring/active",
+			handler: func(h *Handler) gin.HandlerFunc { return h.ListActiveRecurring },
+			setup: func(m *MockRepository) {
+				m.On("GetSetting", mock.Anything, mock.Anything).Return(repo.Setting{}, sql.ErrNoRows)
+				m.On("ListActiveRecurring", mock.Anything, repo.ListActiveRecurringParams{UserID: 1, Limit: -1}).Return([]repo.Recurring(nil), nil)
+				m.On("CountActiveRecurring", mock.Anything, repo.CountActiveRecurringParams{UserID: 1}).Return(int64(0), nil)
+			},
+			body: `{"data": [], "error": null, "total": 0}`,
+		},
+		{
+			name:    "recurring by tag",
+			route:   "/recurring/by-tag/:tag_id",
+			url:     "/recurring/by-tag/1",
+			handler: func(h *Handler) gin.HandlerFunc { return h.GetRecurringByTag },
+			setup: func(m *MockRepository) {
+				m.On("GetTagByID", mock.Anything, int64(1)).Return(repo.Tag{ID: 1, Name: "Tag1"}, nil)
+				m.On("GetRecurringByTag", mock.Anything, int64(1)).Return([]repo.Recurring(nil), nil)
+			},
+		},
+		{
+			name:    "recurring due on date",
+			route:   "/recurring/due",
+			url:     "/recurring/due?date=2025-06-01",
+			handler: func(h *Handler) gin.HandlerFunc { return h.GetRecurringDueOnDate },
+			setup: func(m *MockRepository) {
+				m.On("GetRecurringDueOnDate", mock.Anything, mock.Anything).Return([]repo.Recurring(nil), nil)
+			},
+		},
+		{
+			name:    "tags",
+			route:   "/tags",
+			url:     "/tags",
+			handler: func(h *Handler) gin.HandlerFunc { return h.GetTags },
+			setup: func(m *MockRepository) {
+				m.On("ListTags", mock.Anything).Return([]repo.Tag(nil), nil)
+			},
+		},
+		{
+			name:    "users",
+			route:   "/users",
+			url:     "/users",
+			handler: func(h *Handler) gin.HandlerFunc { return h.ListUsers },
+			setup: func(m *MockRepository) {
+				m.On("ListUsers", mock.Anything).Return([]repo.User(nil), nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockRepository)
+			tt.setup(mockRepo)
+
+			h := NewHandler(mockRepo, zap.NewNop())
+			router := gin.New()
+			router.GET(tt.route, tt.handler(h))
+
+			req := httptest.NewRequest("GET", tt.url, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			expected := tt.body
+			if expected == "" {
+				expected = `{"data": [], "error": null}`
+			}
+			assert.JSONEq(t, expected, w.Body.String())
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
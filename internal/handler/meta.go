@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/piotrzalecki/budget-api/internal/scheduler"
+	"github.com/piotrzalecki/budget-api/pkg/model"
+)
+
+// frequencyExampleDate is a month end, so the examples show how shorter
+// months are clamped
+var frequencyExampleDate = time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+// GetFrequencies handles GET /api/v1/meta/frequencies
+// @Summary List recurring frequencies
+// @Description List the frequencies recurring rules accept, the unit interval_n counts in, the largest allowed interval_n, and an example of the next due date
+// @Tags meta
+// @Produce json
+// @Success 200 {array} model.FrequencyResponse "Supported frequencies"
+// @Security ApiKeyAuth
+// @Router /meta/frequencies [get]
+func (h *Handler) GetFrequencies(c *gin.Context) {
+	frequencies := model.Frequencies()
+	response := make([]model.FrequencyResponse, len(frequencies))
+	for i, f := range frequencies {
+		response[i] = model.FrequencyResponse{
+			Name:        string(f),
+			Unit:        f.Unit(),
+			MaxInterval: f.MaxInterval(),
+			Example: model.FrequencyExample{
+				From:      model.FormatDate(frequencyExampleDate),
+				IntervalN: 1,
+				Next:      model.FormatDate(scheduler.Advance(f, frequencyExampleDate, 1)),
+			},
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
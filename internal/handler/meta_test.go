@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+	"github.com/piotrzalecki/budget-api/internal/scheduler"
+	"github.com/piotrzalecki/budget-api/pkg/model"
+)
+
+func TestGetFrequenciesMatchesValidator(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewHandler(new(MockRepository), zap.NewNop())
+	router := gin.New()
+	router.GET("/meta/frequencies", h.GetFrequencies)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/meta/frequencies", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data []model.FrequencyResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+	names := make([]string, len(response.Data))
+	for i, f := range response.Data {
+		names[i] = f.Name
+	}
+	assert.Equal(t, []string{"daily", "weekly", "monthly", "yearly"}, names)
+
+	validate := validator.New()
+	registerCustomValidators(validate)
+	valid := func(frequency string, intervalN int) bool {
+		return validate.Struct(model.CreateRecurringRequest{
+			Amount:       "-10.00",
+			Description:  "Rule",
+			Frequency:    frequency,
+			IntervalN:    intervalN,
+			FirstDueDate: "2031-01-01",
+		}) == nil
+	}
+
+	// Every listed frequency is accepted up to its max interval and nothing else is
+	for _, f := range response.Data {
+		assert.True(t, valid(f.Name, f.MaxInterval), f.Name)
+		assert.False(t, valid(f.Name, f.MaxInterval+1), f.Name)
+	}
+	assert.False(t, valid("fortnightly", 1))
+
+	// Monthly rules clamp to the end of shorter months
+	assert.Equal(t, model.FrequencyExample{From: "2024-01-31", IntervalN: 1, Next: "2024-02-29"}, response.Data[2].Example)
+}
+
+func TestFrequencyValidatorAndSchedulerAgree(t *testing.T) {
+	validate := validator.New()
+	registerCustomValidators(validate)
+	from := time.Date(2031, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	accepted := func(frequency string) bool {
+		return validate.Struct(model.MakeRecurringRequest{
+			Frequency:    frequency,
+			IntervalN:    1,
+			FirstDueDate: "2031-01-15",
+		}) == nil
+	}
+	advances := func(frequency string) bool {
+		rule := repo.Recurring{Frequency: frequency, IntervalN: 1, NextDueDate: from}
+		return scheduler.NextDueDate(rule).After(from)
+	}
+
+	// Frequencies lists every entry of the model's frequency table
+	for _, f := range model.Frequencies() {
+		assert.True(t, f.Valid(), f)
+		assert.True(t, accepted(string(f)), "validator rejects %s", f)
+		assert.True(t, advances(string(f)), "scheduler doesn't advance %s rules", f)
+		assert.NotEmpty(t, f.Unit(), f)
+		assert.Positive(t, f.MaxInterval(), f)
+	}
+
+	assert.False(t, model.Frequency("fortnightly").Valid())
+	assert.False(t, accepted("fortnightly"))
+	assert.False(t, advances("fortnightly"))
+}
@@ -2,20 +2,27 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"os"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 
 	"github.com/piotrzalecki/budget-api/internal/repo"
+	"github.com/piotrzalecki/budget-api/pkg/model"
 )
 
 // APIKeyAuth blocks requests whose X-API-Key header does not match
-// env variable BUDGET_API_KEY. If the env var is unset, startup aborts.
+// env variable BUDGET_API_KEY, and stores the owner's user_id in the gin
+// context for those that do. If the env var is unset, startup aborts.
 func APIKeyAuth() gin.HandlerFunc {
 	expected := os.Getenv("BUDGET_API_KEY")
 	if expected == "" {
@@ -27,6 +34,7 @@ func APIKeyAuth() gin.HandlerFunc {
 				gin.H{"error": "invalid API key"})
 			return
 		}
+		c.Set("user_id", ownerUserID)
 		c.Next()
 	}
 }
@@ -58,6 +66,35 @@ func SessionAuth(r repo.Repository) gin.HandlerFunc {
 	}
 }
 
+// ownerUserID is the account the admin API key acts as
+const ownerUserID int64 = 1
+
+// APIKeyOrSessionAuth accepts either credential: an X-API-Key header is checked
+// against BUDGET_API_KEY, otherwise the request needs a Bearer session token
+// as in SessionAuth. Expired sessions are rejected by the token lookup. The
+// API key grants access to every route behind this middleware, acting as the
+// owner (user 1).
+func APIKeyOrSessionAuth(r repo.Repository) gin.HandlerFunc {
+	expected := os.Getenv("BUDGET_API_KEY")
+	if expected == "" {
+		panic("BUDGET_API_KEY not set")
+	}
+	sessionAuth := SessionAuth(r)
+	return func(c *gin.Context) {
+		if key := c.GetHeader("X-API-Key"); key != "" {
+			if key != expected {
+				c.AbortWithStatusJSON(http.StatusUnauthorized,
+					gin.H{"error": "invalid API key"})
+				return
+			}
+			c.Set("user_id", ownerUserID)
+			c.Next()
+			return
+		}
+		sessionAuth(c)
+	}
+}
+
 // GetUserID extracts the authenticated user ID from the gin context.
 func GetUserID(c *gin.Context) int64 {
 	v, _ := c.Get("user_id")
@@ -65,21 +102,107 @@ func GetUserID(c *gin.Context) int64 {
 	return id
 }
 
+// defaultMaxBodyBytes is used when MAX_BODY_BYTES is unset or invalid.
+const defaultMaxBodyBytes int64 = 1 << 20
+
+// MaxBodySize caps request bodies at env variable MAX_BODY_BYTES (default 1MB).
+// Requests declaring a larger Content-Length are rejected with 413 up front;
+// otherwise the body is wrapped in http.MaxBytesReader and ValidateRequest
+// answers 413 when the limit is hit while binding.
+func MaxBodySize() gin.HandlerFunc {
+	limit := defaultMaxBodyBytes
+	if v, err := strconv.ParseInt(os.Getenv("MAX_BODY_BYTES"), 10, 64); err == nil && v > 0 {
+		limit = v
+	}
+
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > limit {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
+				"error": "request body too large",
+				"data":  nil,
+			})
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
+
+// defaultRequestTimeout is used when REQUEST_TIMEOUT is unset or invalid.
+const defaultRequestTimeout = 10 * time.Second
+
+// RequestTimeout gives every request a deadline taken from env variable
+// REQUEST_TIMEOUT (a Go duration such as "10s", default 10s). The request
+// context is cancelled at the deadline, so repository calls made with it
+// return early and the handler answers with their error. A handler that
+// gives up without responding gets a 503.
+func RequestTimeout() gin.HandlerFunc {
+	timeout := defaultRequestTimeout
+	if v, err := time.ParseDuration(os.Getenv("REQUEST_TIMEOUT")); err == nil && v > 0 {
+		timeout = v
+	}
+
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		if ctx.Err() == context.DeadlineExceeded && !c.Writer.Written() {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+				"error": "request timed out",
+				"data":  nil,
+			})
+		}
+	}
+}
+
 // ValidateRequest is a middleware that validates request body against a struct
 // using validator v10. It expects the struct to be passed as a type parameter.
 func ValidateRequest[T any]() gin.HandlerFunc {
+	return validateRequest[T](false)
+}
+
+// ValidateOptionalRequest works like ValidateRequest but accepts an empty body,
+// storing a zero-valued request. It suits endpoints whose body only carries
+// optional overrides.
+func ValidateOptionalRequest[T any]() gin.HandlerFunc {
+	return validateRequest[T](true)
+}
+
+func validateRequest[T any](allowEmpty bool) gin.HandlerFunc {
 	validate := validator.New()
 	
+	// Report fields by their JSON names, e.g. tag_ids[1] rather than TagIDs[1]
+	validate.RegisterTagNameFunc(jsonFieldName)
+	
 	// Register custom validators if needed
 	registerCustomValidators(validate)
 	
 	return func(c *gin.Context) {
 		var request T
 		
-		// Bind JSON to struct
-		if err := c.ShouldBindJSON(&request); err != nil {
+		// Decode JSON into the struct, rejecting fields it doesn't have so a
+		// typo such as tag_id for tag_ids isn't silently ignored
+		if err := decodeStrictJSON(c.Request, &request); err != nil && !(allowEmpty && errors.Is(err, io.EOF)) {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
+					"error": "request body too large",
+					"data":  nil,
+				})
+				return
+			}
+			if field, ok := unknownJSONField(err); ok {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+					"error": "unknown field: " + field,
+					"data":  nil,
+				})
+				return
+			}
 			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-				"error": "invalid request format",
+				"error": jsonDecodeErrorMessage(err),
 				"data":  nil,
 			})
 			return
@@ -117,12 +240,149 @@ func ValidateRequest[T any]() gin.HandlerFunc {
 	}
 }
 
+// decodeStrictJSON decodes the request body into dst, failing on fields dst
+// does not declare. An empty body yields io.EOF.
+func decodeStrictJSON(req *http.Request, dst interface{}) error {
+	if req.Body == nil {
+		return io.EOF
+	}
+	decoder := json.NewDecoder(req.Body)
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(dst)
+}
+
+// unknownJSONField returns the field named by a DisallowUnknownFields error.
+// encoding/json doesn't export a type for it, so the message is matched.
+func unknownJSONField(err error) (string, bool) {
+	field, ok := strings.CutPrefix(err.Error(), "json: unknown field ")
+	if !ok {
+		return "", false
+	}
+	return strings.Trim(field, `"`), true
+}
+
+// jsonDecodeErrorMessage describes why a request body could not be decoded:
+// it was empty, was not valid JSON, or held a value of the wrong type for a
+// field
+func jsonDecodeErrorMessage(err error) string {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, io.EOF):
+		return "request body required"
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return "malformed JSON: unexpected end of request body"
+	case errors.As(err, &syntaxErr):
+		return "malformed JSON at offset " + strconv.FormatInt(syntaxErr.Offset, 10)
+	case errors.As(err, &typeErr):
+		if typeErr.Field == "" {
+			return "request body must be a JSON " + jsonTypeName(typeErr.Type)
+		}
+		return "field " + jsonFieldPath(typeErr.Field) + " must be " + withArticle(jsonTypeName(typeErr.Type))
+	}
+	return "invalid request format"
+}
+
+// jsonFieldPath writes the array indexes in a decode error's dotted field
+// path as the validator does, e.g. tag_ids.1 as tag_ids[1]
+func jsonFieldPath(field string) string {
+	parts := strings.Split(field, ".")
+	path := parts[0]
+	for _, part := range parts[1:] {
+		if _, err := strconv.Atoi(part); err == nil {
+			path += "[" + part + "]"
+		} else {
+			path += "." + part
+		}
+	}
+	return path
+}
+
+// withArticle prefixes a JSON type name with "a" or "an"
+func withArticle(name string) string {
+	if strings.ContainsRune("aeiou", rune(name[0])) {
+		return "an " + name
+	}
+	return "a " + name
+}
+
+// jsonTypeName names the JSON type that decodes into t
+func jsonTypeName(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	}
+	return "object"
+}
+
 // registerCustomValidators registers any custom validation functions
 func registerCustomValidators(v *validator.Validate) {
 	// Register currency validator for amount fields
 	v.RegisterValidation("currency", validateCurrency)
 	// Register date validator for date fields
 	v.RegisterValidation("date", validateDate)
+	// Register non-zero validator for transaction amounts
+	v.RegisterValidation("nonzero_amount", validateNonZeroAmount)
+	// Register control character validator for free-text fields
+	v.RegisterValidation("nocontrol", validateNoControl)
+	// Register frequency validator for recurring rule frequencies
+	v.RegisterValidation("frequency", validateFrequency)
+	// Bound interval_n by frequency on recurring rule requests
+	v.RegisterStructValidation(validateRecurringInterval,
+		model.CreateRecurringRequest{}, model.UpdateRecurringRequest{}, model.CloneRecurringRequest{})
+}
+
+// intervalOutOfRange reports whether intervalN is too large for frequency,
+// returning the validation param describing the bound
+func intervalOutOfRange(frequency string, intervalN int) (string, bool) {
+	f := model.Frequency(frequency)
+	if !f.Valid() || intervalN <= f.MaxInterval() {
+		return "", false
+	}
+	return strconv.Itoa(f.MaxInterval()) + " for " + frequency, true
+}
+
+// frequencyNames returns the supported frequencies as strings
+func frequencyNames() []string {
+	frequencies := model.Frequencies()
+	names := make([]string, len(frequencies))
+	for i, f := range frequencies {
+		names[i] = string(f)
+	}
+	return names
+}
+
+// validateRecurringInterval checks interval_n against frequency. Partial
+// updates that set only one of them are checked by the handler once merged
+// with the stored rule.
+func validateRecurringInterval(sl validator.StructLevel) {
+	var frequency *string
+	var intervalN *int
+	switch req := sl.Current().Interface().(type) {
+	case model.CreateRecurringRequest:
+		frequency, intervalN = &req.Frequency, &req.IntervalN
+	case model.UpdateRecurringRequest:
+		frequency, intervalN = req.Frequency, req.IntervalN
+	case model.CloneRecurringRequest:
+		frequency, intervalN = req.Frequency, req.IntervalN
+	}
+	if frequency == nil || intervalN == nil {
+		return
+	}
+	if param, ok := intervalOutOfRange(*frequency, *intervalN); ok {
+		sl.ReportError(*intervalN, "interval_n", "IntervalN", "interval_for_frequency", param)
+	}
 }
 
 // validateCurrency validates currency format (e.g., "-12.34", "123.45")
@@ -167,6 +427,54 @@ func validateCurrency(fl validator.FieldLevel) bool {
 	return err == nil
 }
 
+// validateNonZeroAmount rejects amounts that are zero (e.g., "0.00", "-0.00").
+// Zero amounts are allowed when env variable ALLOW_ZERO_AMOUNTS is true.
+func validateNonZeroAmount(fl validator.FieldLevel) bool {
+	amount := fl.Field().String()
+
+	// Check if empty (handled by required validator)
+	if amount == "" {
+		return true
+	}
+
+	if allow, err := strconv.ParseBool(os.Getenv("ALLOW_ZERO_AMOUNTS")); err == nil && allow {
+		return true
+	}
+
+	// Malformed amounts are reported by the currency validator
+	value, err := strconv.ParseFloat(amount, 64)
+	if err != nil {
+		return true
+	}
+	return value != 0
+}
+
+// validateFrequency checks a recurring rule frequency is one the scheduler supports
+func validateFrequency(fl validator.FieldLevel) bool {
+	frequency := fl.Field().String()
+
+	// Check if empty (handled by required validator)
+	if frequency == "" {
+		return true
+	}
+
+	return model.Frequency(frequency).Valid()
+}
+
+// validateNoControl rejects text containing control characters. Newlines and
+// tabs are allowed so free-text fields can span multiple lines.
+func validateNoControl(fl validator.FieldLevel) bool {
+	for _, r := range fl.Field().String() {
+		if r == '\n' || r == '\r' || r == '\t' {
+			continue
+		}
+		if unicode.IsControl(r) {
+			return false
+		}
+	}
+	return true
+}
+
 // validateDate validates date format (YYYY-MM-DD)
 func validateDate(fl validator.FieldLevel) bool {
 	dateStr := fl.Field().String()
@@ -181,6 +489,16 @@ func validateDate(fl validator.FieldLevel) bool {
 	return err == nil
 }
 
+// jsonFieldName returns the name a struct field has in JSON, or "" to let
+// the validator fall back to the Go field name
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
 // toSnakeCase converts camelCase to snake_case
 func toSnakeCase(s string) string {
 	var result strings.Builder
@@ -216,6 +534,10 @@ func getValidationMessage(tag, param string) string {
 		return "must be less than " + param
 	case "date":
 		return "must be a valid date in YYYY-MM-DD format"
+	case "frequency":
+		return "must be one of: " + strings.Join(frequencyNames(), " ")
+	case "interval_for_frequency":
+		return "must be at most " + param + " rules"
 	case "datetime":
 		return "must be a valid datetime"
 	case "url":
@@ -228,6 +550,10 @@ func getValidationMessage(tag, param string) string {
 		return "must contain only letters and numbers"
 	case "currency":
 		return "must be a valid currency amount (e.g., '12.34' or '-12.34')"
+	case "nonzero_amount":
+		return "must not be zero"
+	case "nocontrol":
+		return "must not contain control characters"
 	default:
 		return "validation failed for " + tag
 	}
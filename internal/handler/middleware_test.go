@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/piotrzalecki/budget-api/pkg/model"
@@ -229,6 +230,132 @@ func TestValidateRequest_CreateTransaction_MissingRequired(t *testing.T) {
 	assert.Contains(t, validationErrors, "t_date")
 }
 
+func TestValidateRequest_ReportsAllFieldErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/test", ValidateRequest[model.CreateRecurringRequest]())
+
+	requestBody := map[string]interface{}{
+		"amount":         "12",
+		"frequency":      "fortnightly",
+		"interval_n":     0,
+		"first_due_date": "2025-13-01",
+		"end_date":       "soon",
+		"tag_ids":        []int64{1, 0, -3},
+	}
+
+	bodyBytes, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest("POST", "/test", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "validation failed", response["error"])
+	assert.Equal(t, map[string]interface{}{
+		"amount":         "must be a valid currency amount (e.g., '12.34' or '-12.34')",
+		"description":    "this field is required",
+		"frequency":      "must be one of: daily weekly monthly yearly",
+		"interval_n":     "this field is required",
+		"first_due_date": "must be a valid date in YYYY-MM-DD format",
+		"end_date":       "must be a valid date in YYYY-MM-DD format",
+		"tag_ids[1]":     "must be greater than 0",
+		"tag_ids[2]":     "must be greater than 0",
+	}, response["data"])
+}
+
+func TestValidateRequest_RejectsUnknownFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/test", ValidateRequest[model.CreateTransactionRequest](), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	router.POST("/optional", ValidateOptionalRequest[model.CloneRecurringRequest](), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	post := func(url, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", url, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("typo of a known field", func(t *testing.T) {
+		w := post("/test", `{"amount": "-12.34", "t_date": "2025-06-17", "tag_id": [1]}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"error": "unknown field: tag_id", "data": null}`, w.Body.String())
+	})
+
+	t.Run("known fields only", func(t *testing.T) {
+		w := post("/test", `{"amount": "-12.34", "t_date": "2025-06-17", "tag_ids": [1]}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("optional body still accepts an empty request", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, post("/optional", "").Code)
+		assert.Equal(t, http.StatusBadRequest, post("/optional", `{"frequncy": "weekly"}`).Code)
+	})
+}
+
+func TestValidateRequest_CreateTransaction_ZeroAmount(t *testing.T) {
+	tests := []struct {
+		name           string
+		amount         string
+		allowZero      string
+		expectedStatus int
+	}{
+		{name: "zero amount", amount: "0.00", expectedStatus: http.StatusBadRequest},
+		{name: "negative zero amount", amount: "-0.00", expectedStatus: http.StatusBadRequest},
+		{name: "valid amount", amount: "-12.34", expectedStatus: http.StatusOK},
+		{name: "zero amount allowed by setting", amount: "0.00", allowZero: "true", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ALLOW_ZERO_AMOUNTS", tt.allowZero)
+
+			// Setup
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.POST("/test", ValidateRequest[model.CreateTransactionRequest](), func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"success": true})
+			})
+
+			requestBody := model.CreateTransactionRequest{
+				Amount: tt.amount,
+				TDate:  "2025-06-17",
+			}
+
+			bodyBytes, _ := json.Marshal(requestBody)
+			req := httptest.NewRequest("POST", "/test", bytes.NewBuffer(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			// Execute
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusBadRequest {
+				var response map[string]interface{}
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Equal(t, "validation failed", response["error"])
+
+				validationErrors := response["data"].(map[string]interface{})
+				assert.Equal(t, "must not be zero", validationErrors["amount"])
+			}
+		})
+	}
+}
+
 func TestValidateRequest_CreateRecurring_Success(t *testing.T) {
 	// Setup
 	gin.SetMode(gin.TestMode)
@@ -299,6 +426,58 @@ func TestValidateRequest_CreateRecurring_InvalidFrequency(t *testing.T) {
 	assert.Contains(t, validationErrors, "frequency")
 }
 
+func TestValidateRequest_RecurringIntervalByFrequency(t *testing.T) {
+	tests := []struct {
+		frequency string
+		intervalN int
+		message   string
+	}{
+		{frequency: "daily", intervalN: 366, message: "must be at most 365 for daily rules"},
+		{frequency: "weekly", intervalN: 53, message: "must be at most 52 for weekly rules"},
+		{frequency: "monthly", intervalN: 25, message: "must be at most 24 for monthly rules"},
+		{frequency: "yearly", intervalN: 11, message: "must be at most 10 for yearly rules"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.frequency, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.POST("/create", ValidateRequest[model.CreateRecurringRequest]())
+			router.POST("/update", ValidateRequest[model.UpdateRecurringRequest]())
+
+			requests := map[string]interface{}{
+				"/create": model.CreateRecurringRequest{
+					Amount:       "-50.00",
+					Description:  "Subscription",
+					Frequency:    tt.frequency,
+					IntervalN:    tt.intervalN,
+					FirstDueDate: "2025-07-01",
+				},
+				"/update": model.UpdateRecurringRequest{
+					Frequency: &tt.frequency,
+					IntervalN: &tt.intervalN,
+				},
+			}
+			for path, requestBody := range requests {
+				bodyBytes, _ := json.Marshal(requestBody)
+				req := httptest.NewRequest("POST", path, bytes.NewBuffer(bodyBytes))
+				req.Header.Set("Content-Type", "application/json")
+				w := httptest.NewRecorder()
+
+				router.ServeHTTP(w, req)
+
+				assert.Equal(t, http.StatusBadRequest, w.Code, path)
+
+				var response map[string]interface{}
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				validationErrors := response["data"].(map[string]interface{})
+				assert.Equal(t, tt.message, validationErrors["interval_n"], path)
+			}
+		})
+	}
+}
+
 func TestValidateCurrency(t *testing.T) {
 	// Test valid currency formats
 	validAmounts := []string{
@@ -364,4 +543,137 @@ func isValidCurrencyFormat(amount string) bool {
 	// Try to parse as float to ensure it's a valid number
 	_, err := strconv.ParseFloat(amount, 64)
 	return err == nil
-} 
\ No newline at end of file
+}
+
+func TestRequestTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("REQUEST_TIMEOUT", "50ms")
+
+	router := gin.New()
+	router.Use(RequestTimeout())
+	router.GET("/fast", func(c *gin.Context) {
+		c.Header("X-Test", "fast")
+		c.JSON(http.StatusCreated, gin.H{"data": "ok", "error": nil})
+	})
+	router.GET("/slow", func(c *gin.Context) {
+		// Behaves like a repository call made with the request context
+		select {
+		case <-time.After(time.Second):
+			c.JSON(http.StatusOK, gin.H{"data": "too late", "error": nil})
+		case <-c.Request.Context().Done():
+			c.JSON(http.StatusInternalServerError, gin.H{"error": c.Request.Context().Err().Error(), "data": nil})
+		}
+	})
+	router.GET("/silent", func(c *gin.Context) {
+		// Gives up at the deadline without writing a response
+		<-c.Request.Context().Done()
+	})
+
+	t.Run("fast handler response is passed through", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/fast", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "fast", w.Header().Get("X-Test"))
+		assert.JSONEq(t, `{"data": "ok", "error": null}`, w.Body.String())
+	})
+
+	t.Run("slow handler sees the deadline", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/slow", nil)
+		w := httptest.NewRecorder()
+		start := time.Now()
+		router.ServeHTTP(w, req)
+
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.JSONEq(t, `{"error": "context deadline exceeded", "data": null}`, w.Body.String())
+	})
+
+	t.Run("handler giving up without a response gets 503", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/silent", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.JSONEq(t, `{"error": "request timed out", "data": null}`, w.Body.String())
+	})
+}
+
+func TestMaxBodySize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("MAX_BODY_BYTES", "64")
+
+	router := gin.New()
+	router.Use(MaxBodySize())
+	router.POST("/test", ValidateRequest[model.CreateTransactionRequest](), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"success": true})
+	})
+
+	t.Run("body within limit is accepted", func(t *testing.T) {
+		body := `{"amount": "-12.34", "t_date": "2025-06-17"}`
+		req := httptest.NewRequest("POST", "/test", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("oversized body is rejected with 413", func(t *testing.T) {
+		body := `{"amount": "-12.34", "t_date": "2025-06-17", "note": "` + strings.Repeat("x", 100) + `"}`
+		req := httptest.NewRequest("POST", "/test", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Contains(t, w.Body.String(), "request body too large")
+	})
+
+	t.Run("oversized body without content length is rejected with 413", func(t *testing.T) {
+		body := `{"amount": "-12.34", "t_date": "2025-06-17", "note": "` + strings.Repeat("x", 100) + `"}`
+		req := httptest.NewRequest("POST", "/test", strings.NewReader(body))
+		req.ContentLength = -1
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+}
+
+func TestValidateRequest_MalformedJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/test", ValidateRequest[model.CreateTransactionRequest](), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"empty body", "", "request body required"},
+		{"whitespace only", "  \n", "request body required"},
+		{"syntax error", `{"amount": "-12.34",, "t_date": "2025-06-17"}`, "malformed JSON at offset 21"},
+		{"truncated body", `{"amount": "-12.34"`, "malformed JSON: unexpected end of request body"},
+		{"number for a string field", `{"amount": -12.34, "t_date": "2025-06-17"}`, "field amount must be a string"},
+		{"string for an array field", `{"amount": "-12.34", "t_date": "2025-06-17", "tag_ids": "1"}`, "field tag_ids must be an array"},
+		{"string in an array of IDs", `{"amount": "-12.34", "t_date": "2025-06-17", "tag_ids": ["1"]}`, "field tag_ids[0] must be a number"},
+		{"array instead of an object", `[]`, "request body must be a JSON object"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/test", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.JSONEq(t, `{"error": "`+tt.want+`", "data": null}`, w.Body.String())
+		})
+	}
+}
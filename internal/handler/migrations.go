@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"net/http"
+	"os"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/database"
+	"go.uber.org/zap"
+
+	"github.com/piotrzalecki/budget-api/pkg/model"
+)
+
+// defaultMigrationsDir is used when MIGRATIONS_DIR is unset
+const defaultMigrationsDir = "migrations"
+
+// migrationsDir returns the directory holding the goose SQL migrations, taken
+// from env variable MIGRATIONS_DIR
+func migrationsDir() string {
+	if dir := os.Getenv("MIGRATIONS_DIR"); dir != "" {
+		return dir
+	}
+	return defaultMigrationsDir
+}
+
+// GetMigrationStatus handles GET /admin/migrations
+// @Summary Get migration status
+// @Description Get the database schema version and the migrations applied to it
+// @Tags admin
+// @Produce json
+// @Success 200 {object} model.MigrationStatusResponse "Migration status"
+// @Failure 503 {object} map[string]interface{} "Database unavailable"
+// @Security ApiKeyAuth
+// @Router /admin/migrations [get]
+func (h *Handler) GetMigrationStatus(c *gin.Context) {
+	db := h.repo.GetDB()
+	if db == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "database connection not available",
+			"data":  nil,
+		})
+		return
+	}
+
+	store, err := database.NewStore(database.DialectSQLite3, goose.TableName())
+	if err != nil {
+		h.logger.Error("failed to create migration store", zap.Error(err))
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "failed to read migration status",
+			"data":  nil,
+		})
+		return
+	}
+
+	// The version table is a log, newest first; a version's latest row says
+	// whether it is currently applied
+	ctx := c.Request.Context()
+	rows, err := store.ListMigrations(ctx, db)
+	if err != nil {
+		h.logger.Error("failed to list migrations", zap.Error(err))
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "failed to read migration status",
+			"data":  nil,
+		})
+		return
+	}
+	seen := make(map[int64]bool)
+	var versions []int64
+	for _, row := range rows {
+		if seen[row.Version] {
+			continue
+		}
+		seen[row.Version] = true
+		// Version 0 is goose's marker for the version table itself
+		if row.IsApplied && row.Version > 0 {
+			versions = append(versions, row.Version)
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+
+	response := model.MigrationStatusResponse{
+		Applied: make([]model.AppliedMigration, 0, len(versions)),
+	}
+	for _, version := range versions {
+		migration, err := store.GetMigration(ctx, db, version)
+		if err != nil {
+			h.logger.Error("failed to fetch migration", zap.Error(err), zap.Int64("version", version))
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"error": "failed to read migration status",
+				"data":  nil,
+			})
+			return
+		}
+		response.Applied = append(response.Applied, model.AppliedMigration{
+			Version:   version,
+			AppliedAt: migration.Timestamp,
+		})
+		response.Version = version
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// RunMigrations handles POST /admin/migrate
+// @Summary Run pending migrations
+// @Description Apply any migrations from MIGRATIONS_DIR that the database has not had yet, for controlled rollouts
+// @Tags admin
+// @Produce json
+// @Success 200 {object} model.MigrateResponse "Resulting schema version"
+// @Failure 500 {object} map[string]interface{} "Migration failed"
+// @Failure 503 {object} map[string]interface{} "Database unavailable"
+// @Security ApiKeyAuth
+// @Router /admin/migrate [post]
+func (h *Handler) RunMigrations(c *gin.Context) {
+	db := h.repo.GetDB()
+	if db == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "database connection not available",
+			"data":  nil,
+		})
+		return
+	}
+
+	dir := migrationsDir()
+	provider, err := goose.NewProvider(goose.DialectSQLite3, db, os.DirFS(dir))
+	if err != nil {
+		h.logger.Error("failed to load migrations", zap.Error(err), zap.String("dir", dir))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to load migrations",
+			"data":  nil,
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	results, err := provider.Up(ctx)
+	if err != nil {
+		h.logger.Error("failed to run migrations", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to run migrations",
+			"data":  nil,
+		})
+		return
+	}
+
+	version, err := provider.GetDBVersion(ctx)
+	if err != nil {
+		h.logger.Error("failed to fetch schema version", zap.Error(err))
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "failed to read migration status",
+			"data":  nil,
+		})
+		return
+	}
+
+	response := model.MigrateResponse{
+		Version: version,
+		Applied: make([]int64, 0, len(results)),
+	}
+	for _, result := range results {
+		response.Applied = append(response.Applied, result.Source.Version)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
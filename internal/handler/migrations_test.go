@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+	"github.com/piotrzalecki/budget-api/pkg/model"
+)
+
+func TestGetMigrationStatusIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	migrations, err := goose.CollectMigrations("../../migrations", 0, goose.MaxVersion)
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	h := NewHandler(repo.NewRepository(db), zap.NewNop())
+	router := gin.New()
+	router.GET("/admin/migrations", h.GetMigrationStatus)
+
+	t.Run("reports the migrated version", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/admin/migrations", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data model.MigrationStatusResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, migrations[len(migrations)-1].Version, response.Data.Version)
+		require.Len(t, response.Data.Applied, len(migrations))
+		for i, m := range migrations {
+			assert.Equal(t, m.Version, response.Data.Applied[i].Version)
+			assert.False(t, response.Data.Applied[i].AppliedAt.IsZero())
+		}
+	})
+
+	t.Run("rolled back migrations are not listed", func(t *testing.T) {
+		require.NoError(t, goose.Down(db, "../../migrations"))
+		defer func() { require.NoError(t, goose.Up(db, "../../migrations")) }()
+
+		req := httptest.NewRequest("GET", "/admin/migrations", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data model.MigrationStatusResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, migrations[len(migrations)-2].Version, response.Data.Version)
+		assert.Len(t, response.Data.Applied, len(migrations)-1)
+	})
+
+	t.Run("unavailable database", func(t *testing.T) {
+		require.NoError(t, db.Close())
+
+		req := httptest.NewRequest("GET", "/admin/migrations", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+}
+
+func TestRunMigrationsIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("MIGRATIONS_DIR", "../../migrations")
+
+	// Start from an empty database
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	migrations, err := goose.CollectMigrations("../../migrations", 0, goose.MaxVersion)
+	require.NoError(t, err)
+	latest := migrations[len(migrations)-1].Version
+
+	h := NewHandler(repo.NewRepository(db), zap.NewNop())
+	router := gin.New()
+	router.POST("/admin/migrate", h.RunMigrations)
+
+	migrate := func() model.MigrateResponse {
+		req := httptest.NewRequest("POST", "/admin/migrate", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data model.MigrateResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data
+	}
+
+	first := migrate()
+	assert.Equal(t, latest, first.Version)
+	assert.Len(t, first.Applied, len(migrations))
+
+	var tables int
+	require.NoError(t, db.QueryRow(
+		`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name IN ('transactions', 'tags', 'recurring')`,
+	).Scan(&tables))
+	assert.Equal(t, 3, tables)
+
+	// Nothing is pending the second time
+	second := migrate()
+	assert.Equal(t, latest, second.Version)
+	assert.Empty(t, second.Applied)
+}
+
+func TestRunMigrationsMissingDir(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("MIGRATIONS_DIR", t.TempDir()+"/missing")
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	h := NewHandler(repo.NewRepository(db), zap.NewNop())
+	router := gin.New()
+	router.POST("/admin/migrate", h.RunMigrations)
+
+	req := httptest.NewRequest("POST", "/admin/migrate", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
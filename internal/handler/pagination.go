@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// maxPageSize is the largest limit a listing endpoint accepts when the
+// page_max setting has not been configured
+const maxPageSize = 100
+
+// pageSizes holds the limit bounds applied by the paginated list endpoints
+type pageSizes struct {
+	defaultSize int64
+	maxSize     int64
+}
+
+// pageSizes reads the page_default and page_max settings. fallbackDefault is
+// the endpoint's own default limit, used when page_default is not set; a
+// negative value means every row, or page_max rows once that is set. Settings
+// that are not positive integers are logged and ignored.
+func (h *Handler) pageSizes(ctx context.Context, fallbackDefault int64) (pageSizes, error) {
+	sizes := pageSizes{defaultSize: fallbackDefault, maxSize: maxPageSize}
+
+	size, maxSet, err := h.pageSizeSetting(ctx, "page_max")
+	if err != nil {
+		return sizes, err
+	} else if maxSet {
+		sizes.maxSize = size
+	}
+	if size, ok, err := h.pageSizeSetting(ctx, "page_default"); err != nil {
+		return sizes, err
+	} else if ok {
+		sizes.defaultSize = size
+	}
+
+	if sizes.defaultSize > sizes.maxSize || (sizes.defaultSize < 0 && maxSet) {
+		sizes.defaultSize = sizes.maxSize
+	}
+	return sizes, nil
+}
+
+// pageSizeSetting returns the positive integer stored under key, reporting
+// false when the setting is absent or unusable
+func (h *Handler) pageSizeSetting(ctx context.Context, key string) (int64, bool, error) {
+	setting, err := h.repo.GetSetting(ctx, key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	size, err := strconv.ParseInt(setting.Value, 10, 64)
+	if err != nil || size < 1 {
+		h.logger.Warn("ignoring invalid page size setting", zap.String("key", key), zap.String("value", setting.Value))
+		return 0, false, nil
+	}
+	return size, true, nil
+}
+
+// parsePagination reads limit and offset from the query string, bounded by the
+// page size settings. On invalid input it writes a 400 response and returns
+// false.
+func (h *Handler) parsePagination(c *gin.Context, fallbackDefault int64) (limit, offset int64, ok bool) {
+	sizes, err := h.pageSizes(c.Request.Context(), fallbackDefault)
+	if err != nil {
+		h.logger.Error("failed to load page size settings", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to load page size settings",
+			"data":  nil,
+		})
+		return 0, 0, false
+	}
+
+	limit = sizes.defaultSize
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err = strconv.ParseInt(limitStr, 10, 64)
+		if err != nil || limit < 1 || limit > sizes.maxSize {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "limit must be between 1 and " + strconv.FormatInt(sizes.maxSize, 10),
+				"data":  nil,
+			})
+			return 0, 0, false
+		}
+	}
+
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		offset, err = strconv.ParseInt(offsetStr, 10, 64)
+		if err != nil || offset < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "offset must be a non-negative integer",
+				"data":  nil,
+			})
+			return 0, 0, false
+		}
+	}
+
+	return limit, offset, true
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"unicode"
+)
+
+// defaultPasswordMinLength is used when PASSWORD_MIN_LENGTH is unset or invalid.
+const defaultPasswordMinLength = 8
+
+// PasswordPolicy describes the minimum strength required of user passwords.
+type PasswordPolicy struct {
+	MinLength         int
+	RequireComplexity bool
+}
+
+// PasswordPolicyFromEnv builds the policy from PASSWORD_MIN_LENGTH (default 8)
+// and PASSWORD_REQUIRE_COMPLEXITY (default false). When complexity is required
+// a password must mix upper case, lower case, digits and symbols.
+func PasswordPolicyFromEnv() PasswordPolicy {
+	policy := PasswordPolicy{MinLength: defaultPasswordMinLength}
+	if v, err := strconv.Atoi(os.Getenv("PASSWORD_MIN_LENGTH")); err == nil && v > 0 {
+		policy.MinLength = v
+	}
+	if v, err := strconv.ParseBool(os.Getenv("PASSWORD_REQUIRE_COMPLEXITY")); err == nil {
+		policy.RequireComplexity = v
+	}
+	return policy
+}
+
+// Validate returns an error naming the first rule the password fails, or nil.
+func (p PasswordPolicy) Validate(password string) error {
+	if len([]rune(password)) < p.MinLength {
+		return errors.New("password must be at least " + strconv.Itoa(p.MinLength) + " characters")
+	}
+	if !p.RequireComplexity {
+		return nil
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+	switch {
+	case !hasUpper:
+		return errors.New("password must contain an uppercase letter")
+	case !hasLower:
+		return errors.New("password must contain a lowercase letter")
+	case !hasDigit:
+		return errors.New("password must contain a digit")
+	case !hasSymbol:
+		return errors.New("password must contain a symbol")
+	}
+	return nil
+}
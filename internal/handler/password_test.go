@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+	"github.com/piotrzalecki/budget-api/pkg/model"
+)
+
+func TestPasswordPolicyFromEnv(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		t.Setenv("PASSWORD_MIN_LENGTH", "")
+		t.Setenv("PASSWORD_REQUIRE_COMPLEXITY", "")
+
+		policy := PasswordPolicyFromEnv()
+		assert.Equal(t, 8, policy.MinLength)
+		assert.False(t, policy.RequireComplexity)
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		t.Setenv("PASSWORD_MIN_LENGTH", "12")
+		t.Setenv("PASSWORD_REQUIRE_COMPLEXITY", "true")
+
+		policy := PasswordPolicyFromEnv()
+		assert.Equal(t, 12, policy.MinLength)
+		assert.True(t, policy.RequireComplexity)
+	})
+}
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   PasswordPolicy
+		password string
+		wantErr  string
+	}{
+		{
+			name:     "too short",
+			policy:   PasswordPolicy{MinLength: 8},
+			password: "short",
+			wantErr:  "password must be at least 8 characters",
+		},
+		{
+			name:     "acceptable length",
+			policy:   PasswordPolicy{MinLength: 8},
+			password: "longenough",
+		},
+		{
+			name:     "missing uppercase",
+			policy:   PasswordPolicy{MinLength: 8, RequireComplexity: true},
+			password: "lowercase1!",
+			wantErr:  "password must contain an uppercase letter",
+		},
+		{
+			name:     "missing digit",
+			policy:   PasswordPolicy{MinLength: 8, RequireComplexity: true},
+			password: "NoDigits!!",
+			wantErr:  "password must contain a digit",
+		},
+		{
+			name:     "missing symbol",
+			policy:   PasswordPolicy{MinLength: 8, RequireComplexity: true},
+			password: "NoSymbol123",
+			wantErr:  "password must contain a symbol",
+		},
+		{
+			name:     "acceptable complex password",
+			policy:   PasswordPolicy{MinLength: 8, RequireComplexity: true},
+			password: "Str0ng!Pass",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate(tt.password)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateUserPasswordPolicy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("PASSWORD_MIN_LENGTH", "10")
+	t.Setenv("PASSWORD_REQUIRE_COMPLEXITY", "false")
+
+	tests := []struct {
+		name           string
+		password       string
+		expectedStatus int
+	}{
+		{name: "too short password", password: "short", expectedStatus: http.StatusUnprocessableEntity},
+		{name: "acceptable password", password: "acceptable-pass", expectedStatus: http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockRepository)
+			if tt.expectedStatus == http.StatusCreated {
+				mockRepo.On("CreateUser", mock.Anything, mock.AnythingOfType("repo.CreateUserParams")).
+					Return(repo.User{ID: 2, Email: "new@example.com"}, nil)
+			}
+
+			h := NewHandler(mockRepo, zap.NewNop())
+			router := gin.New()
+			router.POST("/users", ValidateRequest[model.CreateUserRequest](), h.CreateUser)
+
+			body, _ := json.Marshal(model.CreateUserRequest{Email: "new@example.com", Password: tt.password})
+			req := httptest.NewRequest("POST", "/users", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusUnprocessableEntity {
+				assert.Contains(t, w.Body.String(), "password must be at least 10 characters")
+				mockRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateUserPasswordPolicy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("PASSWORD_MIN_LENGTH", "8")
+	t.Setenv("PASSWORD_REQUIRE_COMPLEXITY", "true")
+
+	mockRepo := new(MockRepository)
+	h := NewHandler(mockRepo, zap.NewNop())
+	router := gin.New()
+	router.PATCH("/users/:id", ValidateRequest[model.UpdateUserRequest](), h.UpdateUser)
+
+	body, _ := json.Marshal(map[string]interface{}{"password": "alllowercase1!"})
+	req := httptest.NewRequest("PATCH", "/users/1", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), "password must contain an uppercase letter")
+	mockRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+	"github.com/piotrzalecki/budget-api/pkg/model"
+)
+
+// CreateReceipt handles POST /api/v1/transactions/:id/receipts
+// @Summary Attach a receipt to a transaction
+// @Description Store a reference (URL and content type) to a receipt kept elsewhere. The file itself is not uploaded.
+// @Tags transactions
+// @Accept json
+// @Produce json
+// @Param id path int true "Transaction ID"
+// @Param receipt body model.CreateReceiptRequest true "Receipt reference"
+// @Success 201 {object} model.ReceiptResponse "Receipt attached"
+// @Failure 400 {object} map[string]interface{} "Invalid request data"
+// @Failure 404 {object} map[string]interface{} "Transaction not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /transactions/{id}/receipts [post]
+func (h *Handler) CreateReceipt(c *gin.Context) {
+	id, ok := h.findReceiptTransaction(c)
+	if !ok {
+		return
+	}
+
+	request, ok := GetValidatedRequest[model.CreateReceiptRequest](c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to get validated request",
+			"data":  nil,
+		})
+		return
+	}
+
+	params := repo.CreateReceiptParams{
+		TransactionID: id,
+		Url:           request.URL,
+	}
+	if request.ContentType != nil {
+		params.ContentType = sql.NullString{String: *request.ContentType, Valid: true}
+	}
+
+	receipt, err := h.repo.CreateReceipt(c.Request.Context(), params)
+	if err != nil {
+		h.logger.Error("failed to create receipt", zap.Error(err), zap.Int64("transaction_id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to create receipt",
+			"data":  nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":  receiptToResponse(receipt),
+		"error": nil,
+	})
+}
+
+// GetReceipts handles GET /api/v1/transactions/:id/receipts
+// @Summary List a transaction's receipts
+// @Description List the receipt references attached to a transaction, oldest first
+// @Tags transactions
+// @Produce json
+// @Param id path int true "Transaction ID"
+// @Success 200 {array} model.ReceiptResponse "Receipts"
+// @Failure 400 {object} map[string]interface{} "Invalid transaction ID"
+// @Failure 404 {object} map[string]interface{} "Transaction not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /transactions/{id}/receipts [get]
+func (h *Handler) GetReceipts(c *gin.Context) {
+	id, ok := h.findReceiptTransaction(c)
+	if !ok {
+		return
+	}
+
+	receipts, err := h.repo.ListReceipts(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("failed to fetch receipts", zap.Error(err), zap.Int64("transaction_id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch receipts",
+			"data":  nil,
+		})
+		return
+	}
+
+	response := make([]model.ReceiptResponse, len(receipts))
+	for i, receipt := range receipts {
+		response[i] = receiptToResponse(receipt)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// DeleteReceipt handles DELETE /api/v1/transactions/:id/receipts/:receipt_id
+// @Summary Remove a receipt from a transaction
+// @Description Delete a receipt reference. The file it points to is not touched.
+// @Tags transactions
+// @Param id path int true "Transaction ID"
+// @Param receipt_id path int true "Receipt ID"
+// @Success 204 "Receipt deleted"
+// @Failure 400 {object} map[string]interface{} "Invalid transaction or receipt ID"
+// @Failure 404 {object} map[string]interface{} "Transaction or receipt not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /transactions/{id}/receipts/{receipt_id} [delete]
+func (h *Handler) DeleteReceipt(c *gin.Context) {
+	id, ok := h.findReceiptTransaction(c)
+	if !ok {
+		return
+	}
+
+	receiptID, err := strconv.ParseInt(c.Param("receipt_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid receipt ID",
+			"data":  nil,
+		})
+		return
+	}
+
+	// A receipt is only reachable through the transaction it belongs to
+	receipt, err := h.repo.GetReceiptByID(c.Request.Context(), receiptID)
+	if err == sql.ErrNoRows || (err == nil && receipt.TransactionID != id) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "receipt not found",
+			"data":  nil,
+		})
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to fetch receipt", zap.Error(err), zap.Int64("receipt_id", receiptID))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch receipt",
+			"data":  nil,
+		})
+		return
+	}
+
+	if err := h.repo.DeleteReceipt(c.Request.Context(), receiptID); err != nil {
+		h.logger.Error("failed to delete receipt", zap.Error(err), zap.Int64("receipt_id", receiptID))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to delete receipt",
+			"data":  nil,
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// findReceiptTransaction parses the :id parameter and checks the transaction
+// exists, writing a 400 or 404 response when it does not
+func (h *Handler) findReceiptTransaction(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid transaction ID",
+			"data":  nil,
+		})
+		return 0, false
+	}
+
+	// TODO: Check if user has access to this transaction when authentication is implemented
+	if _, err := h.repo.GetTransactionByID(c.Request.Context(), id); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "transaction not found",
+				"data":  nil,
+			})
+			return 0, false
+		}
+		h.logger.Error("failed to fetch transaction", zap.Error(err), zap.Int64("id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch transaction",
+			"data":  nil,
+		})
+		return 0, false
+	}
+	return id, true
+}
+
+func receiptToResponse(receipt repo.Receipt) model.ReceiptResponse {
+	return model.ReceiptResponse{
+		ID:            receipt.ID,
+		TransactionID: receipt.TransactionID,
+		URL:           receipt.Url,
+		ContentType:   model.SQLNullStringToString(receipt.ContentType),
+		UploadedAt:    receipt.UploadedAt.Time,
+	}
+}
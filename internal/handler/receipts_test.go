@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+	"github.com/piotrzalecki/budget-api/pkg/model"
+)
+
+func TestReceiptsIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+
+	txn, err := repository.CreateTransaction(context.Background(), repo.CreateTransactionParams{
+		UserID:      1,
+		AmountPence: -4599,
+		TDate:       time.Date(2031, 5, 17, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	receiptsURL := "/transactions/" + strconv.FormatInt(txn.ID, 10) + "/receipts"
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.POST("/transactions/:id/receipts", ValidateRequest[model.CreateReceiptRequest](), h.CreateReceipt)
+	router.GET("/transactions/:id/receipts", h.GetReceipts)
+	router.DELETE("/transactions/:id/receipts/:receipt_id", h.DeleteReceipt)
+
+	send := func(method, url string, body interface{}) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req := httptest.NewRequest(method, url, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	list := func() []model.ReceiptResponse {
+		w := send("GET", receiptsURL, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Data []model.ReceiptResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data
+	}
+
+	var attached model.ReceiptResponse
+	t.Run("attaches receipts", func(t *testing.T) {
+		w := send("POST", receiptsURL, map[string]interface{}{
+			"url":          "https://files.example.com/receipts/till-1.jpg",
+			"content_type": "image/jpeg",
+		})
+		require.Equal(t, http.StatusCreated, w.Code)
+		var response struct {
+			Data model.ReceiptResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		attached = response.Data
+		assert.Equal(t, txn.ID, attached.TransactionID)
+		require.NotNil(t, attached.ContentType)
+		assert.Equal(t, "image/jpeg", *attached.ContentType)
+		assert.False(t, attached.UploadedAt.IsZero())
+
+		w = send("POST", receiptsURL, map[string]interface{}{"url": "https://files.example.com/receipts/invoice.pdf"})
+		require.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("lists receipts in order", func(t *testing.T) {
+		receipts := list()
+		require.Len(t, receipts, 2)
+		assert.Equal(t, "https://files.example.com/receipts/till-1.jpg", receipts[0].URL)
+		assert.Equal(t, "https://files.example.com/receipts/invoice.pdf", receipts[1].URL)
+		assert.Nil(t, receipts[1].ContentType)
+	})
+
+	t.Run("rejects an invalid URL", func(t *testing.T) {
+		w := send("POST", receiptsURL, map[string]interface{}{"url": "not a url"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("unknown transaction", func(t *testing.T) {
+		w := send("GET", "/transactions/999999/receipts", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("deletes a receipt", func(t *testing.T) {
+		w := send("DELETE", receiptsURL+"/"+strconv.FormatInt(attached.ID, 10), nil)
+		require.Equal(t, http.StatusNoContent, w.Code)
+		assert.Len(t, list(), 1)
+
+		w = send("DELETE", receiptsURL+"/"+strconv.FormatInt(attached.ID, 10), nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -1,14 +1,21 @@
 package handler
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"math"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 	"github.com/piotrzalecki/budget-api/internal/repo"
+	"github.com/piotrzalecki/budget-api/internal/scheduler"
 	"github.com/piotrzalecki/budget-api/pkg/model"
 )
 
@@ -19,7 +26,8 @@ import (
 // @Accept json
 // @Produce json
 // @Param recurring body model.CreateRecurringRequest true "Recurring transaction data"
-// @Success 200 {object} map[string]interface{} "Recurring transaction created successfully"
+// @Param backfill query bool false "Allow a first_due_date more than 90 days (or the recurring_max_backfill_days setting) ago"
+// @Success 201 {object} map[string]interface{} "ID of the created recurring transaction"
 // @Failure 400 {object} map[string]interface{} "Invalid request data"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security ApiKeyAuth
@@ -73,6 +81,10 @@ func (h *Handler) CreateRecurring(c *gin.Context) {
 	// For now, use a default user ID of 1
 	userID := int64(1)
 
+	if h.rejectLongBackfill(c, userID, firstDueDate) {
+		return
+	}
+
 	// Create recurring parameters
 	params := repo.CreateRecurringParams{
 		UserID:       userID,
@@ -84,12 +96,20 @@ func (h *Handler) CreateRecurring(c *gin.Context) {
 		NextDueDate:  firstDueDate, // Initially same as first due date
 		EndDate:      endDate,
 		Active:       true,
+		GroupName:    recurringGroupName(request.Group),
 	}
 
-	// Create recurring rule in database
-	recurring, err := h.repo.CreateRecurring(c.Request.Context(), params)
+	// Create the recurring rule and its tag associations atomically
+	recurring, err := h.createRecurringWithTags(c, params, request.TagIDs)
 	if err != nil {
-		h.logger.Error("failed to create recurring rule", zap.Error(err))
+		var tagErr *invalidTagError
+		if errors.As(err, &tagErr) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": tagErr.Error(),
+				"data":  nil,
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "failed to create recurring rule",
 			"data":  nil,
@@ -97,17 +117,30 @@ func (h *Handler) CreateRecurring(c *gin.Context) {
 		return
 	}
 
-	// Handle tag associations if provided
-	if len(request.TagIDs) > 0 {
-		for _, tagID := range request.TagIDs {
+	c.JSON(http.StatusCreated, gin.H{
+		"data": gin.H{
+			"id": recurring.ID,
+		},
+		"error": nil,
+	})
+}
+
+// createRecurringWithTags creates a recurring rule and its tag associations
+// atomically. An unknown tag is reported as *invalidTagError.
+func (h *Handler) createRecurringWithTags(c *gin.Context, params repo.CreateRecurringParams, tagIDs []int64) (repo.Recurring, error) {
+	var recurring repo.Recurring
+	err := h.repo.WithTx(c.Request.Context(), func(txRepo repo.Repository) error {
+		var err error
+		recurring, err = txRepo.CreateRecurring(c.Request.Context(), params)
+		if err != nil {
+			h.logger.Error("failed to create recurring rule", zap.Error(err))
+			return err
+		}
+
+		for _, tagID := range tagIDs {
 			// Verify tag exists
-			_, err := h.repo.GetTagByID(c.Request.Context(), tagID)
-			if err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"error": "invalid tag ID: " + strconv.FormatInt(tagID, 10),
-					"data":  nil,
-				})
-				return
+			if _, err := txRepo.GetTagByID(c.Request.Context(), tagID); err != nil {
+				return &invalidTagError{tagID: tagID}
 			}
 
 			// Create recurring-tag association
@@ -115,24 +148,109 @@ func (h *Handler) CreateRecurring(c *gin.Context) {
 				RecurringID: recurring.ID,
 				TagID:       tagID,
 			}
-			err = h.repo.CreateRecurringTag(c.Request.Context(), tagParams)
-			if err != nil {
+			if err := txRepo.CreateRecurringTag(c.Request.Context(), tagParams); err != nil {
 				h.logger.Error("failed to associate tag with recurring rule", zap.Error(err), zap.Int64("tag_id", tagID))
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"error": "failed to associate tag with recurring rule",
-					"data":  nil,
-				})
-				return
+				return err
+			}
+		}
+		return nil
+	})
+	return recurring, err
+}
+
+// getLiveRecurring returns the recurring rule with the given ID, treating a
+// soft-deleted rule as missing so it can't be changed any further
+func (h *Handler) getLiveRecurring(ctx context.Context, id int64) (repo.Recurring, error) {
+	rule, err := h.repo.GetRecurringByID(ctx, id)
+	if err != nil {
+		return repo.Recurring{}, err
+	}
+	if rule.DeletedAt.Valid {
+		return repo.Recurring{}, sql.ErrNoRows
+	}
+	return rule, nil
+}
+
+// recurringGroupName normalises an optional group name. Blank names mean no group.
+func recurringGroupName(group *string) sql.NullString {
+	trimmed := model.TrimStringPtr(group)
+	if trimmed == nil || *trimmed == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *trimmed, Valid: true}
+}
+
+// monthlyEquivalentPence converts a rule's amount to its average cost per month
+// over the twelve months from today.
+//
+// A rule without an end date inside that window runs all year, so its cost is
+// normalised from its frequency: amount * occurrences per year / 12 / interval,
+// with 365 days and 52 weeks to the year. A rule ending inside the window is
+// prorated instead: amount * (occurrences from its next due date up to and
+// including the end date) / 12.
+func monthlyEquivalentPence(rule repo.Recurring, today time.Time) int64 {
+	horizon := today.AddDate(1, 0, 0)
+	if rule.EndDate.Valid && rule.EndDate.Time.Before(horizon) {
+		occurrences := 0
+		next := rule
+		for !next.NextDueDate.After(rule.EndDate.Time) && next.NextDueDate.Before(horizon) {
+			occurrences++
+			advanced := scheduler.NextDueDate(next)
+			if !advanced.After(next.NextDueDate) {
+				// The frequency or interval never moves the date forward
+				break
 			}
+			next.NextDueDate = advanced
 		}
+		return int64(math.Round(float64(rule.AmountPence) * float64(occurrences) / 12))
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"data": gin.H{
-			"id": recurring.ID,
-		},
-		"error": nil,
-	})
+	interval := float64(rule.IntervalN)
+	if interval < 1 {
+		interval = 1
+	}
+	perYear := model.Frequency(rule.Frequency).PerYear()
+	if perYear == 0 {
+		perYear = 12
+	}
+	return int64(math.Round(float64(rule.AmountPence) * perYear / 12 / interval))
+}
+
+// recurringListFilter holds the optional query parameters shared by the
+// recurring list endpoints
+type recurringListFilter struct {
+	frequency sql.NullString
+	limit     int64
+	offset    int64
+}
+
+// parseRecurringListFilter reads frequency, limit and offset from the query
+// string. Without a limit every matching rule is returned unless the
+// page_default setting says otherwise. On invalid input it writes an error
+// response and returns false.
+func (h *Handler) parseRecurringListFilter(c *gin.Context) (recurringListFilter, bool) {
+	var filter recurringListFilter
+
+	if frequency := c.Query("frequency"); frequency != "" {
+		if !model.Frequency(frequency).Valid() {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "frequency must be one of " + strings.Join(frequencyNames(), ", "),
+				"data":  nil,
+			})
+			return filter, false
+		}
+		filter.frequency = sql.NullString{String: frequency, Valid: true}
+	}
+
+	// SQLite treats a negative LIMIT as no limit
+	limit, offset, ok := h.parsePagination(c, -1)
+	if !ok {
+		return filter, false
+	}
+	filter.limit = limit
+	filter.offset = offset
+
+	return filter, true
 }
 
 // GetRecurring handles GET /api/v1/recurring
@@ -141,7 +259,12 @@ func (h *Handler) CreateRecurring(c *gin.Context) {
 // @Tags recurring
 // @Accept json
 // @Produce json
+// @Param frequency query string false "Only return rules with this frequency" Enums(daily, weekly, monthly, yearly)
+// @Param limit query int false "Maximum number of rules to return (max 100 or the page_max setting; default the page_default setting, else page_max if set, else all)"
+// @Param offset query int false "Number of rules to skip (default 0)"
 // @Success 200 {object} map[string]interface{} "List of recurring transactions"
+// @Header 200 {integer} X-Total-Count "Number of rules matching the filters, ignoring limit and offset"
+// @Failure 400 {object} map[string]interface{} "Invalid filter or pagination parameters"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security ApiKeyAuth
 // @Router /recurring [get]
@@ -150,8 +273,18 @@ func (h *Handler) GetRecurring(c *gin.Context) {
 	// For now, use a default user ID of 1
 	userID := int64(1)
 
+	filter, ok := h.parseRecurringListFilter(c)
+	if !ok {
+		return
+	}
+
 	// Get all recurring rules for user
-	recurringRules, err := h.repo.ListRecurring(c.Request.Context(), userID)
+	recurringRules, err := h.repo.ListRecurring(c.Request.Context(), repo.ListRecurringParams{
+		UserID:    userID,
+		Frequency: filter.frequency,
+		Limit:     filter.limit,
+		Offset:    filter.offset,
+	})
 	if err != nil {
 		h.logger.Error("failed to fetch recurring rules", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -161,6 +294,20 @@ func (h *Handler) GetRecurring(c *gin.Context) {
 		return
 	}
 
+	// Count with the same filters so paginated clients know the full size
+	total, err := h.repo.CountRecurring(c.Request.Context(), repo.CountRecurringParams{
+		UserID:    userID,
+		Frequency: filter.frequency,
+	})
+	if err != nil {
+		h.logger.Error("failed to count recurring rules", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to count recurring rules",
+			"data":  nil,
+		})
+		return
+	}
+
 	// Convert to response DTOs
 	response := make([]model.RecurringResponse, len(recurringRules))
 	for i, rule := range recurringRules {
@@ -200,12 +347,16 @@ func (h *Handler) GetRecurring(c *gin.Context) {
 			Active:        rule.Active,
 			CreatedAt:     rule.CreatedAt.Time,
 			TagIDs:        tagIDs,
+			Group:         model.SQLNullStringToString(rule.GroupName),
+			DeletedAt:     model.SQLNullTimeToTimePtr(rule.DeletedAt),
 		}
 	}
 
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
 	c.JSON(http.StatusOK, gin.H{
 		"data":  response,
 		"error": nil,
+		"total": total,
 	})
 }
 
@@ -283,6 +434,8 @@ func (h *Handler) GetRecurringByID(c *gin.Context) {
 		Active:        rule.Active,
 		CreatedAt:     rule.CreatedAt.Time,
 		TagIDs:        tagIDs,
+		Group:         model.SQLNullStringToString(rule.GroupName),
+		DeletedAt:     model.SQLNullTimeToTimePtr(rule.DeletedAt),
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -299,7 +452,7 @@ func (h *Handler) GetRecurringByID(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Recurring transaction ID"
 // @Param recurring body model.UpdateRecurringRequest true "Update data"
-// @Success 200 {object} map[string]interface{} "Recurring transaction updated successfully"
+// @Success 204 "Recurring transaction updated successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid request data"
 // @Failure 404 {object} map[string]interface{} "Recurring transaction not found"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
@@ -328,7 +481,7 @@ func (h *Handler) UpdateRecurring(c *gin.Context) {
 	}
 
 	// Get existing recurring rule
-	existingRule, err := h.repo.GetRecurringByID(c.Request.Context(), id)
+	existingRule, err := h.getLiveRecurring(c.Request.Context(), id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "recurring rule not found",
@@ -350,6 +503,7 @@ func (h *Handler) UpdateRecurring(c *gin.Context) {
 		NextDueDate:  existingRule.NextDueDate,
 		EndDate:      existingRule.EndDate,
 		Active:       existingRule.Active,
+		GroupName:    existingRule.GroupName,
 	}
 
 	// Update fields if provided
@@ -377,6 +531,11 @@ func (h *Handler) UpdateRecurring(c *gin.Context) {
 		updateParams.IntervalN = int64(*request.IntervalN)
 	}
 
+	if (request.Frequency != nil || request.IntervalN != nil) &&
+		rejectIntervalOutOfRange(c, updateParams.Frequency, updateParams.IntervalN) {
+		return
+	}
+
 	if request.FirstDueDate != nil {
 		firstDueDate, err := model.ParseDate(*request.FirstDueDate)
 		if err != nil {
@@ -409,40 +568,36 @@ func (h *Handler) UpdateRecurring(c *gin.Context) {
 		updateParams.Active = *request.Active
 	}
 
-	// Update recurring rule
-	_, err = h.repo.UpdateRecurring(c.Request.Context(), updateParams)
-	if err != nil {
-		h.logger.Error("failed to update recurring rule", zap.Error(err), zap.Int64("id", id))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "failed to update recurring rule",
-			"data":  nil,
-		})
-		return
+	if request.Group != nil {
+		updateParams.GroupName = recurringGroupName(request.Group)
 	}
 
-	// Handle tag associations if provided
-	if request.TagIDs != nil {
-		// Delete existing tags
-		err = h.repo.DeleteAllRecurringTags(c.Request.Context(), id)
-		if err != nil {
-			h.logger.Error("failed to remove existing tags", zap.Error(err), zap.Int64("recurring_id", id))
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "failed to remove existing tags",
-				"data":  nil,
-			})
-			return
+	// Update the rule, its pause/resume history and its tags atomically
+	err = h.repo.WithTx(c.Request.Context(), func(txRepo repo.Repository) error {
+		if _, err := txRepo.UpdateRecurring(c.Request.Context(), updateParams); err != nil {
+			h.logger.Error("failed to update recurring rule", zap.Error(err), zap.Int64("id", id))
+			return err
+		}
+
+		// Record pause/resume when the active flag changed
+		if updateParams.Active != existingRule.Active {
+			if err := h.recordRecurringHistory(c, txRepo, id, updateParams.Active); err != nil {
+				return err
+			}
 		}
 
-		// Add new tags
+		// Replace tag associations if provided
+		if request.TagIDs == nil {
+			return nil
+		}
+		if err := txRepo.DeleteAllRecurringTags(c.Request.Context(), id); err != nil {
+			h.logger.Error("failed to remove existing tags", zap.Error(err), zap.Int64("recurring_id", id))
+			return err
+		}
 		for _, tagID := range request.TagIDs {
 			// Verify tag exists
-			_, err := h.repo.GetTagByID(c.Request.Context(), tagID)
-			if err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"error": "invalid tag ID: " + strconv.FormatInt(tagID, 10),
-					"data":  nil,
-				})
-				return
+			if _, err := txRepo.GetTagByID(c.Request.Context(), tagID); err != nil {
+				return &invalidTagError{tagID: tagID}
 			}
 
 			// Create recurring-tag association
@@ -450,27 +605,35 @@ func (h *Handler) UpdateRecurring(c *gin.Context) {
 				RecurringID: id,
 				TagID:       tagID,
 			}
-			err = h.repo.CreateRecurringTag(c.Request.Context(), tagParams)
-			if err != nil {
+			if err := txRepo.CreateRecurringTag(c.Request.Context(), tagParams); err != nil {
 				h.logger.Error("failed to associate tag with recurring rule", zap.Error(err), zap.Int64("tag_id", tagID))
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"error": "failed to associate tag with recurring rule",
-					"data":  nil,
-				})
-				return
+				return err
 			}
 		}
+		return nil
+	})
+	if err != nil {
+		var tagErr *invalidTagError
+		if errors.As(err, &tagErr) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": tagErr.Error(),
+				"data":  nil,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to update recurring rule",
+			"data":  nil,
+		})
+		return
 	}
 
-	c.JSON(http.StatusNoContent, gin.H{
-		"data":  nil,
-		"error": nil,
-	})
+	c.Status(http.StatusNoContent)
 }
 
 // DeleteRecurring handles DELETE /api/v1/recurring/:id
 // @Summary Delete a recurring transaction
-// @Description Delete a recurring transaction rule
+// @Description Soft-delete a recurring transaction rule. It stops generating transactions and is hidden from listings, but transactions it generated can still look it up by ID.
 // @Tags recurring
 // @Accept json
 // @Produce json
@@ -493,6 +656,58 @@ func (h *Handler) DeleteRecurring(c *gin.Context) {
 		return
 	}
 
+	// Check if recurring rule exists and hasn't already been deleted
+	_, err = h.getLiveRecurring(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "recurring rule not found",
+			"data":  nil,
+		})
+		return
+	}
+
+	// TODO: Check if user has access to this recurring rule when authentication is implemented
+
+	// Keep the rule, its tags and history so generated transactions still
+	// resolve their source
+	err = h.repo.SoftDeleteRecurring(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("failed to delete recurring rule", zap.Error(err), zap.Int64("id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to delete recurring rule",
+			"data":  nil,
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// HardDeleteRecurring handles DELETE /admin/recurring/:id
+// @Summary Permanently delete a recurring transaction
+// @Description Permanently delete a recurring transaction rule, including soft-deleted ones, with its tags and history. Transactions it generated are kept but unlinked from it.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Recurring transaction ID"
+// @Success 204 "Recurring transaction deleted successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid recurring transaction ID"
+// @Failure 404 {object} map[string]interface{} "Recurring transaction not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /admin/recurring/{id} [delete]
+func (h *Handler) HardDeleteRecurring(c *gin.Context) {
+	// Parse ID from URL
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid recurring rule ID",
+			"data":  nil,
+		})
+		return
+	}
+
 	// Check if recurring rule exists
 	_, err = h.repo.GetRecurringByID(c.Request.Context(), id)
 	if err != nil {
@@ -516,6 +731,39 @@ func (h *Handler) DeleteRecurring(c *gin.Context) {
 		return
 	}
 
+	// Delete its pause/resume history
+	err = h.repo.DeleteRecurringHistory(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("failed to remove recurring rule history", zap.Error(err), zap.Int64("recurring_id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to remove recurring rule history",
+			"data":  nil,
+		})
+		return
+	}
+
+	// Remove its tag links
+	err = h.repo.DeleteAllRecurringTags(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("failed to remove recurring rule tags", zap.Error(err), zap.Int64("recurring_id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to remove recurring rule tags",
+			"data":  nil,
+		})
+		return
+	}
+
+	// Keep the transactions it generated, unlinked from the rule
+	err = h.repo.DetachRecurringTransactions(c.Request.Context(), sql.NullInt64{Int64: id, Valid: true})
+	if err != nil {
+		h.logger.Error("failed to detach generated transactions", zap.Error(err), zap.Int64("recurring_id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to detach generated transactions",
+			"data":  nil,
+		})
+		return
+	}
+
 	// Delete the recurring rule
 	err = h.repo.DeleteRecurring(c.Request.Context(), id)
 	if err != nil {
@@ -527,8 +775,74 @@ func (h *Handler) DeleteRecurring(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusNoContent, gin.H{
-		"data":  nil,
+	c.Status(http.StatusNoContent)
+}
+
+// RepairRecurring handles POST /admin/recurring/repair
+// @Summary Repair overdue recurring rules
+// @Description Move every active rule whose next due date is in the past to its first occurrence strictly after today (in the owner's timezone), without creating the missed transactions
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.RepairRecurringResponse "Number of rules checked and repaired"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /admin/recurring/repair [post]
+func (h *Handler) RepairRecurring(c *gin.Context) {
+	users, err := h.repo.ListUsers(c.Request.Context())
+	if err != nil {
+		h.logger.Error("failed to list users", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to list users",
+			"data":  nil,
+		})
+		return
+	}
+
+	var response model.RepairRecurringResponse
+	for _, user := range users {
+		today := h.userToday(c.Request.Context(), user.ID)
+		err := h.repo.WithTx(c.Request.Context(), func(txRepo repo.Repository) error {
+			rules, err := txRepo.ListActiveRecurring(c.Request.Context(), repo.ListActiveRecurringParams{
+				UserID: user.ID,
+				Limit:  -1,
+			})
+			if err != nil {
+				return err
+			}
+			for _, rule := range rules {
+				response.Checked++
+				if !rule.NextDueDate.Before(today) {
+					continue
+				}
+				next, ok := scheduler.NextDueAfter(rule, today)
+				if !ok {
+					h.logger.Warn("cannot advance recurring rule", zap.Int64("id", rule.ID), zap.String("frequency", rule.Frequency))
+					continue
+				}
+				if err := txRepo.UpdateRecurringNextDue(c.Request.Context(), repo.UpdateRecurringNextDueParams{
+					NextDueDate: next,
+					ID:          rule.ID,
+				}); err != nil {
+					return err
+				}
+				response.Repaired++
+			}
+			return nil
+		})
+		if err != nil {
+			h.logger.Error("failed to repair recurring rules", zap.Error(err), zap.Int64("user_id", user.ID))
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "failed to repair recurring rules",
+				"data":  nil,
+			})
+			return
+		}
+	}
+
+	h.logger.Info("repaired recurring rules", zap.Int("checked", response.Checked), zap.Int("repaired", response.Repaired))
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
 		"error": nil,
 	})
 }
@@ -617,6 +931,8 @@ func (h *Handler) GetRecurringByTag(c *gin.Context) {
 			Active:        rule.Active,
 			CreatedAt:     rule.CreatedAt.Time,
 			TagIDs:        tagIDs,
+			Group:         model.SQLNullStringToString(rule.GroupName),
+			DeletedAt:     model.SQLNullTimeToTimePtr(rule.DeletedAt),
 		}
 	}
 
@@ -632,7 +948,12 @@ func (h *Handler) GetRecurringByTag(c *gin.Context) {
 // @Tags recurring
 // @Accept json
 // @Produce json
+// @Param frequency query string false "Only return rules with this frequency" Enums(daily, weekly, monthly, yearly)
+// @Param limit query int false "Maximum number of rules to return (max 100 or the page_max setting; default the page_default setting, else page_max if set, else all)"
+// @Param offset query int false "Number of rules to skip (default 0)"
 // @Success 200 {object} map[string]interface{} "List of active recurring transactions"
+// @Header 200 {integer} X-Total-Count "Number of rules matching the filters, ignoring limit and offset"
+// @Failure 400 {object} map[string]interface{} "Invalid filter or pagination parameters"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security ApiKeyAuth
 // @Router /recurring/active [get]
@@ -641,8 +962,18 @@ func (h *Handler) ListActiveRecurring(c *gin.Context) {
 	// For now, use a default user ID of 1
 	userID := int64(1)
 
+	filter, ok := h.parseRecurringListFilter(c)
+	if !ok {
+		return
+	}
+
 	// Get active recurring rules for user
-	recurringRules, err := h.repo.ListActiveRecurring(c.Request.Context(), userID)
+	recurringRules, err := h.repo.ListActiveRecurring(c.Request.Context(), repo.ListActiveRecurringParams{
+		UserID:    userID,
+		Frequency: filter.frequency,
+		Limit:     filter.limit,
+		Offset:    filter.offset,
+	})
 	if err != nil {
 		h.logger.Error("failed to fetch active recurring rules", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -652,6 +983,20 @@ func (h *Handler) ListActiveRecurring(c *gin.Context) {
 		return
 	}
 
+	// Count with the same filters so paginated clients know the full size
+	total, err := h.repo.CountActiveRecurring(c.Request.Context(), repo.CountActiveRecurringParams{
+		UserID:    userID,
+		Frequency: filter.frequency,
+	})
+	if err != nil {
+		h.logger.Error("failed to count active recurring rules", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to count active recurring rules",
+			"data":  nil,
+		})
+		return
+	}
+
 	// Convert to response DTOs
 	response := make([]model.RecurringResponse, len(recurringRules))
 	for i, rule := range recurringRules {
@@ -691,12 +1036,16 @@ func (h *Handler) ListActiveRecurring(c *gin.Context) {
 			Active:        rule.Active,
 			CreatedAt:     rule.CreatedAt.Time,
 			TagIDs:        tagIDs,
+			Group:         model.SQLNullStringToString(rule.GroupName),
+			DeletedAt:     model.SQLNullTimeToTimePtr(rule.DeletedAt),
 		}
 	}
 
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
 	c.JSON(http.StatusOK, gin.H{
 		"data":  response,
 		"error": nil,
+		"total": total,
 	})
 }
 
@@ -707,7 +1056,7 @@ func (h *Handler) ListActiveRecurring(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "Recurring transaction ID"
-// @Success 200 {object} map[string]interface{} "Recurring transaction status toggled successfully"
+// @Success 204 "Recurring transaction status toggled successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid recurring transaction ID"
 // @Failure 404 {object} map[string]interface{} "Recurring transaction not found"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
@@ -726,7 +1075,7 @@ func (h *Handler) ToggleRecurringActive(c *gin.Context) {
 	}
 
 	// Check if recurring rule exists
-	_, err = h.repo.GetRecurringByID(c.Request.Context(), id)
+	rule, err := h.getLiveRecurring(c.Request.Context(), id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "recurring rule not found",
@@ -737,10 +1086,15 @@ func (h *Handler) ToggleRecurringActive(c *gin.Context) {
 
 	// TODO: Check if user has access to this recurring rule when authentication is implemented
 
-	// Toggle the active status
-	err = h.repo.ToggleRecurringActive(c.Request.Context(), id)
+	// Toggle the active status and record it as a pause or resume
+	err = h.repo.WithTx(c.Request.Context(), func(txRepo repo.Repository) error {
+		if err := txRepo.ToggleRecurringActive(c.Request.Context(), id); err != nil {
+			h.logger.Error("failed to toggle recurring rule status", zap.Error(err), zap.Int64("id", id))
+			return err
+		}
+		return h.recordRecurringHistory(c, txRepo, id, !rule.Active)
+	})
 	if err != nil {
-		h.logger.Error("failed to toggle recurring rule status", zap.Error(err), zap.Int64("id", id))
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "failed to toggle recurring rule status",
 			"data":  nil,
@@ -748,46 +1102,240 @@ func (h *Handler) ToggleRecurringActive(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusNoContent, gin.H{
-		"data":  nil,
-		"error": nil,
-	})
+	c.Status(http.StatusNoContent)
 }
 
-// GetRecurringDueOnDate handles GET /api/v1/recurring/due?date=YYYY-MM-DD
-// @Summary Get recurring transactions due on a date
-// @Description Get all recurring transaction rules that are due on a specific date
+// GetUpcomingRecurring handles GET /api/v1/recurring/upcoming
+// @Summary Forecast upcoming recurring transactions
+// @Description Project each active rule's due dates between from and from+days (inclusive) with a running total. Overdue occurrences before from are not included.
 // @Tags recurring
-// @Accept json
 // @Produce json
-// @Param date query string false "Date to check (YYYY-MM-DD format, defaults to today)"
-// @Success 200 {object} map[string]interface{} "List of recurring transactions due on the date"
-// @Failure 400 {object} map[string]interface{} "Invalid date format"
+// @Param days query int false "Number of days to look ahead (1-366, default 30)"
+// @Param from query string false "Start of the window (YYYY-MM-DD, defaults to today)"
+// @Success 200 {object} model.UpcomingRecurringResponse "Projected occurrences"
+// @Failure 400 {object} map[string]interface{} "Invalid parameters"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security ApiKeyAuth
-// @Router /recurring/due [get]
-func (h *Handler) GetRecurringDueOnDate(c *gin.Context) {
-	// Get date from query parameter
-	dateStr := c.Query("date")
-	if dateStr == "" {
-		// If no date provided, use today's date
-		dateStr = model.FormatDate(time.Now())
+// @Router /recurring/upcoming [get]
+func (h *Handler) GetUpcomingRecurring(c *gin.Context) {
+	days := 30
+	if daysStr := c.Query("days"); daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil || parsed < 1 || parsed > 366 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "days must be between 1 and 366",
+				"data":  nil,
+			})
+			return
+		}
+		days = parsed
 	}
 
-	// Parse the date
-	dueDate, err := model.ParseDate(dateStr)
+	// Default the window to start today
+	fromStr := c.DefaultQuery("from", model.FormatDate(h.clock.Now()))
+	from, err := model.ParseDate(fromStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "invalid date format. Use YYYY-MM-DD",
+			"error": "invalid from date format. Use YYYY-MM-DD",
 			"data":  nil,
 		})
 		return
 	}
+	to := from.AddDate(0, 0, days)
 
-	// Get recurring rules due on the specified date
-	recurringRules, err := h.repo.GetRecurringDueOnDate(c.Request.Context(), dueDate)
-	if err != nil {
-		h.logger.Error("failed to fetch recurring rules due on date", zap.Error(err), zap.String("date", dateStr))
+	// TODO: Get user ID from context when authentication is implemented
+	// For now, use a default user ID of 1
+	userID := int64(1)
+
+	rules, err := h.repo.ListActiveRecurring(c.Request.Context(), repo.ListActiveRecurringParams{
+		UserID: userID,
+		Limit:  -1,
+	})
+	if err != nil {
+		h.logger.Error("failed to fetch active recurring rules", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch active recurring rules",
+			"data":  nil,
+		})
+		return
+	}
+
+	occurrences, total := upcomingOccurrences(rules, from, to)
+	response := model.UpcomingRecurringResponse{
+		From:        model.FormatDate(from),
+		To:          model.FormatDate(to),
+		Occurrences: occurrences,
+		Total:       model.PenceToCurrency(total),
+		TotalPence:  total,
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// upcomingOccurrences projects the due dates of rules between from and to
+// (inclusive), ordered by date then rule ID, with a running total. It also
+// returns the sum of all occurrences.
+func upcomingOccurrences(rules []repo.Recurring, from, to time.Time) ([]model.UpcomingOccurrence, int64) {
+	type occurrence struct {
+		rule    repo.Recurring
+		dueDate time.Time
+	}
+	var occurrences []occurrence
+	for _, rule := range rules {
+		// Walk the rule forward the same way the scheduler would, stopping
+		// if the due date never advances (an unknown frequency or an
+		// interval_n below 1)
+		for projected := rule; !projected.NextDueDate.After(to); {
+			if rule.EndDate.Valid && projected.NextDueDate.After(rule.EndDate.Time) {
+				break
+			}
+			if !projected.NextDueDate.Before(from) {
+				occurrences = append(occurrences, occurrence{rule: rule, dueDate: projected.NextDueDate})
+			}
+			next := scheduler.NextDueDate(projected)
+			if !next.After(projected.NextDueDate) {
+				break
+			}
+			projected.NextDueDate = next
+		}
+	}
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		if !occurrences[i].dueDate.Equal(occurrences[j].dueDate) {
+			return occurrences[i].dueDate.Before(occurrences[j].dueDate)
+		}
+		return occurrences[i].rule.ID < occurrences[j].rule.ID
+	})
+
+	result := make([]model.UpcomingOccurrence, len(occurrences))
+	var total int64
+	for i, o := range occurrences {
+		total += o.rule.AmountPence
+		result[i] = model.UpcomingOccurrence{
+			RecurringID:       o.rule.ID,
+			Description:       o.rule.Description.String,
+			DueDate:           model.FormatDate(o.dueDate),
+			Amount:            model.PenceToCurrency(o.rule.AmountPence),
+			AmountPence:       o.rule.AmountPence,
+			RunningTotal:      model.PenceToCurrency(total),
+			RunningTotalPence: total,
+		}
+	}
+	return result, total
+}
+
+// GetStaleRecurring handles GET /api/v1/recurring/stale
+// @Summary Find stale recurring transactions
+// @Description Get active rules whose most recent generated transaction is older than the given number of days, or that have never generated one despite being first due before then. These are often forgotten subscriptions. Rules that never generated a transaction are listed first, then the longest-idle ones.
+// @Tags recurring
+// @Produce json
+// @Param days query int false "Days without a generated transaction (1-3650, default 90)"
+// @Success 200 {object} model.StaleRecurringResponse "Stale recurring transactions"
+// @Failure 400 {object} map[string]interface{} "Invalid days"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /recurring/stale [get]
+func (h *Handler) GetStaleRecurring(c *gin.Context) {
+	days := 90
+	if daysStr := c.Query("days"); daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil || parsed < 1 || parsed > 3650 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "days must be between 1 and 3650",
+				"data":  nil,
+			})
+			return
+		}
+		days = parsed
+	}
+
+	today := h.clock.Now().UTC().Truncate(24 * time.Hour)
+	cutoff := today.AddDate(0, 0, -days)
+
+	// TODO: Get user ID from context when authentication is implemented
+	// For now, use a default user ID of 1
+	userID := int64(1)
+
+	rules, err := h.repo.ListStaleRecurring(c.Request.Context(), repo.ListStaleRecurringParams{
+		UserID: userID,
+		Cutoff: model.FormatDate(cutoff),
+	})
+	if err != nil {
+		h.logger.Error("failed to fetch stale recurring rules", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch stale recurring rules",
+			"data":  nil,
+		})
+		return
+	}
+
+	response := model.StaleRecurringResponse{
+		Days:   days,
+		Cutoff: model.FormatDate(cutoff),
+		Rules:  make([]model.StaleRecurring, len(rules)),
+	}
+	for i, rule := range rules {
+		stale := model.StaleRecurring{
+			RecurringID: rule.ID,
+			Description: rule.Description.String,
+			Amount:      model.PenceToCurrency(rule.AmountPence),
+			AmountPence: rule.AmountPence,
+			Frequency:   rule.Frequency,
+			NextDueDate: model.FormatDate(rule.NextDueDate),
+		}
+		if rule.LastGeneratedDate.Valid {
+			last := rule.LastGeneratedDate.String
+			stale.LastGeneratedDate = &last
+			if lastDate, err := model.ParseDate(last); err == nil {
+				since := int(today.Sub(lastDate).Hours() / 24)
+				stale.DaysSinceLast = &since
+			}
+		}
+		response.Rules[i] = stale
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// GetRecurringDueOnDate handles GET /api/v1/recurring/due?date=YYYY-MM-DD
+// @Summary Get recurring transactions due on a date
+// @Description Get all recurring transaction rules that are due on a specific date
+// @Tags recurring
+// @Accept json
+// @Produce json
+// @Param date query string false "Date to check (YYYY-MM-DD format, defaults to today)"
+// @Success 200 {object} map[string]interface{} "List of recurring transactions due on the date"
+// @Failure 400 {object} map[string]interface{} "Invalid date format"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /recurring/due [get]
+func (h *Handler) GetRecurringDueOnDate(c *gin.Context) {
+	// Get date from query parameter
+	dateStr := c.Query("date")
+	if dateStr == "" {
+		// If no date provided, use today's date
+		dateStr = model.FormatDate(h.clock.Now())
+	}
+
+	// Parse the date
+	dueDate, err := model.ParseDate(dateStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid date format. Use YYYY-MM-DD",
+			"data":  nil,
+		})
+		return
+	}
+
+	// Get recurring rules due on the specified date
+	recurringRules, err := h.repo.GetRecurringDueOnDate(c.Request.Context(), dueDate)
+	if err != nil {
+		h.logger.Error("failed to fetch recurring rules due on date", zap.Error(err), zap.String("date", dateStr))
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "failed to fetch recurring rules due on date",
 			"data":  nil,
@@ -834,11 +1382,1037 @@ func (h *Handler) GetRecurringDueOnDate(c *gin.Context) {
 			Active:        rule.Active,
 			CreatedAt:     rule.CreatedAt.Time,
 			TagIDs:        tagIDs,
+			Group:         model.SQLNullStringToString(rule.GroupName),
+			DeletedAt:     model.SQLNullTimeToTimePtr(rule.DeletedAt),
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+} 
+
+// GetRecurringTransactions handles GET /api/v1/recurring/:id/transactions
+// @Summary Get transactions generated by a recurring transaction
+// @Description Get the transactions created from a recurring transaction rule along with their count and total
+// @Tags recurring
+// @Accept json
+// @Produce json
+// @Param id path int true "Recurring transaction ID"
+// @Success 200 {object} model.RecurringTransactionsResponse "Generated transactions with totals"
+// @Failure 400 {object} map[string]interface{} "Invalid recurring transaction ID"
+// @Failure 404 {object} map[string]interface{} "Recurring transaction not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /recurring/{id}/transactions [get]
+func (h *Handler) GetRecurringTransactions(c *gin.Context) {
+	// Parse ID from URL
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid recurring rule ID",
+			"data":  nil,
+		})
+		return
+	}
+
+	// Check if recurring rule exists
+	_, err = h.repo.GetRecurringByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "recurring rule not found",
+			"data":  nil,
+		})
+		return
+	}
+
+	// TODO: Check if user has access to this recurring rule when authentication is implemented
+
+	// Get transactions generated by this rule
+	sourceRecurring := sql.NullInt64{Int64: id, Valid: true}
+	transactions, err := h.repo.GetTransactionsByRecurringID(c.Request.Context(), sourceRecurring)
+	if err != nil {
+		h.logger.Error("failed to fetch transactions by recurring ID", zap.Error(err), zap.Int64("recurring_id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch transactions",
+			"data":  nil,
+		})
+		return
+	}
+
+	// Convert to response DTOs and sum the amounts
+	response := model.RecurringTransactionsResponse{
+		Transactions: make([]model.TransactionResponse, len(transactions)),
+		Count:        len(transactions),
+	}
+	for i, txn := range transactions {
+		// Get tags for this transaction
+		tags, err := h.repo.GetTransactionTags(c.Request.Context(), txn.ID)
+		if err != nil {
+			h.logger.Error("failed to fetch transaction tags", zap.Error(err), zap.Int64("transaction_id", txn.ID))
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "failed to fetch transaction tags",
+				"data":  nil,
+			})
+			return
+		}
+
+		// Convert tag IDs
+		tagIDs := make([]int64, len(tags))
+		for j, tag := range tags {
+			tagIDs[j] = tag.ID
+		}
+
+		response.Transactions[i] = model.TransactionResponse{
+			ID:              txn.ID,
+			Amount:          model.PenceToCurrency(txn.AmountPence),
+			TDate:           model.FormatDate(txn.TDate),
+			Note:            model.SQLNullStringToString(txn.Note),
+			CreatedAt:       txn.CreatedAt.Time,
+			SourceRecurring: model.SQLNullInt64ToInt64(txn.SourceRecurring),
+			DeletedAt:       model.SQLNullTimeToTimePtr(txn.DeletedAt),
+			TagIDs:          tagIDs,
+			Version:         txn.Version,
+			ExternalID:      model.SQLNullStringToString(txn.ExternalID),
+			Cleared:         txn.Cleared,
 		}
+		response.TotalPence += txn.AmountPence
 	}
+	response.Total = model.PenceToCurrency(response.TotalPence)
 
 	c.JSON(http.StatusOK, gin.H{
 		"data":  response,
 		"error": nil,
 	})
-} 
\ No newline at end of file
+}
+
+// GetRecurringCalendarFeed handles GET /api/v1/recurring/calendar.ics
+// @Summary Export all active recurring transactions as an iCalendar feed
+// @Description Get every active rule of the user as one calendar with a VEVENT series per rule, suitable for subscribing to upcoming bills. Each event summary carries the description and amount.
+// @Tags recurring
+// @Produce text/calendar
+// @Success 200 {string} string "iCalendar feed"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /recurring/calendar.ics [get]
+func (h *Handler) GetRecurringCalendarFeed(c *gin.Context) {
+	// TODO: Get user ID from context when authentication is implemented
+	// For now, use a default user ID of 1
+	userID := int64(1)
+
+	rules, err := h.repo.ListActiveRecurring(c.Request.Context(), repo.ListActiveRecurringParams{
+		UserID: userID,
+		Limit:  -1,
+	})
+	if err != nil {
+		h.logger.Error("failed to fetch active recurring rules", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch active recurring rules",
+			"data":  nil,
+		})
+		return
+	}
+
+	currency, err := h.reportCurrency(c.Request.Context())
+	if err != nil {
+		h.logger.Error("failed to fetch currency setting", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch currency setting",
+			"data":  nil,
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="recurring.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(recurringCalendar(rules, currency, h.clock.Now())))
+}
+
+// GetRecurringCalendar handles GET /api/v1/recurring/:id/calendar.ics
+// @Summary Export a recurring transaction as an iCalendar feed
+// @Description Get the rule as an all-day VEVENT series that calendar apps can import or subscribe to. The RRULE follows the rule's frequency and interval, starting on first_due_date and ending on end_date if set.
+// @Tags recurring
+// @Produce text/calendar
+// @Param id path int true "Recurring transaction ID"
+// @Success 200 {string} string "iCalendar feed"
+// @Failure 400 {object} map[string]interface{} "Invalid recurring transaction ID"
+// @Failure 404 {object} map[string]interface{} "Recurring transaction not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /recurring/{id}/calendar.ics [get]
+func (h *Handler) GetRecurringCalendar(c *gin.Context) {
+	// Parse ID from URL
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid recurring rule ID",
+			"data":  nil,
+		})
+		return
+	}
+
+	rule, err := h.getLiveRecurring(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "recurring rule not found",
+			"data":  nil,
+		})
+		return
+	}
+
+	// TODO: Check if user has access to this recurring rule when authentication is implemented
+
+	currency, err := h.reportCurrency(c.Request.Context())
+	if err != nil {
+		h.logger.Error("failed to fetch currency setting", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch currency setting",
+			"data":  nil,
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="recurring-`+idStr+`.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(recurringCalendar([]repo.Recurring{rule}, currency, h.clock.Now())))
+}
+
+// recurringCalendar renders rules as an iCalendar (RFC 5545) document with
+// one recurring all-day event per rule. Lines end in CRLF as the format
+// requires.
+func recurringCalendar(rules []repo.Recurring, currency string, now time.Time) string {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//budget-api//recurring//EN",
+		"CALSCALE:GREGORIAN",
+	}
+	for _, rule := range rules {
+		lines = append(lines, recurringEvent(rule, currency, now)...)
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(icalFold(line))
+		b.WriteString("\r\n")
+	}
+	return b.String()
+}
+
+// recurringEvent returns the VEVENT lines for a single rule, summarised by its
+// description and amount. The scheduler clamps a monthly rule on the 29th-31st
+// (or a yearly one on 29 February) to the end of a shorter month and carries
+// on from the clamped day, where an RRULE would skip that month instead. The
+// occurrences before the day stops changing are emitted as one-off events,
+// and the RRULE starts from the first occurrence after them.
+func recurringEvent(rule repo.Recurring, currency string, now time.Time) []string {
+	summary := "Recurring transaction"
+	if rule.Description.Valid && rule.Description.String != "" {
+		summary = rule.Description.String
+	}
+	summary += " (" + model.PenceToDisplay(rule.AmountPence, currency) + ")"
+	uid := "recurring-" + strconv.FormatInt(rule.ID, 10)
+	dtstamp := "DTSTAMP:" + now.UTC().Format("20060102T150405Z")
+
+	clamped, start := calendarClampedOccurrences(rule)
+
+	var lines []string
+	for _, date := range clamped {
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+uid+"-"+date.Format("20060102")+"@budget-api",
+			dtstamp,
+			"DTSTART;VALUE=DATE:"+date.Format("20060102"),
+			"SUMMARY:"+icalText(summary),
+			"END:VEVENT",
+		)
+	}
+	if rule.EndDate.Valid && start.After(rule.EndDate.Time) {
+		return lines
+	}
+
+	rrule := "FREQ=" + strings.ToUpper(rule.Frequency) + ";INTERVAL=" + strconv.FormatInt(rule.IntervalN, 10)
+	if rule.EndDate.Valid {
+		rrule += ";UNTIL=" + rule.EndDate.Time.Format("20060102")
+	}
+
+	return append(lines,
+		"BEGIN:VEVENT",
+		"UID:"+uid+"@budget-api",
+		dtstamp,
+		"DTSTART;VALUE=DATE:"+start.Format("20060102"),
+		"RRULE:"+rrule,
+		"SUMMARY:"+icalText(summary),
+		"END:VEVENT",
+	)
+}
+
+// calendarClampLookahead is how many occurrences ahead are checked for one
+// the scheduler would clamp, enough to cover a leap year cycle
+const calendarClampLookahead = 48
+
+// calendarClampedOccurrences steps rule from its first due date as the
+// scheduler does, returning the occurrences (up to its end date) that come
+// before one whose day of month no later occurrence changes, and that
+// occurrence. From there an RRULE gives the same dates as the scheduler.
+func calendarClampedOccurrences(rule repo.Recurring) ([]time.Time, time.Time) {
+	frequency := model.Frequency(rule.Frequency)
+	n := int(rule.IntervalN)
+	date := rule.FirstDueDate
+	if frequency != model.FrequencyMonthly && frequency != model.FrequencyYearly {
+		return nil, date
+	}
+
+	var clamped []time.Time
+	for date.Day() > 28 {
+		if rule.EndDate.Valid && date.After(rule.EndDate.Time) {
+			break
+		}
+
+		// Look for a later occurrence the scheduler moves to another day
+		settled := true
+		next := date
+		for i := 0; i < calendarClampLookahead; i++ {
+			advanced := scheduler.Advance(frequency, next, n)
+			if !advanced.After(next) {
+				break
+			}
+			if advanced.Day() != date.Day() {
+				settled = false
+				break
+			}
+			next = advanced
+		}
+		if settled {
+			break
+		}
+
+		clamped = append(clamped, date)
+		date = scheduler.Advance(frequency, date, n)
+	}
+	return clamped, date
+}
+
+// icalText escapes the characters iCalendar TEXT values reserve
+func icalText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// icalFold splits a content line longer than 75 octets into continuation
+// lines starting with a space, without breaking UTF-8 sequences
+func icalFold(line string) string {
+	const limit = 75
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := utf8.RuneLen(r)
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
+
+// GetRecurringPauseHistory handles GET /api/v1/recurring/:id/pause-history
+// @Summary Get pause history of a recurring transaction
+// @Description Get the pause/resume/skip actions taken on a recurring transaction rule, oldest first
+// @Tags recurring
+// @Accept json
+// @Produce json
+// @Param id path int true "Recurring transaction ID"
+// @Param limit query int false "Maximum number of entries to return (default 50 or the page_default setting, max 100 or the page_max setting)"
+// @Param offset query int false "Number of entries to skip (default 0)"
+// @Success 200 {object} model.RecurringHistoryResponse "Recurring transaction history"
+// @Failure 400 {object} map[string]interface{} "Invalid recurring transaction ID or pagination parameters"
+// @Failure 404 {object} map[string]interface{} "Recurring transaction not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /recurring/{id}/pause-history [get]
+func (h *Handler) GetRecurringPauseHistory(c *gin.Context) {
+	// Parse ID from URL
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid recurring rule ID",
+			"data":  nil,
+		})
+		return
+	}
+
+	// Parse pagination parameters
+	limit, offset, ok := h.parsePagination(c, 50)
+	if !ok {
+		return
+	}
+
+	// Check if recurring rule exists
+	_, err = h.repo.GetRecurringByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "recurring rule not found",
+			"data":  nil,
+		})
+		return
+	}
+
+	// TODO: Check if user has access to this recurring rule when authentication is implemented
+
+	entries, err := h.repo.ListRecurringHistory(c.Request.Context(), repo.ListRecurringHistoryParams{
+		RecurringID: id,
+		Limit:       limit,
+		Offset:      offset,
+	})
+	if err != nil {
+		h.logger.Error("failed to fetch recurring rule history", zap.Error(err), zap.Int64("recurring_id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch recurring rule history",
+			"data":  nil,
+		})
+		return
+	}
+
+	total, err := h.repo.CountRecurringHistory(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("failed to count recurring rule history", zap.Error(err), zap.Int64("recurring_id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch recurring rule history",
+			"data":  nil,
+		})
+		return
+	}
+
+	response := model.RecurringHistoryResponse{
+		Entries: make([]model.RecurringHistoryEntry, len(entries)),
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+	}
+	for i, entry := range entries {
+		response.Entries[i] = model.RecurringHistoryEntry{
+			ID:        entry.ID,
+			Action:    entry.Action,
+			CreatedAt: entry.CreatedAt.Time,
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// recordRecurringHistory stores a "resumed" or "paused" entry for a rule whose
+// active flag has just been set to active
+func (h *Handler) recordRecurringHistory(c *gin.Context, r repo.Repository, recurringID int64, active bool) error {
+	action := "paused"
+	if active {
+		action = "resumed"
+	}
+	_, err := r.CreateRecurringHistory(c.Request.Context(), repo.CreateRecurringHistoryParams{
+		RecurringID: recurringID,
+		Action:      action,
+	})
+	if err != nil {
+		h.logger.Error("failed to record recurring rule history", zap.Error(err), zap.Int64("recurring_id", recurringID), zap.String("action", action))
+	}
+	return err
+}
+
+// GetRecurringGroups handles GET /api/v1/recurring/groups
+// @Summary Get recurring rule groups
+// @Description Summarise active recurring rules by group with their count and monthly-equivalent cost over the next twelve months, prorating rules that end within them. Ungrouped rules are reported last with a null group.
+// @Tags recurring
+// @Produce json
+// @Success 200 {array} model.RecurringGroupSummary "Recurring groups"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /recurring/groups [get]
+func (h *Handler) GetRecurringGroups(c *gin.Context) {
+	// TODO: Get user ID from context when authentication is implemented
+	// For now, use a default user ID of 1
+	userID := int64(1)
+
+	rules, err := h.repo.ListActiveRecurring(c.Request.Context(), repo.ListActiveRecurringParams{
+		UserID: userID,
+		Limit:  -1,
+	})
+	if err != nil {
+		h.logger.Error("failed to fetch active recurring rules", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch active recurring rules",
+			"data":  nil,
+		})
+		return
+	}
+
+	today := h.clock.Now().UTC().Truncate(24 * time.Hour)
+	groups := make(map[string]*model.RecurringGroupSummary)
+	ungrouped := model.RecurringGroupSummary{}
+	for _, rule := range rules {
+		summary := &ungrouped
+		if rule.GroupName.Valid {
+			summary = groups[rule.GroupName.String]
+			if summary == nil {
+				name := rule.GroupName.String
+				summary = &model.RecurringGroupSummary{Group: &name}
+				groups[name] = summary
+			}
+		}
+		summary.Count++
+		summary.MonthlyTotalPence += monthlyEquivalentPence(rule, today)
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	response := make([]model.RecurringGroupSummary, 0, len(groups)+1)
+	for _, name := range names {
+		response = append(response, *groups[name])
+	}
+	if ungrouped.Count > 0 {
+		response = append(response, ungrouped)
+	}
+	for i := range response {
+		response[i].MonthlyTotal = model.PenceToCurrency(response[i].MonthlyTotalPence)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// CloneRecurring handles POST /api/v1/recurring/:id/clone
+// @Summary Clone a recurring rule
+// @Description Copy a recurring rule, including its tags, into a new active rule whose next due date is its first due date. Fields in the optional body override the copied values; tag_ids replaces the copied tags.
+// @Tags recurring
+// @Accept json
+// @Produce json
+// @Param id path int true "Recurring rule ID"
+// @Param overrides body model.CloneRecurringRequest false "Fields to override"
+// @Param backfill query bool false "Allow a first due date more than 90 days (or the recurring_max_backfill_days setting) ago"
+// @Success 201 {object} map[string]interface{} "ID of the new recurring rule"
+// @Failure 400 {object} map[string]interface{} "Invalid request data"
+// @Failure 404 {object} map[string]interface{} "Recurring rule not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /recurring/{id}/clone [post]
+func (h *Handler) CloneRecurring(c *gin.Context) {
+	// Parse ID from URL
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid recurring rule ID",
+			"data":  nil,
+		})
+		return
+	}
+
+	// Get the validated request from context
+	request, ok := GetValidatedRequest[model.CloneRecurringRequest](c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to get validated request",
+			"data":  nil,
+		})
+		return
+	}
+
+	source, err := h.getLiveRecurring(c.Request.Context(), id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "recurring rule not found",
+				"data":  nil,
+			})
+			return
+		}
+		h.logger.Error("failed to fetch recurring rule", zap.Error(err), zap.Int64("id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch recurring rule",
+			"data":  nil,
+		})
+		return
+	}
+
+	// TODO: Get user ID from context when authentication is implemented
+	// For now, use a default user ID of 1
+	userID := int64(1)
+
+	params := repo.CreateRecurringParams{
+		UserID:       userID,
+		AmountPence:  source.AmountPence,
+		Description:  source.Description,
+		Frequency:    source.Frequency,
+		IntervalN:    source.IntervalN,
+		FirstDueDate: source.FirstDueDate,
+		EndDate:      source.EndDate,
+		Active:       true,
+		GroupName:    source.GroupName,
+	}
+
+	// Apply overrides
+	if request.Amount != nil {
+		amountPence, err := model.CurrencyToPence(*request.Amount)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid amount format",
+				"data":  nil,
+			})
+			return
+		}
+		params.AmountPence = amountPence
+	}
+
+	if request.Description != nil {
+		params.Description = sql.NullString{String: *request.Description, Valid: true}
+	}
+
+	if request.Frequency != nil {
+		params.Frequency = *request.Frequency
+	}
+
+	if request.IntervalN != nil {
+		params.IntervalN = int64(*request.IntervalN)
+	}
+
+	if (request.Frequency != nil || request.IntervalN != nil) &&
+		rejectIntervalOutOfRange(c, params.Frequency, params.IntervalN) {
+		return
+	}
+
+	if request.FirstDueDate != nil {
+		firstDueDate, err := model.ParseDate(*request.FirstDueDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid first_due_date format",
+				"data":  nil,
+			})
+			return
+		}
+		params.FirstDueDate = firstDueDate
+	}
+
+	if request.EndDate != nil {
+		if *request.EndDate == "" {
+			params.EndDate = sql.NullTime{Valid: false}
+		} else {
+			endDate, err := model.ParseDate(*request.EndDate)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": "invalid end_date format",
+					"data":  nil,
+				})
+				return
+			}
+			params.EndDate = sql.NullTime{Time: endDate, Valid: true}
+		}
+	}
+
+	if request.Group != nil {
+		params.GroupName = recurringGroupName(request.Group)
+	}
+
+	// The clone starts from its first due date
+	params.NextDueDate = params.FirstDueDate
+	if h.rejectLongBackfill(c, userID, params.FirstDueDate) {
+		return
+	}
+
+	// Copy the source tags unless the request replaces them
+	tagIDs := request.TagIDs
+	if tagIDs == nil {
+		tags, err := h.repo.GetRecurringTags(c.Request.Context(), id)
+		if err != nil {
+			h.logger.Error("failed to fetch recurring rule tags", zap.Error(err), zap.Int64("recurring_id", id))
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "failed to fetch recurring rule tags",
+				"data":  nil,
+			})
+			return
+		}
+		tagIDs = make([]int64, len(tags))
+		for i, tag := range tags {
+			tagIDs[i] = tag.ID
+		}
+	}
+
+	recurring, err := h.createRecurringWithTags(c, params, tagIDs)
+	if err != nil {
+		var tagErr *invalidTagError
+		if errors.As(err, &tagErr) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": tagErr.Error(),
+				"data":  nil,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to clone recurring rule",
+			"data":  nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": gin.H{
+			"id": recurring.ID,
+		},
+		"error": nil,
+	})
+}
+
+// AddRecurringTag handles POST /api/v1/recurring/:id/tags/:tag_id
+// @Summary Add a tag to a recurring transaction
+// @Description Attach one tag to a recurring rule, leaving its other tags in place. Adding a tag the rule already has is a no-op.
+// @Tags recurring
+// @Produce json
+// @Param id path int true "Recurring transaction ID"
+// @Param tag_id path int true "Tag ID"
+// @Success 200 {object} map[string]interface{} "The rule's tag IDs after the change"
+// @Failure 400 {object} map[string]interface{} "Invalid recurring rule or tag ID"
+// @Failure 404 {object} map[string]interface{} "Recurring transaction not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /recurring/{id}/tags/{tag_id} [post]
+func (h *Handler) AddRecurringTag(c *gin.Context) {
+	id, tagID, ok := h.parseRecurringTagParams(c)
+	if !ok {
+		return
+	}
+
+	if _, err := h.repo.GetTagByID(c.Request.Context(), tagID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid tag ID: " + strconv.FormatInt(tagID, 10),
+			"data":  nil,
+		})
+		return
+	}
+
+	err := h.repo.CreateRecurringTag(c.Request.Context(), repo.CreateRecurringTagParams{
+		RecurringID: id,
+		TagID:       tagID,
+	})
+	if err != nil {
+		h.logger.Error("failed to add recurring rule tag", zap.Error(err), zap.Int64("id", id), zap.Int64("tag_id", tagID))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to add tag to recurring rule",
+			"data":  nil,
+		})
+		return
+	}
+
+	h.respondRecurringTagIDs(c, id)
+}
+
+// RemoveRecurringTag handles DELETE /api/v1/recurring/:id/tags/:tag_id
+// @Summary Remove a tag from a recurring transaction
+// @Description Detach one tag from a recurring rule, leaving its other tags in place
+// @Tags recurring
+// @Produce json
+// @Param id path int true "Recurring transaction ID"
+// @Param tag_id path int true "Tag ID"
+// @Success 204 "Tag removed"
+// @Failure 400 {object} map[string]interface{} "Invalid recurring rule or tag ID"
+// @Failure 404 {object} map[string]interface{} "Recurring transaction not found or tag not on it"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /recurring/{id}/tags/{tag_id} [delete]
+func (h *Handler) RemoveRecurringTag(c *gin.Context) {
+	id, tagID, ok := h.parseRecurringTagParams(c)
+	if !ok {
+		return
+	}
+
+	tags, err := h.repo.GetRecurringTags(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("failed to fetch recurring rule tags", zap.Error(err), zap.Int64("id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch recurring rule tags",
+			"data":  nil,
+		})
+		return
+	}
+	attached := false
+	for _, tag := range tags {
+		if tag.ID == tagID {
+			attached = true
+			break
+		}
+	}
+	if !attached {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "tag is not on this recurring rule",
+			"data":  nil,
+		})
+		return
+	}
+
+	err = h.repo.DeleteRecurringTag(c.Request.Context(), repo.DeleteRecurringTagParams{
+		RecurringID: id,
+		TagID:       tagID,
+	})
+	if err != nil {
+		h.logger.Error("failed to remove recurring rule tag", zap.Error(err), zap.Int64("id", id), zap.Int64("tag_id", tagID))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to remove tag from recurring rule",
+			"data":  nil,
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseRecurringTagParams parses the :id and :tag_id parameters and checks
+// the recurring rule exists, writing an error response when not
+func (h *Handler) parseRecurringTagParams(c *gin.Context) (int64, int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid recurring rule ID",
+			"data":  nil,
+		})
+		return 0, 0, false
+	}
+	tagID, err := strconv.ParseInt(c.Param("tag_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid tag ID",
+			"data":  nil,
+		})
+		return 0, 0, false
+	}
+
+	// TODO: Check if user has access to this recurring rule when authentication is implemented
+	if _, err := h.getLiveRecurring(c.Request.Context(), id); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "recurring rule not found",
+				"data":  nil,
+			})
+			return 0, 0, false
+		}
+		h.logger.Error("failed to fetch recurring rule", zap.Error(err), zap.Int64("id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch recurring rule",
+			"data":  nil,
+		})
+		return 0, 0, false
+	}
+	return id, tagID, true
+}
+
+// respondRecurringTagIDs answers with the tag IDs now on the recurring rule
+func (h *Handler) respondRecurringTagIDs(c *gin.Context, id int64) {
+	tags, err := h.repo.GetRecurringTags(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("failed to fetch recurring rule tags", zap.Error(err), zap.Int64("id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch recurring rule tags",
+			"data":  nil,
+		})
+		return
+	}
+
+	tagIDs := make([]int64, len(tags))
+	for i, tag := range tags {
+		tagIDs[i] = tag.ID
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"id":      id,
+			"tag_ids": tagIDs,
+		},
+		"error": nil,
+	})
+}
+
+// RescheduleRecurring handles POST /api/v1/recurring/:id/reschedule
+// @Summary Reset a recurring rule's schedule
+// @Description Set the rule's next due date, defaulting to its first due date. The date must be today or later.
+// @Tags recurring
+// @Accept json
+// @Produce json
+// @Param id path int true "Recurring rule ID"
+// @Param request body model.RescheduleRecurringRequest false "New next due date"
+// @Success 200 {object} map[string]interface{} "Recurring rule rescheduled"
+// @Failure 400 {object} map[string]interface{} "Invalid request data"
+// @Failure 404 {object} map[string]interface{} "Recurring rule not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /recurring/{id}/reschedule [post]
+func (h *Handler) RescheduleRecurring(c *gin.Context) {
+	// Parse ID from URL
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid recurring rule ID",
+			"data":  nil,
+		})
+		return
+	}
+
+	// Get the validated request from context
+	request, ok := GetValidatedRequest[model.RescheduleRecurringRequest](c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to get validated request",
+			"data":  nil,
+		})
+		return
+	}
+
+	// TODO: Check if user has access to this recurring rule when authentication is implemented
+	rule, err := h.getLiveRecurring(c.Request.Context(), id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "recurring rule not found",
+				"data":  nil,
+			})
+			return
+		}
+		h.logger.Error("failed to fetch recurring rule", zap.Error(err), zap.Int64("id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch recurring rule",
+			"data":  nil,
+		})
+		return
+	}
+
+	nextDueDate := rule.FirstDueDate
+	if request.NextDueDate != nil {
+		nextDueDate, err = model.ParseDate(*request.NextDueDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid next_due_date format",
+				"data":  nil,
+			})
+			return
+		}
+	}
+
+	// Past dates would have the scheduler backfill every missed occurrence
+	today := h.userToday(c.Request.Context(), rule.UserID)
+	if nextDueDate.Before(today) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "next_due_date must be today or later",
+			"data":  nil,
+		})
+		return
+	}
+
+	err = h.repo.UpdateRecurringNextDue(c.Request.Context(), repo.UpdateRecurringNextDueParams{
+		NextDueDate: nextDueDate,
+		ID:          id,
+	})
+	if err != nil {
+		h.logger.Error("failed to reschedule recurring rule", zap.Error(err), zap.Int64("id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to reschedule recurring rule",
+			"data":  nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"id":            id,
+			"next_due_date": model.FormatDate(nextDueDate),
+		},
+		"error": nil,
+	})
+}
+
+// rejectIntervalOutOfRange answers 400 when interval_n is too large for the
+// frequency, covering partial requests that change only one of the two
+func rejectIntervalOutOfRange(c *gin.Context, frequency string, intervalN int64) bool {
+	param, ok := intervalOutOfRange(frequency, int(intervalN))
+	if !ok {
+		return false
+	}
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error": "validation failed",
+		"data":  map[string]string{"interval_n": getValidationMessage("interval_for_frequency", param)},
+	})
+	return true
+}
+
+// defaultMaxBackfillDays is how far before today a new rule may start when
+// the recurring_max_backfill_days setting has not been configured
+const defaultMaxBackfillDays = 90
+
+// maxBackfillDays reads the recurring_max_backfill_days setting. Values that
+// are not non-negative integers are logged and ignored.
+func (h *Handler) maxBackfillDays(ctx context.Context) (int, error) {
+	setting, err := h.repo.GetSetting(ctx, "recurring_max_backfill_days")
+	if errors.Is(err, sql.ErrNoRows) {
+		return defaultMaxBackfillDays, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	days, err := strconv.Atoi(setting.Value)
+	if err != nil || days < 0 {
+		h.logger.Warn("ignoring invalid max backfill setting", zap.String("value", setting.Value))
+		return defaultMaxBackfillDays, nil
+	}
+	return days, nil
+}
+
+// rejectLongBackfill answers 400 when a new rule's first due date is further
+// in the past than the max backfill days, as the scheduler would materialize
+// every missed occurrence. Passing backfill=true allows it.
+func (h *Handler) rejectLongBackfill(c *gin.Context, userID int64, firstDueDate time.Time) bool {
+	if value := c.Query("backfill"); value != "" {
+		backfill, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "backfill must be true or false",
+				"data":  nil,
+			})
+			return true
+		}
+		if backfill {
+			return false
+		}
+	}
+
+	today := h.userToday(c.Request.Context(), userID)
+	if !firstDueDate.Before(today) {
+		return false
+	}
+
+	maxDays, err := h.maxBackfillDays(c.Request.Context())
+	if err != nil {
+		h.logger.Error("failed to fetch max backfill setting", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch max backfill setting",
+			"data":  nil,
+		})
+		return true
+	}
+	if !firstDueDate.Before(today.AddDate(0, 0, -maxDays)) {
+		return false
+	}
+
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error": "first_due_date is more than " + strconv.Itoa(maxDays) + " days ago; pass backfill=true to create every missed occurrence",
+		"data":  nil,
+	})
+	return true
+}
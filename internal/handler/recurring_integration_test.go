@@ -0,0 +1,1391 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+	"github.com/piotrzalecki/budget-api/internal/scheduler"
+	"github.com/piotrzalecki/budget-api/pkg/model"
+)
+
+func TestRecurringPauseHistoryIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+
+	rule, err := repository.CreateRecurring(context.Background(), repo.CreateRecurringParams{
+		UserID:       1,
+		AmountPence:  -1500,
+		Frequency:    "monthly",
+		IntervalN:    1,
+		FirstDueDate: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+		NextDueDate:  time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+		Active:       true,
+	})
+	require.NoError(t, err)
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.PATCH("/recurring/:id/toggle", h.ToggleRecurringActive)
+	router.GET("/recurring/:id/pause-history", h.GetRecurringPauseHistory)
+
+	toggle := func() {
+		req := httptest.NewRequest("PATCH", "/recurring/"+strconv.FormatInt(rule.ID, 10)+"/toggle", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusNoContent, w.Code)
+	}
+	getHistory := func(query string) (int, model.RecurringHistoryResponse) {
+		req := httptest.NewRequest("GET", "/recurring/"+strconv.FormatInt(rule.ID, 10)+"/pause-history"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var response struct {
+			Data model.RecurringHistoryResponse `json:"data"`
+		}
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w.Code, response.Data
+	}
+
+	// pause, resume, pause
+	toggle()
+	toggle()
+	toggle()
+
+	t.Run("entries are recorded in order", func(t *testing.T) {
+		code, history := getHistory("")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, int64(3), history.Total)
+		require.Len(t, history.Entries, 3)
+		assert.Equal(t, "paused", history.Entries[0].Action)
+		assert.Equal(t, "resumed", history.Entries[1].Action)
+		assert.Equal(t, "paused", history.Entries[2].Action)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		code, history := getHistory("?limit=1&offset=1")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, int64(3), history.Total)
+		assert.Equal(t, int64(1), history.Limit)
+		assert.Equal(t, int64(1), history.Offset)
+		require.Len(t, history.Entries, 1)
+		assert.Equal(t, "resumed", history.Entries[0].Action)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		code, _ := getHistory("?limit=0")
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("unknown rule", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/recurring/999999/pause-history", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestUpdateRecurringAtomicIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	rule, err := repository.CreateRecurring(ctx, repo.CreateRecurringParams{
+		UserID:       1,
+		AmountPence:  -1500,
+		Description:  sql.NullString{String: "Gym", Valid: true},
+		Frequency:    "monthly",
+		IntervalN:    1,
+		FirstDueDate: time.Date(2031, 6, 1, 0, 0, 0, 0, time.UTC),
+		NextDueDate:  time.Date(2031, 6, 1, 0, 0, 0, 0, time.UTC),
+		Active:       true,
+	})
+	require.NoError(t, err)
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.PATCH("/recurring/:id", ValidateRequest[model.UpdateRecurringRequest](), h.UpdateRecurring)
+
+	update := func(body string) int {
+		req := httptest.NewRequest("PATCH", "/recurring/"+strconv.FormatInt(rule.ID, 10), bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	t.Run("unknown tag leaves the rule unchanged", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, update(`{"description": "Pool", "tag_ids": [999999]}`))
+
+		stored, err := repository.GetRecurringByID(ctx, rule.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Gym", stored.Description.String)
+	})
+
+	t.Run("failed history write leaves the rule unchanged", func(t *testing.T) {
+		_, err := db.Exec("DROP TABLE recurring_history")
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusInternalServerError, update(`{"description": "Pool", "active": false}`))
+
+		stored, err := repository.GetRecurringByID(ctx, rule.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Gym", stored.Description.String)
+		assert.True(t, stored.Active)
+	})
+}
+
+func TestGetRecurringTransactionsIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	rule, err := repository.CreateRecurring(ctx, repo.CreateRecurringParams{
+		UserID:       1,
+		AmountPence:  -1000,
+		Frequency:    "monthly",
+		IntervalN:    1,
+		FirstDueDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		NextDueDate:  time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		Active:       true,
+	})
+	require.NoError(t, err)
+
+	// Three generated payments
+	for month := time.January; month <= time.March; month++ {
+		_, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+			UserID:          1,
+			AmountPence:     -1000,
+			TDate:           time.Date(2025, month, 1, 0, 0, 0, 0, time.UTC),
+			SourceRecurring: sql.NullInt64{Int64: rule.ID, Valid: true},
+		})
+		require.NoError(t, err)
+	}
+	// A manual transaction that must not be counted
+	_, err = repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+		UserID:      1,
+		AmountPence: -999,
+		TDate:       time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.GET("/recurring/:id/transactions", h.GetRecurringTransactions)
+
+	t.Run("returns transactions with totals", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/recurring/"+strconv.FormatInt(rule.ID, 10)+"/transactions", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data model.RecurringTransactionsResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, 3, response.Data.Count)
+		assert.Len(t, response.Data.Transactions, 3)
+		assert.Equal(t, int64(-3000), response.Data.TotalPence)
+		assert.Equal(t, "-30.00", response.Data.Total)
+	})
+
+	t.Run("unknown rule", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/recurring/999999/transactions", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestListRecurringFilterIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	// The seed only contains monthly rules, so weekly and yearly ones are ours
+	for _, r := range []struct {
+		frequency string
+		active    bool
+	}{
+		{"weekly", true},
+		{"weekly", false},
+		{"yearly", true},
+	} {
+		_, err := repository.CreateRecurring(ctx, repo.CreateRecurringParams{
+			UserID:       1,
+			AmountPence:  -500,
+			Frequency:    r.frequency,
+			IntervalN:    1,
+			FirstDueDate: time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC),
+			NextDueDate:  time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC),
+			Active:       r.active,
+		})
+		require.NoError(t, err)
+	}
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.GET("/recurring", h.GetRecurring)
+	router.GET("/recurring/active", h.ListActiveRecurring)
+
+	var lastHeader http.Header
+	var lastTotal int64
+	list := func(url string) (int, []model.RecurringResponse) {
+		req := httptest.NewRequest("GET", url, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var response struct {
+			Data  []model.RecurringResponse `json:"data"`
+			Total int64                     `json:"total"`
+		}
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		lastHeader, lastTotal = w.Header(), response.Total
+		return w.Code, response.Data
+	}
+
+	t.Run("frequency filter", func(t *testing.T) {
+		code, rules := list("/recurring?frequency=weekly")
+		require.Equal(t, http.StatusOK, code)
+		require.Len(t, rules, 2)
+		for _, rule := range rules {
+			assert.Equal(t, "weekly", rule.Frequency)
+		}
+
+		code, rules = list("/recurring/active?frequency=weekly")
+		require.Equal(t, http.StatusOK, code)
+		require.Len(t, rules, 1)
+		assert.True(t, rules[0].Active)
+
+		code, rules = list("/recurring?frequency=daily")
+		require.Equal(t, http.StatusOK, code)
+		assert.Empty(t, rules)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		code, all := list("/recurring")
+		require.Equal(t, http.StatusOK, code)
+		require.Len(t, all, 7)
+
+		code, page := list("/recurring?limit=3&offset=2")
+		require.Equal(t, http.StatusOK, code)
+		require.Len(t, page, 3)
+		assert.Equal(t, all[2].ID, page[0].ID)
+		assert.Equal(t, all[4].ID, page[2].ID)
+
+		code, page = list("/recurring?limit=5&offset=5")
+		require.Equal(t, http.StatusOK, code)
+		assert.Len(t, page, 2)
+
+		code, page = list("/recurring/active?frequency=monthly&limit=2")
+		require.Equal(t, http.StatusOK, code)
+		assert.Len(t, page, 2)
+	})
+
+	t.Run("total count", func(t *testing.T) {
+		code, rules := list("/recurring?frequency=weekly")
+		require.Equal(t, http.StatusOK, code)
+		assert.Len(t, rules, 2)
+		assert.Equal(t, "2", lastHeader.Get("X-Total-Count"))
+		assert.Equal(t, int64(2), lastTotal)
+
+		// limit and offset do not change the total
+		code, rules = list("/recurring?limit=3&offset=1")
+		require.Equal(t, http.StatusOK, code)
+		assert.Len(t, rules, 3)
+		assert.Equal(t, "7", lastHeader.Get("X-Total-Count"))
+		assert.Equal(t, int64(7), lastTotal)
+
+		code, rules = list("/recurring/active?frequency=weekly")
+		require.Equal(t, http.StatusOK, code)
+		assert.Len(t, rules, 1)
+		assert.Equal(t, "1", lastHeader.Get("X-Total-Count"))
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		for _, url := range []string{
+			"/recurring?frequency=hourly",
+			"/recurring?limit=0",
+			"/recurring?limit=101",
+			"/recurring?limit=abc",
+			"/recurring?offset=-1",
+			"/recurring/active?limit=0",
+		} {
+			code, _ := list(url)
+			assert.Equal(t, http.StatusBadRequest, code, url)
+		}
+	})
+
+	t.Run("page_max caps listing every rule", func(t *testing.T) {
+		_, err := repository.CreateSetting(ctx, repo.CreateSettingParams{Key: "page_max", Value: "5"})
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, repository.DeleteSetting(ctx, "page_max"))
+		}()
+
+		code, page := list("/recurring")
+		require.Equal(t, http.StatusOK, code)
+		assert.Len(t, page, 5)
+		assert.Equal(t, "7", lastHeader.Get("X-Total-Count"))
+
+		code, page = list("/recurring/active")
+		require.Equal(t, http.StatusOK, code)
+		assert.Len(t, page, 5)
+		assert.Equal(t, "6", lastHeader.Get("X-Total-Count"))
+	})
+
+	// Runs last: the settings it writes change the defaults for later requests
+	t.Run("page size settings", func(t *testing.T) {
+		_, err := repository.CreateSetting(ctx, repo.CreateSettingParams{Key: "page_default", Value: "2"})
+		require.NoError(t, err)
+
+		code, page := list("/recurring")
+		require.Equal(t, http.StatusOK, code)
+		assert.Len(t, page, 2)
+		assert.Equal(t, "7", lastHeader.Get("X-Total-Count"))
+
+		code, page = list("/recurring?limit=4")
+		require.Equal(t, http.StatusOK, code)
+		assert.Len(t, page, 4)
+
+		_, err = repository.CreateSetting(ctx, repo.CreateSettingParams{Key: "page_max", Value: "3"})
+		require.NoError(t, err)
+
+		code, _ = list("/recurring?limit=4")
+		assert.Equal(t, http.StatusBadRequest, code)
+
+		code, page = list("/recurring/active?limit=3")
+		require.Equal(t, http.StatusOK, code)
+		assert.Len(t, page, 3)
+	})
+}
+
+func TestRecurringGroupIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.POST("/recurring", ValidateRequest[model.CreateRecurringRequest](), h.CreateRecurring)
+	router.PATCH("/recurring/:id", ValidateRequest[model.UpdateRecurringRequest](), h.UpdateRecurring)
+	router.GET("/recurring/groups", h.GetRecurringGroups)
+
+	send := func(method, url string, body interface{}) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(body)
+		req := httptest.NewRequest(method, url, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("POST", "/recurring", map[string]interface{}{
+		"amount":         "-6.99",
+		"description":    "Video streaming",
+		"frequency":      "monthly",
+		"interval_n":     1,
+		"first_due_date": "2031-05-01",
+		"group":          "  Streaming ",
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created struct {
+		Data struct {
+			ID int64 `json:"id"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+
+	rule, err := repository.GetRecurringByID(context.Background(), created.Data.ID)
+	require.NoError(t, err)
+	assert.Equal(t, sql.NullString{String: "Streaming", Valid: true}, rule.GroupName)
+
+	groups := func() []model.RecurringGroupSummary {
+		w := send("GET", "/recurring/groups", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Data []model.RecurringGroupSummary `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data
+	}
+
+	// The seeded rules have no group and are reported after named groups
+	summary := groups()
+	require.Len(t, summary, 2)
+	require.NotNil(t, summary[0].Group)
+	assert.Equal(t, "Streaming", *summary[0].Group)
+	assert.Equal(t, 1, summary[0].Count)
+	assert.Equal(t, "-6.99", summary[0].MonthlyTotal)
+	assert.Nil(t, summary[1].Group)
+
+	// An empty group removes the rule from its group
+	w = send("PATCH", "/recurring/"+strconv.FormatInt(created.Data.ID, 10), map[string]interface{}{"group": ""})
+	require.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Body.String())
+	rule, err = repository.GetRecurringByID(context.Background(), created.Data.ID)
+	require.NoError(t, err)
+	assert.False(t, rule.GroupName.Valid)
+	assert.Len(t, groups(), 1)
+}
+
+func TestCloneRecurringIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	source, err := repository.CreateRecurring(ctx, repo.CreateRecurringParams{
+		UserID:       1,
+		AmountPence:  -1099,
+		Description:  sql.NullString{String: "Music streaming", Valid: true},
+		Frequency:    "monthly",
+		IntervalN:    1,
+		FirstDueDate: time.Date(2031, 8, 5, 0, 0, 0, 0, time.UTC),
+		NextDueDate:  time.Date(2031, 11, 5, 0, 0, 0, 0, time.UTC),
+		Active:       true,
+		GroupName:    sql.NullString{String: "Streaming", Valid: true},
+	})
+	require.NoError(t, err)
+	tagA, err := repository.CreateTag(ctx, "clone-a")
+	require.NoError(t, err)
+	tagB, err := repository.CreateTag(ctx, "clone-b")
+	require.NoError(t, err)
+	for _, tagID := range []int64{tagA.ID, tagB.ID} {
+		require.NoError(t, repository.CreateRecurringTag(ctx, repo.CreateRecurringTagParams{RecurringID: source.ID, TagID: tagID}))
+	}
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.POST("/recurring/:id/clone", ValidateOptionalRequest[model.CloneRecurringRequest](), h.CloneRecurring)
+
+	clone := func(id int64, body []byte) (int, int64) {
+		req := httptest.NewRequest("POST", "/recurring/"+strconv.FormatInt(id, 10)+"/clone", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var response struct {
+			Data struct {
+				ID int64 `json:"id"`
+			} `json:"data"`
+		}
+		if w.Code == http.StatusCreated {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w.Code, response.Data.ID
+	}
+	tagIDsOf := func(recurringID int64) []int64 {
+		tags, err := repository.GetRecurringTags(ctx, recurringID)
+		require.NoError(t, err)
+		ids := make([]int64, len(tags))
+		for i, tag := range tags {
+			ids[i] = tag.ID
+		}
+		return ids
+	}
+
+	t.Run("copies the rule and its tags", func(t *testing.T) {
+		code, id := clone(source.ID, nil)
+		require.Equal(t, http.StatusCreated, code)
+		require.NotEqual(t, source.ID, id)
+
+		cloned, err := repository.GetRecurringByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, source.AmountPence, cloned.AmountPence)
+		assert.Equal(t, source.Description, cloned.Description)
+		assert.Equal(t, source.Frequency, cloned.Frequency)
+		assert.Equal(t, source.IntervalN, cloned.IntervalN)
+		assert.Equal(t, source.GroupName, cloned.GroupName)
+		assert.True(t, cloned.FirstDueDate.Equal(source.FirstDueDate))
+		assert.True(t, cloned.NextDueDate.Equal(source.FirstDueDate))
+		assert.True(t, cloned.Active)
+		assert.ElementsMatch(t, []int64{tagA.ID, tagB.ID}, tagIDsOf(id))
+	})
+
+	t.Run("applies overrides", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]interface{}{
+			"amount":         "-4.99",
+			"description":    "Video streaming",
+			"first_due_date": "2031-09-01",
+			"tag_ids":        []int64{tagB.ID},
+		})
+		code, id := clone(source.ID, body)
+		require.Equal(t, http.StatusCreated, code)
+
+		cloned, err := repository.GetRecurringByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, int64(-499), cloned.AmountPence)
+		assert.Equal(t, "Video streaming", cloned.Description.String)
+		assert.Equal(t, "2031-09-01", model.FormatDate(cloned.NextDueDate))
+		assert.Equal(t, []int64{tagB.ID}, tagIDsOf(id))
+	})
+
+	t.Run("unknown rule", func(t *testing.T) {
+		code, _ := clone(999999, nil)
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("invalid override", func(t *testing.T) {
+		code, _ := clone(source.ID, []byte(`{"frequency": "hourly"}`))
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+func TestRecurringTagEndpointsIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	rule, err := repository.CreateRecurring(ctx, repo.CreateRecurringParams{
+		UserID:       1,
+		AmountPence:  -2500,
+		Frequency:    "monthly",
+		IntervalN:    1,
+		FirstDueDate: time.Date(2031, 3, 1, 0, 0, 0, 0, time.UTC),
+		NextDueDate:  time.Date(2031, 3, 1, 0, 0, 0, 0, time.UTC),
+		Active:       true,
+	})
+	require.NoError(t, err)
+	tagA, err := repository.CreateTag(ctx, "rule-tag-a")
+	require.NoError(t, err)
+	tagB, err := repository.CreateTag(ctx, "rule-tag-b")
+	require.NoError(t, err)
+	tagC, err := repository.CreateTag(ctx, "rule-tag-c")
+	require.NoError(t, err)
+	for _, tagID := range []int64{tagA.ID, tagB.ID} {
+		require.NoError(t, repository.CreateRecurringTag(ctx, repo.CreateRecurringTagParams{RecurringID: rule.ID, TagID: tagID}))
+	}
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.POST("/recurring/:id/tags/:tag_id", h.AddRecurringTag)
+	router.DELETE("/recurring/:id/tags/:tag_id", h.RemoveRecurringTag)
+
+	call := func(method string, id, tagID int64) *httptest.ResponseRecorder {
+		url := "/recurring/" + strconv.FormatInt(id, 10) + "/tags/" + strconv.FormatInt(tagID, 10)
+		req := httptest.NewRequest(method, url, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	tagIDsOf := func(recurringID int64) []int64 {
+		tags, err := repository.GetRecurringTags(ctx, recurringID)
+		require.NoError(t, err)
+		ids := make([]int64, len(tags))
+		for i, tag := range tags {
+			ids[i] = tag.ID
+		}
+		return ids
+	}
+
+	t.Run("adds a tag alongside the existing ones", func(t *testing.T) {
+		w := call("POST", rule.ID, tagC.ID)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data struct {
+				TagIDs []int64 `json:"tag_ids"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.ElementsMatch(t, []int64{tagA.ID, tagB.ID, tagC.ID}, response.Data.TagIDs)
+		assert.ElementsMatch(t, []int64{tagA.ID, tagB.ID, tagC.ID}, tagIDsOf(rule.ID))
+
+		// Adding it again is a no-op
+		w = call("POST", rule.ID, tagC.ID)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Len(t, tagIDsOf(rule.ID), 3)
+	})
+
+	t.Run("removes one tag and keeps the others", func(t *testing.T) {
+		w := call("DELETE", rule.ID, tagA.ID)
+		require.Equal(t, http.StatusNoContent, w.Code)
+		assert.Empty(t, w.Body.String())
+		assert.ElementsMatch(t, []int64{tagB.ID, tagC.ID}, tagIDsOf(rule.ID))
+
+		w = call("DELETE", rule.ID, tagA.ID)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("rejects an unknown tag", func(t *testing.T) {
+		w := call("POST", rule.ID, 999999)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("unknown rule", func(t *testing.T) {
+		w := call("POST", 999999, tagA.ID)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestRescheduleRecurringIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	rule, err := repository.CreateRecurring(ctx, repo.CreateRecurringParams{
+		UserID:       1,
+		AmountPence:  -3500,
+		Frequency:    "monthly",
+		IntervalN:    1,
+		FirstDueDate: time.Date(2031, 2, 1, 0, 0, 0, 0, time.UTC),
+		NextDueDate:  time.Date(2031, 9, 1, 0, 0, 0, 0, time.UTC),
+		Active:       true,
+	})
+	require.NoError(t, err)
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.POST("/recurring/:id/reschedule", ValidateOptionalRequest[model.RescheduleRecurringRequest](), h.RescheduleRecurring)
+
+	reschedule := func(id int64, body []byte) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/recurring/"+strconv.FormatInt(id, 10)+"/reschedule", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	nextDue := func() string {
+		stored, err := repository.GetRecurringByID(ctx, rule.ID)
+		require.NoError(t, err)
+		return model.FormatDate(stored.NextDueDate)
+	}
+
+	t.Run("sets a new next due date", func(t *testing.T) {
+		w := reschedule(rule.ID, []byte(`{"next_due_date": "2031-12-15"}`))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"next_due_date":"2031-12-15"`)
+		assert.Equal(t, "2031-12-15", nextDue())
+	})
+
+	t.Run("defaults to the first due date", func(t *testing.T) {
+		w := reschedule(rule.ID, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "2031-02-01", nextDue())
+	})
+
+	t.Run("rejects a date in the past", func(t *testing.T) {
+		w := reschedule(rule.ID, []byte(`{"next_due_date": "2020-01-01"}`))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "2031-02-01", nextDue())
+	})
+
+	t.Run("unknown rule", func(t *testing.T) {
+		w := reschedule(999999, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestDeleteRecurringSoftDeleteIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	rule, err := repository.CreateRecurring(ctx, repo.CreateRecurringParams{
+		UserID:       1,
+		AmountPence:  -899,
+		Description:  sql.NullString{String: "Soft-deleted subscription", Valid: true},
+		Frequency:    "monthly",
+		IntervalN:    1,
+		FirstDueDate: time.Date(2031, 1, 20, 0, 0, 0, 0, time.UTC),
+		NextDueDate:  time.Date(2031, 3, 20, 0, 0, 0, 0, time.UTC),
+		Active:       true,
+	})
+	require.NoError(t, err)
+	generated, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+		UserID:          1,
+		AmountPence:     -899,
+		TDate:           time.Date(2031, 2, 20, 0, 0, 0, 0, time.UTC),
+		SourceRecurring: sql.NullInt64{Int64: rule.ID, Valid: true},
+	})
+	require.NoError(t, err)
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.GET("/recurring", h.GetRecurring)
+	router.GET("/recurring/:id", h.GetRecurringByID)
+	router.DELETE("/recurring/:id", h.DeleteRecurring)
+	router.DELETE("/admin/recurring/:id", h.HardDeleteRecurring)
+	router.PATCH("/recurring/:id", ValidateRequest[model.UpdateRecurringRequest](), h.UpdateRecurring)
+	router.PATCH("/recurring/:id/toggle", h.ToggleRecurringActive)
+	router.GET("/recurring/:id/calendar.ics", h.GetRecurringCalendar)
+	router.POST("/recurring/:id/clone", ValidateOptionalRequest[model.CloneRecurringRequest](), h.CloneRecurring)
+	router.POST("/recurring/:id/reschedule", ValidateOptionalRequest[model.RescheduleRecurringRequest](), h.RescheduleRecurring)
+	router.POST("/recurring/:id/tags/:tag_id", h.AddRecurringTag)
+	router.DELETE("/recurring/:id/tags/:tag_id", h.RemoveRecurringTag)
+
+	sendJSON := func(method, url, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	send := func(method, url string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, url, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	ruleURL := "/recurring/" + strconv.FormatInt(rule.ID, 10)
+	listedIDs := func() []int64 {
+		w := send("GET", "/recurring")
+		require.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Data []model.RecurringResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		ids := make([]int64, len(response.Data))
+		for i, r := range response.Data {
+			ids[i] = r.ID
+		}
+		return ids
+	}
+
+	require.Contains(t, listedIDs(), rule.ID)
+
+	t.Run("soft delete hides the rule", func(t *testing.T) {
+		w := send("DELETE", ruleURL)
+		require.Equal(t, http.StatusNoContent, w.Code)
+		assert.Empty(t, w.Body.String())
+		assert.NotContains(t, listedIDs(), rule.ID)
+
+		due, err := repository.GetRecurringDueOnDate(ctx, time.Date(2031, 12, 31, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		for _, r := range due {
+			assert.NotEqual(t, rule.ID, r.ID)
+		}
+
+		// Deleting it again finds nothing to delete
+		assert.Equal(t, http.StatusNotFound, send("DELETE", ruleURL).Code)
+	})
+
+	t.Run("soft-deleted rule can't be changed", func(t *testing.T) {
+		tag, err := repository.CreateTag(ctx, "soft-deleted-tag")
+		require.NoError(t, err)
+		tagURL := ruleURL + "/tags/" + strconv.FormatInt(tag.ID, 10)
+
+		assert.Equal(t, http.StatusNotFound, send("PATCH", ruleURL+"/toggle").Code)
+		assert.Equal(t, http.StatusNotFound, sendJSON("PATCH", ruleURL, `{"amount": "-9.99"}`).Code)
+		assert.Equal(t, http.StatusNotFound, sendJSON("POST", ruleURL+"/clone", `{}`).Code)
+		assert.Equal(t, http.StatusNotFound, sendJSON("POST", ruleURL+"/reschedule", `{}`).Code)
+		assert.Equal(t, http.StatusNotFound, send("POST", tagURL).Code)
+		assert.Equal(t, http.StatusNotFound, send("DELETE", tagURL).Code)
+		assert.Equal(t, http.StatusNotFound, send("GET", ruleURL+"/calendar.ics").Code)
+
+		stored, err := repository.GetRecurringByID(ctx, rule.ID)
+		require.NoError(t, err)
+		assert.True(t, stored.DeletedAt.Valid)
+		assert.False(t, stored.Active)
+		assert.Equal(t, int64(-899), stored.AmountPence)
+		tags, err := repository.GetRecurringTags(ctx, rule.ID)
+		require.NoError(t, err)
+		assert.Empty(t, tags)
+	})
+
+	t.Run("generated transactions still resolve their source", func(t *testing.T) {
+		txn, err := repository.GetTransactionByID(ctx, generated.ID)
+		require.NoError(t, err)
+		require.Equal(t, rule.ID, txn.SourceRecurring.Int64)
+
+		w := send("GET", "/recurring/"+strconv.FormatInt(txn.SourceRecurring.Int64, 10))
+		require.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Data model.RecurringResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "Soft-deleted subscription", response.Data.Description)
+		assert.NotNil(t, response.Data.DeletedAt)
+		assert.False(t, response.Data.Active)
+	})
+
+	t.Run("admin hard delete removes the rule", func(t *testing.T) {
+		tag, err := repository.CreateTag(ctx, "hard-deleted-tag")
+		require.NoError(t, err)
+		require.NoError(t, repository.CreateRecurringTag(ctx, repo.CreateRecurringTagParams{RecurringID: rule.ID, TagID: tag.ID}))
+
+		w := send("DELETE", "/admin"+ruleURL)
+		require.Equal(t, http.StatusNoContent, w.Code)
+
+		_, err = repository.GetRecurringByID(ctx, rule.ID)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		tags, err := repository.GetRecurringTags(ctx, rule.ID)
+		require.NoError(t, err)
+		assert.Empty(t, tags)
+		txn, err := repository.GetTransactionByID(ctx, generated.ID)
+		require.NoError(t, err)
+		assert.False(t, txn.SourceRecurring.Valid)
+	})
+}
+
+func TestRepairRecurringIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	// Keep the dev seed rules out of the count
+	_, err := db.Exec("UPDATE recurring SET active = 0")
+	require.NoError(t, err)
+
+	today := time.Date(2031, 6, 15, 0, 0, 0, 0, time.UTC)
+	create := func(nextDue time.Time, active bool) int64 {
+		rule, err := repository.CreateRecurring(ctx, repo.CreateRecurringParams{
+			UserID:       1,
+			AmountPence:  -1200,
+			Frequency:    "weekly",
+			IntervalN:    1,
+			FirstDueDate: nextDue,
+			NextDueDate:  nextDue,
+			Active:       active,
+		})
+		require.NoError(t, err)
+		return rule.ID
+	}
+	overdue := create(today.AddDate(0, 0, -100), true)
+	upcoming := create(today.AddDate(0, 0, 3), true)
+	paused := create(today.AddDate(0, 0, -100), false)
+
+	h := NewHandler(repository, zap.NewNop())
+	h.clock = fixedClock(today.Add(12 * time.Hour))
+	router := gin.New()
+	router.POST("/admin/recurring/repair", h.RepairRecurring)
+
+	repair := func() model.RepairRecurringResponse {
+		req := httptest.NewRequest("POST", "/admin/recurring/repair", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data model.RepairRecurringResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data
+	}
+	nextDue := func(id int64) string {
+		stored, err := repository.GetRecurringByID(ctx, id)
+		require.NoError(t, err)
+		return model.FormatDate(stored.NextDueDate)
+	}
+	transactionCount := func() int64 {
+		var count int64
+		require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM transactions WHERE source_recurring IN (?, ?, ?)", overdue, upcoming, paused).Scan(&count))
+		return count
+	}
+
+	response := repair()
+	assert.Equal(t, 2, response.Checked)
+	assert.Equal(t, 1, response.Repaired)
+
+	// 100 days is 14 weeks and 2 days, so the next weekly occurrence is 15 weeks on
+	assert.Equal(t, "2031-06-20", nextDue(overdue))
+	assert.Equal(t, "2031-06-18", nextDue(upcoming))
+	assert.Equal(t, "2031-03-07", nextDue(paused))
+	assert.Zero(t, transactionCount())
+
+	t.Run("a second run has nothing to repair", func(t *testing.T) {
+		response := repair()
+		assert.Equal(t, 0, response.Repaired)
+		assert.Equal(t, "2031-06-20", nextDue(overdue))
+	})
+}
+
+func TestGetRecurringCalendarIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	monthly, err := repository.CreateRecurring(ctx, repo.CreateRecurringParams{
+		UserID:       1,
+		AmountPence:  -85000,
+		Description:  sql.NullString{String: "Rent, flat 2", Valid: true},
+		Frequency:    "monthly",
+		IntervalN:    1,
+		FirstDueDate: time.Date(2031, 6, 1, 0, 0, 0, 0, time.UTC),
+		NextDueDate:  time.Date(2031, 6, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:      sql.NullTime{Time: time.Date(2031, 12, 31, 0, 0, 0, 0, time.UTC), Valid: true},
+		Active:       true,
+	})
+	require.NoError(t, err)
+	weekly, err := repository.CreateRecurring(ctx, repo.CreateRecurringParams{
+		UserID:       1,
+		AmountPence:  -1200,
+		Frequency:    "weekly",
+		IntervalN:    2,
+		FirstDueDate: time.Date(2031, 6, 3, 0, 0, 0, 0, time.UTC),
+		NextDueDate:  time.Date(2031, 6, 3, 0, 0, 0, 0, time.UTC),
+		Active:       true,
+	})
+	require.NoError(t, err)
+	createRule := func(frequency string, intervalN int64, firstDue time.Time) repo.Recurring {
+		rule, err := repository.CreateRecurring(ctx, repo.CreateRecurringParams{
+			UserID:       1,
+			AmountPence:  -1000,
+			Frequency:    frequency,
+			IntervalN:    intervalN,
+			FirstDueDate: firstDue,
+			NextDueDate:  firstDue,
+			Active:       true,
+		})
+		require.NoError(t, err)
+		return rule
+	}
+
+	h := NewHandler(repository, zap.NewNop())
+	h.clock = fixedClock(time.Date(2031, 5, 20, 9, 30, 0, 0, time.UTC))
+	router := gin.New()
+	router.GET("/recurring/:id/calendar.ics", h.GetRecurringCalendar)
+
+	get := func(id int64) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/recurring/"+strconv.FormatInt(id, 10)+"/calendar.ics", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("monthly rule with an end date", func(t *testing.T) {
+		w := get(monthly.ID)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+
+		body := w.Body.String()
+		assert.True(t, strings.HasPrefix(body, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+		assert.True(t, strings.HasSuffix(body, "END:VEVENT\r\nEND:VCALENDAR\r\n"))
+		assert.Contains(t, body, "\r\nRRULE:FREQ=MONTHLY;INTERVAL=1;UNTIL=20311231\r\n")
+		assert.Contains(t, body, "\r\nDTSTART;VALUE=DATE:20310601\r\n")
+		assert.Contains(t, body, "\r\nDTSTAMP:20310520T093000Z\r\n")
+		assert.Contains(t, body, "\r\nUID:recurring-"+strconv.FormatInt(monthly.ID, 10)+"@budget-api\r\n")
+		assert.Contains(t, body, "\r\nSUMMARY:Rent\\, flat 2 (-£850.00)\r\n")
+	})
+
+	t.Run("weekly rule without an end date", func(t *testing.T) {
+		w := get(weekly.ID)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "\r\nRRULE:FREQ=WEEKLY;INTERVAL=2\r\n")
+		assert.Contains(t, w.Body.String(), "\r\nSUMMARY:Recurring transaction (-£12.00)\r\n")
+	})
+
+	t.Run("monthly rule on the 31st follows the scheduler's clamping", func(t *testing.T) {
+		rule := createRule("monthly", 1, time.Date(2031, 1, 31, 0, 0, 0, 0, time.UTC))
+		w := get(rule.ID)
+		require.Equal(t, http.StatusOK, w.Code)
+		body := w.Body.String()
+		id := strconv.FormatInt(rule.ID, 10)
+
+		// 31 January, then the 28th of every month as the scheduler generates
+		assert.Equal(t, 2, strings.Count(body, "BEGIN:VEVENT"))
+		assert.Contains(t, body, "\r\nUID:recurring-"+id+"-20310131@budget-api\r\n")
+		assert.Contains(t, body, "\r\nDTSTART;VALUE=DATE:20310131\r\nSUMMARY:")
+		assert.Contains(t, body, "\r\nUID:recurring-"+id+"@budget-api\r\n")
+		assert.Contains(t, body, "\r\nDTSTART;VALUE=DATE:20310228\r\nRRULE:FREQ=MONTHLY;INTERVAL=1\r\n")
+		assert.Equal(t, 1, strings.Count(body, "RRULE:"))
+	})
+
+	t.Run("yearly rule on 29 February follows the scheduler's clamping", func(t *testing.T) {
+		rule := createRule("yearly", 1, time.Date(2032, 2, 29, 0, 0, 0, 0, time.UTC))
+		body := get(rule.ID).Body.String()
+
+		assert.Contains(t, body, "\r\nDTSTART;VALUE=DATE:20320229\r\nSUMMARY:")
+		assert.Contains(t, body, "\r\nDTSTART;VALUE=DATE:20330228\r\nRRULE:FREQ=YEARLY;INTERVAL=1\r\n")
+	})
+
+	t.Run("a rule on the 31st that never reaches a shorter month is unchanged", func(t *testing.T) {
+		rule := createRule("monthly", 12, time.Date(2031, 1, 31, 0, 0, 0, 0, time.UTC))
+		body := get(rule.ID).Body.String()
+
+		assert.Equal(t, 1, strings.Count(body, "BEGIN:VEVENT"))
+		assert.Contains(t, body, "\r\nDTSTART;VALUE=DATE:20310131\r\nRRULE:FREQ=MONTHLY;INTERVAL=12\r\n")
+	})
+
+	t.Run("unknown rule", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, get(999999).Code)
+	})
+}
+
+func TestCalendarClampedOccurrencesMatchScheduler(t *testing.T) {
+	// The one-off events and the first RRULE occurrence are the dates the
+	// scheduler generates, and the RRULE's day no longer changes after them
+	rule := repo.Recurring{
+		Frequency:    "monthly",
+		IntervalN:    1,
+		FirstDueDate: time.Date(2031, 3, 31, 0, 0, 0, 0, time.UTC),
+		NextDueDate:  time.Date(2031, 3, 31, 0, 0, 0, 0, time.UTC),
+	}
+	clamped, start := calendarClampedOccurrences(rule)
+
+	date := rule.FirstDueDate
+	for _, occurrence := range clamped {
+		assert.Equal(t, date, occurrence)
+		date = scheduler.Advance(model.FrequencyMonthly, date, 1)
+	}
+	assert.Equal(t, date, start)
+	assert.Equal(t, time.Date(2033, 2, 28, 0, 0, 0, 0, time.UTC), start)
+	for i := 0; i < 60; i++ {
+		date = scheduler.Advance(model.FrequencyMonthly, date, 1)
+		assert.Equal(t, 28, date.Day())
+	}
+
+	// Ended before the clamping settles
+	rule.EndDate = sql.NullTime{Time: time.Date(2031, 5, 31, 0, 0, 0, 0, time.UTC), Valid: true}
+	clamped, start = calendarClampedOccurrences(rule)
+	assert.Equal(t, []time.Time{
+		time.Date(2031, 3, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2031, 4, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2031, 5, 30, 0, 0, 0, 0, time.UTC),
+	}, clamped)
+	assert.True(t, start.After(rule.EndDate.Time))
+}
+
+func TestGetRecurringCalendarFeedIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	// Deactivate the seeded rules so the feed holds only the ones below
+	_, err := db.Exec("UPDATE recurring SET active = 0")
+	require.NoError(t, err)
+
+	create := func(description string, amount int64, active bool) repo.Recurring {
+		rule, err := repository.CreateRecurring(ctx, repo.CreateRecurringParams{
+			UserID:       1,
+			AmountPence:  amount,
+			Description:  sql.NullString{String: description, Valid: true},
+			Frequency:    "monthly",
+			IntervalN:    1,
+			FirstDueDate: time.Date(2031, 6, 1, 0, 0, 0, 0, time.UTC),
+			NextDueDate:  time.Date(2031, 6, 1, 0, 0, 0, 0, time.UTC),
+			Active:       active,
+		})
+		require.NoError(t, err)
+		return rule
+	}
+	rent := create("Rent", -85000, true)
+	salary := create("Salary", 250000, true)
+	create("Old gym", -3000, false)
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.GET("/recurring/calendar.ics", h.GetRecurringCalendarFeed)
+
+	req := httptest.NewRequest("GET", "/recurring/calendar.ics", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+
+	body := w.Body.String()
+	assert.Equal(t, 1, strings.Count(body, "BEGIN:VCALENDAR\r\n"))
+	assert.Equal(t, 2, strings.Count(body, "BEGIN:VEVENT\r\n"))
+	assert.Equal(t, 2, strings.Count(body, "END:VEVENT\r\n"))
+	assert.Contains(t, body, "\r\nUID:recurring-"+strconv.FormatInt(rent.ID, 10)+"@budget-api\r\n")
+	assert.Contains(t, body, "\r\nUID:recurring-"+strconv.FormatInt(salary.ID, 10)+"@budget-api\r\n")
+	assert.Contains(t, body, "\r\nSUMMARY:Rent (-£850.00)\r\n")
+	assert.Contains(t, body, "\r\nSUMMARY:Salary (£2500.00)\r\n")
+	assert.NotContains(t, body, "Old gym")
+}
+
+func TestGetStaleRecurringIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	// Deactivate the seeded rules so only the ones below are considered
+	_, err := db.Exec("UPDATE recurring SET active = 0")
+	require.NoError(t, err)
+
+	create := func(description string, generated ...time.Time) repo.Recurring {
+		rule, err := repository.CreateRecurring(ctx, repo.CreateRecurringParams{
+			UserID:       1,
+			AmountPence:  -999,
+			Description:  sql.NullString{String: description, Valid: true},
+			Frequency:    "monthly",
+			IntervalN:    1,
+			FirstDueDate: time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC),
+			NextDueDate:  time.Date(2031, 7, 1, 0, 0, 0, 0, time.UTC),
+			Active:       true,
+		})
+		require.NoError(t, err)
+		for _, date := range generated {
+			_, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+				UserID:          1,
+				AmountPence:     -999,
+				TDate:           date,
+				SourceRecurring: sql.NullInt64{Int64: rule.ID, Valid: true},
+			})
+			require.NoError(t, err)
+		}
+		return rule
+	}
+	stale := create("Forgotten streaming",
+		time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2031, 2, 1, 0, 0, 0, 0, time.UTC),
+	)
+	create("Phone bill",
+		time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2031, 5, 1, 0, 0, 0, 0, time.UTC),
+	)
+	never := create("Never charged")
+
+	// Added recently and not due yet, so it hasn't had the chance to generate
+	recent, err := repository.CreateRecurring(ctx, repo.CreateRecurringParams{
+		UserID:       1,
+		AmountPence:  -999,
+		Description:  sql.NullString{String: "New gym membership", Valid: true},
+		Frequency:    "monthly",
+		IntervalN:    1,
+		FirstDueDate: time.Date(2031, 5, 25, 0, 0, 0, 0, time.UTC),
+		NextDueDate:  time.Date(2031, 6, 25, 0, 0, 0, 0, time.UTC),
+		Active:       true,
+	})
+	require.NoError(t, err)
+
+	h := NewHandler(repository, zap.NewNop())
+	h.clock = fixedClock(time.Date(2031, 6, 1, 12, 0, 0, 0, time.UTC))
+	router := gin.New()
+	router.GET("/recurring/stale", h.GetStaleRecurring)
+
+	get := func(query string) (int, model.StaleRecurringResponse) {
+		req := httptest.NewRequest("GET", "/recurring/stale"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var response struct {
+			Data model.StaleRecurringResponse `json:"data"`
+		}
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w.Code, response.Data
+	}
+
+	t.Run("default threshold", func(t *testing.T) {
+		code, response := get("")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, 90, response.Days)
+		assert.Equal(t, "2031-03-03", response.Cutoff)
+		require.Len(t, response.Rules, 2)
+
+		assert.Equal(t, never.ID, response.Rules[0].RecurringID)
+		assert.Nil(t, response.Rules[0].LastGeneratedDate)
+		assert.Nil(t, response.Rules[0].DaysSinceLast)
+
+		assert.Equal(t, stale.ID, response.Rules[1].RecurringID)
+		assert.Equal(t, "Forgotten streaming", response.Rules[1].Description)
+		require.NotNil(t, response.Rules[1].LastGeneratedDate)
+		assert.Equal(t, "2031-02-01", *response.Rules[1].LastGeneratedDate)
+		require.NotNil(t, response.Rules[1].DaysSinceLast)
+		assert.Equal(t, 120, *response.Rules[1].DaysSinceLast)
+	})
+
+	t.Run("a shorter threshold includes the fresh rule", func(t *testing.T) {
+		code, response := get("?days=14")
+		require.Equal(t, http.StatusOK, code)
+		assert.Len(t, response.Rules, 3)
+	})
+
+	t.Run("rules that never generated are stale once first due before the cutoff", func(t *testing.T) {
+		ids := func(response model.StaleRecurringResponse) []int64 {
+			ids := make([]int64, len(response.Rules))
+			for i, rule := range response.Rules {
+				ids[i] = rule.RecurringID
+			}
+			return ids
+		}
+
+		_, response := get("?days=14")
+		assert.NotContains(t, ids(response), recent.ID)
+
+		_, response = get("?days=3")
+		assert.Contains(t, ids(response), recent.ID)
+		assert.Len(t, response.Rules, 4)
+	})
+
+	t.Run("invalid days", func(t *testing.T) {
+		code, _ := get("?days=0")
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+func TestCreateRecurringBackfillIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	h := NewHandler(repository, zap.NewNop())
+	h.clock = fixedClock(time.Date(2031, 6, 15, 9, 0, 0, 0, time.UTC))
+	router := gin.New()
+	router.POST("/recurring", ValidateRequest[model.CreateRecurringRequest](), h.CreateRecurring)
+	router.POST("/recurring/:id/clone", ValidateOptionalRequest[model.CloneRecurringRequest](), h.CloneRecurring)
+	router.POST("/transactions/:id/make-recurring", ValidateRequest[model.MakeRecurringRequest](), h.MakeTransactionRecurring)
+
+	create := func(query, firstDueDate string) int {
+		body, _ := json.Marshal(map[string]interface{}{
+			"amount":         "-12.00",
+			"description":    "Backfill " + firstDueDate,
+			"frequency":      "monthly",
+			"interval_n":     1,
+			"first_due_date": firstDueDate,
+		})
+		req := httptest.NewRequest("POST", "/recurring"+query, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	ruleCount := func() int {
+		rules, err := repository.ListRecurring(ctx, repo.ListRecurringParams{UserID: 1, Limit: -1})
+		require.NoError(t, err)
+		return len(rules)
+	}
+
+	t.Run("rejects a first due date more than 90 days ago", func(t *testing.T) {
+		before := ruleCount()
+		assert.Equal(t, http.StatusBadRequest, create("", "2031-03-16"))
+		assert.Equal(t, http.StatusBadRequest, create("?backfill=false", "2029-01-01"))
+		assert.Equal(t, before, ruleCount())
+	})
+
+	t.Run("allows recent past and future dates", func(t *testing.T) {
+		assert.Equal(t, http.StatusCreated, create("", "2031-03-17"))
+		assert.Equal(t, http.StatusCreated, create("", "2031-07-01"))
+	})
+
+	t.Run("allows an explicit backfill", func(t *testing.T) {
+		before := ruleCount()
+		assert.Equal(t, http.StatusCreated, create("?backfill=true", "2029-01-01"))
+		assert.Equal(t, before+1, ruleCount())
+	})
+
+	t.Run("rejects an invalid backfill flag", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, create("?backfill=maybe", "2031-07-01"))
+	})
+
+	t.Run("clones are guarded too", func(t *testing.T) {
+		source, err := repository.CreateRecurring(ctx, repo.CreateRecurringParams{
+			UserID:       1,
+			AmountPence:  -500,
+			Frequency:    "monthly",
+			IntervalN:    1,
+			FirstDueDate: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+			NextDueDate:  time.Date(2031, 7, 1, 0, 0, 0, 0, time.UTC),
+			Active:       true,
+		})
+		require.NoError(t, err)
+
+		clone := func(query string) int {
+			req := httptest.NewRequest("POST", "/recurring/"+strconv.FormatInt(source.ID, 10)+"/clone"+query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w.Code
+		}
+		assert.Equal(t, http.StatusBadRequest, clone(""))
+		assert.Equal(t, http.StatusCreated, clone("?backfill=true"))
+	})
+
+	t.Run("rules made from transactions are guarded too", func(t *testing.T) {
+		txn, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+			UserID:      1,
+			AmountPence: -1500,
+			TDate:       time.Date(2029, 1, 1, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+
+		makeRecurring := func(query, firstDueDate string) int {
+			body, _ := json.Marshal(map[string]interface{}{
+				"frequency":      "monthly",
+				"interval_n":     1,
+				"first_due_date": firstDueDate,
+			})
+			req := httptest.NewRequest("POST", "/transactions/"+strconv.FormatInt(txn.ID, 10)+"/make-recurring"+query, bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w.Code
+		}
+		before := ruleCount()
+		assert.Equal(t, http.StatusBadRequest, makeRecurring("", "2029-01-01"))
+		assert.Equal(t, before, ruleCount())
+		assert.Equal(t, http.StatusCreated, makeRecurring("?backfill=true", "2029-01-01"))
+		assert.Equal(t, before+1, ruleCount())
+	})
+
+	t.Run("the limit comes from the setting", func(t *testing.T) {
+		_, err := repository.CreateSetting(ctx, repo.CreateSettingParams{
+			Key:   "recurring_max_backfill_days",
+			Value: "10",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, create("", "2031-06-04"))
+		assert.Equal(t, http.StatusCreated, create("", "2031-06-05"))
+	})
+}
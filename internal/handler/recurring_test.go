@@ -27,7 +27,10 @@ type MockRepository struct {
 // Implement the required methods for the mock
 func (m *MockRepository) WithTx(ctx context.Context, fn func(repo.Repository) error) error {
 	args := m.Called(ctx, fn)
-	return args.Error(0)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+	return fn(m)
 }
 
 // GetDB returns nil for mock (not used in tests)
@@ -48,8 +51,8 @@ func (m *MockRepository) GetRecurringByID(ctx context.Context, id int64) (repo.R
 	return args.Get(0).(repo.Recurring), args.Error(1)
 }
 
-func (m *MockRepository) ListRecurring(ctx context.Context, userID int64) ([]repo.Recurring, error) {
-	args := m.Called(ctx, userID)
+func (m *MockRepository) ListRecurring(ctx context.Context, arg repo.ListRecurringParams) ([]repo.Recurring, error) {
+	args := m.Called(ctx, arg)
 	return args.Get(0).([]repo.Recurring), args.Error(1)
 }
 
@@ -164,9 +167,9 @@ func (m *MockRepository) HardDeleteTransaction(ctx context.Context, id int64) er
 	return args.Error(0)
 }
 
-func (m *MockRepository) PurgeSoftDeletedTransactions(ctx context.Context, deletedAt sql.NullTime) error {
-	args := m.Called(ctx, deletedAt)
-	return args.Error(0)
+func (m *MockRepository) PurgeSoftDeletedTransactions(ctx context.Context, arg repo.PurgeSoftDeletedTransactionsParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
 }
 
 func (m *MockRepository) CreateTag(ctx context.Context, name string) (repo.Tag, error) {
@@ -214,8 +217,8 @@ func (m *MockRepository) DeleteAllTransactionTags(ctx context.Context, transacti
 	return args.Error(0)
 }
 
-func (m *MockRepository) ListActiveRecurring(ctx context.Context, userID int64) ([]repo.Recurring, error) {
-	args := m.Called(ctx, userID)
+func (m *MockRepository) ListActiveRecurring(ctx context.Context, arg repo.ListActiveRecurringParams) ([]repo.Recurring, error) {
+	args := m.Called(ctx, arg)
 	return args.Get(0).([]repo.Recurring), args.Error(1)
 }
 
@@ -294,6 +297,246 @@ func (m *MockRepository) DeleteAllSessionsByUserID(ctx context.Context, userID i
 	return args.Error(0)
 }
 
+func (m *MockRepository) SoftDeleteAllTransactionsByUser(ctx context.Context, userID int64) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) CreateRecurringHistory(ctx context.Context, arg repo.CreateRecurringHistoryParams) (repo.RecurringHistory, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(repo.RecurringHistory), args.Error(1)
+}
+
+func (m *MockRepository) ListRecurringHistory(ctx context.Context, arg repo.ListRecurringHistoryParams) ([]repo.RecurringHistory, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]repo.RecurringHistory), args.Error(1)
+}
+
+func (m *MockRepository) CountRecurringHistory(ctx context.Context, recurringID int64) (int64, error) {
+	args := m.Called(ctx, recurringID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) DeleteRecurringHistory(ctx context.Context, recurringID int64) error {
+	args := m.Called(ctx, recurringID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListTransactionTagsByDateRange(ctx context.Context, arg repo.ListTransactionTagsByDateRangeParams) ([]repo.ListTransactionTagsByDateRangeRow, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]repo.ListTransactionTagsByDateRangeRow), args.Error(1)
+}
+
+func (m *MockRepository) CountTransactions(ctx context.Context, arg repo.CountTransactionsParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) CountRecurring(ctx context.Context, arg repo.CountRecurringParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) CountActiveRecurring(ctx context.Context, arg repo.CountActiveRecurringParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) PurgeAllSoftDeleted(ctx context.Context, userID int64) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) ReassignTransactionTags(ctx context.Context, arg repo.ReassignTransactionTagsParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) DeleteTransactionTagsByTag(ctx context.Context, tagID int64) (int64, error) {
+	args := m.Called(ctx, tagID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) ListTransactionAmountsByDateRange(ctx context.Context, arg repo.ListTransactionAmountsByDateRangeParams) ([]repo.ListTransactionAmountsByDateRangeRow, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]repo.ListTransactionAmountsByDateRangeRow), args.Error(1)
+}
+
+func (m *MockRepository) GetBalanceBefore(ctx context.Context, arg repo.GetBalanceBeforeParams) (sql.NullFloat64, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(sql.NullFloat64), args.Error(1)
+}
+
+func (m *MockRepository) DetachRecurringTransactions(ctx context.Context, sourceRecurring sql.NullInt64) error {
+	args := m.Called(ctx, sourceRecurring)
+	return args.Error(0)
+}
+
+func (m *MockRepository) CreateTagAlert(ctx context.Context, arg repo.CreateTagAlertParams) (repo.TagAlert, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(repo.TagAlert), args.Error(1)
+}
+
+func (m *MockRepository) GetTagAlertByID(ctx context.Context, id int64) (repo.TagAlert, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(repo.TagAlert), args.Error(1)
+}
+
+func (m *MockRepository) ListTagAlerts(ctx context.Context, userID int64) ([]repo.TagAlert, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]repo.TagAlert), args.Error(1)
+}
+
+func (m *MockRepository) UpdateTagAlertThreshold(ctx context.Context, arg repo.UpdateTagAlertThresholdParams) (repo.TagAlert, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(repo.TagAlert), args.Error(1)
+}
+
+func (m *MockRepository) MarkTagAlertNotified(ctx context.Context, arg repo.MarkTagAlertNotifiedParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockRepository) DeleteTagAlert(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetTagMonthlySpend(ctx context.Context, arg repo.GetTagMonthlySpendParams) (sql.NullFloat64, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(sql.NullFloat64), args.Error(1)
+}
+
+func (m *MockRepository) GetTopSpendingTags(ctx context.Context, arg repo.GetTopSpendingTagsParams) ([]repo.GetTopSpendingTagsRow, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]repo.GetTopSpendingTagsRow), args.Error(1)
+}
+
+func (m *MockRepository) SoftDeleteRecurring(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockRepository) CreateReceipt(ctx context.Context, arg repo.CreateReceiptParams) (repo.Receipt, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(repo.Receipt), args.Error(1)
+}
+
+func (m *MockRepository) GetReceiptByID(ctx context.Context, id int64) (repo.Receipt, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(repo.Receipt), args.Error(1)
+}
+
+func (m *MockRepository) ListReceipts(ctx context.Context, transactionID int64) ([]repo.Receipt, error) {
+	args := m.Called(ctx, transactionID)
+	return args.Get(0).([]repo.Receipt), args.Error(1)
+}
+
+func (m *MockRepository) DeleteReceipt(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetTransactionByExternalID(ctx context.Context, arg repo.GetTransactionByExternalIDParams) (repo.Transaction, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(repo.Transaction), args.Error(1)
+}
+
+func (m *MockRepository) ToggleTransactionCleared(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockRepository) SetTransactionCleared(ctx context.Context, arg repo.SetTransactionClearedParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetNetByPeriod(ctx context.Context, arg repo.GetNetByPeriodParams) ([]repo.GetNetByPeriodRow, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]repo.GetNetByPeriodRow), args.Error(1)
+}
+
+func (m *MockRepository) DeleteTransactionsByUser(ctx context.Context, userID int64) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) DeleteRecurringByUser(ctx context.Context, userID int64) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) ListStaleRecurring(ctx context.Context, arg repo.ListStaleRecurringParams) ([]repo.ListStaleRecurringRow, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]repo.ListStaleRecurringRow), args.Error(1)
+}
+
+func (m *MockRepository) ListDailyActivity(ctx context.Context, arg repo.ListDailyActivityParams) ([]repo.ListDailyActivityRow, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]repo.ListDailyActivityRow), args.Error(1)
+}
+
+func (m *MockRepository) PurgeSoftDeletedTransaction(ctx context.Context, arg repo.PurgeSoftDeletedTransactionParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) AcquireSettingLock(ctx context.Context, arg repo.AcquireSettingLockParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) ReleaseSettingLock(ctx context.Context, arg repo.ReleaseSettingLockParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockRepository) SearchTags(ctx context.Context, arg repo.SearchTagsParams) ([]repo.Tag, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]repo.Tag), args.Error(1)
+}
+
+func (m *MockRepository) GetOrCreateTag(ctx context.Context, name string) (repo.Tag, bool, error) {
+	args := m.Called(ctx, name)
+	return args.Get(0).(repo.Tag), args.Bool(1), args.Error(2)
+}
+
+func (m *MockRepository) DeleteOtherSessionsByUserID(ctx context.Context, arg repo.DeleteOtherSessionsByUserIDParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockRepository) DeleteTransactionTagsByUser(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) PurgeSoftDeletedTransactionTags(ctx context.Context, arg repo.PurgeSoftDeletedTransactionTagsParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockRepository) PurgeAllSoftDeletedTags(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) PurgeSoftDeletedTransactionTagsByID(ctx context.Context, arg repo.PurgeSoftDeletedTransactionTagsByIDParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockRepository) DeleteRecurringTagsByTag(ctx context.Context, tagID int64) error {
+	args := m.Called(ctx, tagID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) DeleteRecurringTagsByUser(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
 // TestCreateRecurring tests the CreateRecurring handler
 func TestCreateRecurring(t *testing.T) {
 	// Set Gin to test mode
@@ -311,7 +554,7 @@ func TestCreateRecurring(t *testing.T) {
 		Description:   "Monthly subscription",
 		Frequency:     "monthly",
 		IntervalN:     1,
-		FirstDueDate:  "2025-07-01",
+		FirstDueDate:  "2031-07-01",
 		TagIDs:        []int64{1, 2},
 	}
 
@@ -334,6 +577,8 @@ func TestCreateRecurring(t *testing.T) {
 	c.Set("validated_request", request)
 
 	// Set up mock expectations
+	mockRepo.On("GetUserByID", mock.Anything, int64(1)).Return(repo.User{ID: 1, Timezone: "UTC"}, nil)
+	mockRepo.On("WithTx", mock.Anything, mock.Anything).Return(nil)
 	mockRepo.On("CreateRecurring", mock.Anything, mock.AnythingOfType("repo.CreateRecurringParams")).Return(
 		repo.Recurring{
 			ID: 1,
@@ -348,7 +593,7 @@ func TestCreateRecurring(t *testing.T) {
 	handler.CreateRecurring(c)
 
 	// Assert the response
-	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, http.StatusCreated, w.Code)
 	
 	// Verify mock expectations
 	mockRepo.AssertExpectations(t)
@@ -377,7 +622,9 @@ func TestGetRecurring(t *testing.T) {
 	c.Request = req
 
 	// Set up mock expectations
-	mockRepo.On("ListRecurring", mock.Anything, int64(1)).Return([]repo.Recurring{}, nil)
+	mockRepo.On("GetSetting", mock.Anything, mock.Anything).Return(repo.Setting{}, sql.ErrNoRows)
+	mockRepo.On("ListRecurring", mock.Anything, repo.ListRecurringParams{UserID: 1, Limit: -1}).Return([]repo.Recurring{}, nil)
+	mockRepo.On("CountRecurring", mock.Anything, repo.CountRecurringParams{UserID: 1}).Return(int64(0), nil)
 
 	// Call the handler
 	handler.GetRecurring(c)
@@ -389,6 +636,30 @@ func TestGetRecurring(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+// TestGetRecurringWithoutTagsHasEmptyTagIDs checks tag_ids serializes as [] rather than null
+func TestGetRecurringWithoutTagsHasEmptyTagIDs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockRepo := new(MockRepository)
+	handler := NewHandler(mockRepo, zap.NewNop())
+
+	req, _ := http.NewRequest("GET", "/api/v1/recurring", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	mockRepo.On("GetSetting", mock.Anything, mock.Anything).Return(repo.Setting{}, sql.ErrNoRows)
+	mockRepo.On("ListRecurring", mock.Anything, repo.ListRecurringParams{UserID: 1, Limit: -1}).Return([]repo.Recurring{{ID: 1, Frequency: "monthly", IntervalN: 1}}, nil)
+	mockRepo.On("CountRecurring", mock.Anything, repo.CountRecurringParams{UserID: 1}).Return(int64(1), nil)
+	mockRepo.On("GetRecurringTags", mock.Anything, int64(1)).Return([]repo.Tag(nil), nil)
+
+	handler.GetRecurring(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"tag_ids":[]`)
+	mockRepo.AssertExpectations(t)
+}
+
 // TestListActiveRecurring tests the ListActiveRecurring handler
 func TestListActiveRecurring(t *testing.T) {
 	// Set Gin to test mode
@@ -412,7 +683,9 @@ func TestListActiveRecurring(t *testing.T) {
 	c.Request = req
 
 	// Set up mock expectations
-	mockRepo.On("ListActiveRecurring", mock.Anything, int64(1)).Return([]repo.Recurring{}, nil)
+	mockRepo.On("GetSetting", mock.Anything, mock.Anything).Return(repo.Setting{}, sql.ErrNoRows)
+	mockRepo.On("ListActiveRecurring", mock.Anything, repo.ListActiveRecurringParams{UserID: 1, Limit: -1}).Return([]repo.Recurring{}, nil)
+	mockRepo.On("CountActiveRecurring", mock.Anything, repo.CountActiveRecurringParams{UserID: 1}).Return(int64(0), nil)
 
 	// Call the handler
 	handler.ListActiveRecurring(c)
@@ -424,6 +697,107 @@ func TestListActiveRecurring(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+// TestGetRecurringGroups tests grouping active rules and their monthly-equivalent cost
+func TestGetRecurringGroups(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockRepo := new(MockRepository)
+	handler := NewHandler(mockRepo, zap.NewNop())
+
+	streaming := sql.NullString{String: "Streaming", Valid: true}
+	utilities := sql.NullString{String: "Utilities", Valid: true}
+	mockRepo.On("ListActiveRecurring", mock.Anything, repo.ListActiveRecurringParams{UserID: 1, Limit: -1}).Return([]repo.Recurring{
+		{ID: 1, AmountPence: -1799, Frequency: "monthly", IntervalN: 1, Active: true, GroupName: streaming},
+		{ID: 2, AmountPence: -1199, Frequency: "monthly", IntervalN: 1, Active: true, GroupName: streaming},
+		{ID: 3, AmountPence: -12000, Frequency: "yearly", IntervalN: 1, Active: true, GroupName: utilities},
+		{ID: 4, AmountPence: -1000, Frequency: "weekly", IntervalN: 2, Active: true, GroupName: utilities},
+		{ID: 5, AmountPence: -500, Frequency: "monthly", IntervalN: 1, Active: true},
+	}, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/recurring/groups", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	handler.GetRecurringGroups(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Data []model.RecurringGroupSummary `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	if assert.Len(t, response.Data, 3) {
+		assert.Equal(t, "Streaming", *response.Data[0].Group)
+		assert.Equal(t, 2, response.Data[0].Count)
+		assert.Equal(t, int64(-2998), response.Data[0].MonthlyTotalPence)
+		assert.Equal(t, "-29.98", response.Data[0].MonthlyTotal)
+
+		// yearly -120.00 is -10.00 a month; fortnightly -10.00 is 52/24 of that
+		assert.Equal(t, "Utilities", *response.Data[1].Group)
+		assert.Equal(t, 2, response.Data[1].Count)
+		assert.Equal(t, int64(-1000-2167), response.Data[1].MonthlyTotalPence)
+
+		assert.Nil(t, response.Data[2].Group)
+		assert.Equal(t, 1, response.Data[2].Count)
+		assert.Equal(t, int64(-500), response.Data[2].MonthlyTotalPence)
+	}
+	assert.Contains(t, w.Body.String(), `"group":null`)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMonthlyEquivalentPence(t *testing.T) {
+	tests := []struct {
+		frequency string
+		intervalN int64
+		amount    int64
+		expected  int64
+	}{
+		{"daily", 1, 100, 3042},
+		{"weekly", 1, 1200, 5200},
+		{"weekly", 2, 1200, 2600},
+		{"monthly", 1, -1799, -1799},
+		{"monthly", 3, 3000, 1000},
+		{"yearly", 1, 12000, 1000},
+	}
+	for _, tt := range tests {
+		rule := repo.Recurring{AmountPence: tt.amount, Frequency: tt.frequency, IntervalN: tt.intervalN}
+		assert.Equal(t, tt.expected, monthlyEquivalentPence(rule, time.Now()), "%s every %d", tt.frequency, tt.intervalN)
+	}
+}
+
+func TestMonthlyEquivalentPenceProratesEndDate(t *testing.T) {
+	today := time.Date(2031, 1, 10, 0, 0, 0, 0, time.UTC)
+	openEnded := repo.Recurring{
+		AmountPence: -1200,
+		Frequency:   "monthly",
+		IntervalN:   1,
+		NextDueDate: time.Date(2031, 1, 15, 0, 0, 0, 0, time.UTC),
+	}
+	assert.Equal(t, int64(-1200), monthlyEquivalentPence(openEnded, today))
+
+	// Due 15 Jan, 15 Feb and 15 Mar before it ends: 3 * -12.00 / 12
+	endingSoon := openEnded
+	endingSoon.EndDate = sql.NullTime{Time: time.Date(2031, 4, 10, 0, 0, 0, 0, time.UTC), Valid: true}
+	assert.Equal(t, int64(-300), monthlyEquivalentPence(endingSoon, today))
+
+	// Ending on a due date counts that occurrence
+	endingSoon.EndDate.Time = time.Date(2031, 4, 15, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, int64(-400), monthlyEquivalentPence(endingSoon, today))
+
+	// Stored rules that never advance count once instead of looping forever
+	stuck := endingSoon
+	stuck.IntervalN = 0
+	assert.Equal(t, int64(-100), monthlyEquivalentPence(stuck, today))
+	stuck = endingSoon
+	stuck.Frequency = "fortnightly"
+	assert.Equal(t, int64(-100), monthlyEquivalentPence(stuck, today))
+
+	// Ending after the twelve-month window is the same as open-ended
+	endingLater := openEnded
+	endingLater.EndDate = sql.NullTime{Time: time.Date(2033, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+	assert.Equal(t, int64(-1200), monthlyEquivalentPence(endingLater, today))
+}
+
 // TestToggleRecurringActive tests the ToggleRecurringActive handler
 func TestToggleRecurringActive(t *testing.T) {
 	// Set Gin to test mode
@@ -449,18 +823,73 @@ func TestToggleRecurringActive(t *testing.T) {
 
 	// Set up mock expectations
 	mockRepo.On("GetRecurringByID", mock.Anything, int64(1)).Return(repo.Recurring{ID: 1}, nil)
+	mockRepo.On("WithTx", mock.Anything, mock.Anything).Return(nil)
 	mockRepo.On("ToggleRecurringActive", mock.Anything, int64(1)).Return(nil)
+	mockRepo.On("CreateRecurringHistory", mock.Anything, repo.CreateRecurringHistoryParams{RecurringID: 1, Action: "resumed"}).Return(repo.RecurringHistory{ID: 1}, nil)
 
 	// Call the handler
 	handler.ToggleRecurringActive(c)
+	// c.Status only records the code; gin writes it once the request finishes
+	c.Writer.WriteHeaderNow()
 
 	// Assert the response
 	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Body.String())
 	
 	// Verify mock expectations
 	mockRepo.AssertExpectations(t)
 }
 
+func TestUpdateRecurringIntervalOutOfRangeForStoredFrequency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetRecurringByID", mock.Anything, int64(1)).
+		Return(repo.Recurring{ID: 1, Frequency: "yearly", IntervalN: 1}, nil)
+
+	handler := NewHandler(mockRepo, zap.NewNop())
+	router := gin.New()
+	router.PATCH("/recurring/:id", ValidateRequest[model.UpdateRecurringRequest](), handler.UpdateRecurring)
+
+	// Only interval_n is sent, so the stored yearly frequency bounds it
+	req := httptest.NewRequest("PATCH", "/recurring/1", bytes.NewBufferString(`{"interval_n": 12}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error": "validation failed", "data": {"interval_n": "must be at most 10 for yearly rules"}}`, w.Body.String())
+	mockRepo.AssertNotCalled(t, "UpdateRecurring", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestUpdateRecurringNoContent checks a successful update answers a bare 204;
+// a body on a 204 breaks clients that read it
+func TestUpdateRecurringNoContent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	existing := repo.Recurring{ID: 1, AmountPence: -999, Frequency: "monthly", IntervalN: 1, Active: true}
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetRecurringByID", mock.Anything, int64(1)).Return(existing, nil)
+	mockRepo.On("WithTx", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("UpdateRecurring", mock.Anything, mock.MatchedBy(func(arg repo.UpdateRecurringParams) bool {
+		return arg.ID == 1 && arg.Description.String == "Gym"
+	})).Return(existing, nil)
+
+	handler := NewHandler(mockRepo, zap.NewNop())
+	router := gin.New()
+	router.PATCH("/recurring/:id", ValidateRequest[model.UpdateRecurringRequest](), handler.UpdateRecurring)
+
+	req := httptest.NewRequest("PATCH", "/recurring/1", bytes.NewBufferString(`{"description": "Gym"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Body.String())
+	mockRepo.AssertExpectations(t)
+}
+
 // TestGetRecurringDueOnDate tests the GetRecurringDueOnDate handler
 func TestGetRecurringDueOnDate(t *testing.T) {
 	// Set Gin to test mode
@@ -567,16 +996,23 @@ func TestGetMonthlyReport(t *testing.T) {
 			},
 			expectedStatus: http.StatusOK,
 			expectedData: map[string]interface{}{
-				"total_in":  "50.00",
-				"total_out": "30.00",
+				"currency":        "EUR",
+				"total_in":        "50.00",
+				"total_out":       "30.00",
+				"total_in_pence":  float64(5000),
+				"total_out_pence": float64(3000),
 				"by_tag": map[string]interface{}{
 					"Food": map[string]interface{}{
-						"total_in":  "0.00",
-						"total_out": "20.00",
+						"total_in":        "0.00",
+						"total_out":       "20.00",
+						"total_in_pence":  float64(0),
+						"total_out_pence": float64(2000),
 					},
 					"Transport": map[string]interface{}{
-						"total_in":  "0.00",
-						"total_out": "10.00",
+						"total_in":        "0.00",
+						"total_out":       "10.00",
+						"total_in_pence":  float64(0),
+						"total_out_pence": float64(1000),
 					},
 				},
 			},
@@ -598,6 +1034,7 @@ func TestGetMonthlyReport(t *testing.T) {
 			if tt.expectedStatus == http.StatusOK {
 				mockRepo.On("GetMonthlyTotals", mock.Anything, mock.Anything).Return(tt.mockTotals, nil)
 				mockRepo.On("GetMonthlyReport", mock.Anything, mock.Anything).Return(tt.mockReport, nil)
+				mockRepo.On("GetSetting", mock.Anything, "default_currency").Return(repo.Setting{Key: "default_currency", Value: "EUR"}, nil)
 			}
 
 			// Create handler
@@ -634,6 +1071,65 @@ func TestGetMonthlyReport(t *testing.T) {
 	}
 }
 
+func TestGetMonthlyReportCSV(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, tt := range []struct {
+		name   string
+		url    string
+		accept string
+	}{
+		{name: "format query", url: "/reports/monthly?ym=2025-06&format=csv"},
+		{name: "accept header", url: "/reports/monthly?ym=2025-06", accept: "text/csv"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockRepository)
+			mockRepo.On("GetMonthlyTotals", mock.Anything, mock.Anything).Return(repo.GetMonthlyTotalsRow{
+				TotalInPence:  sql.NullFloat64{Float64: 5000, Valid: true},
+				TotalOutPence: sql.NullFloat64{Float64: 3000, Valid: true},
+			}, nil)
+			mockRepo.On("GetMonthlyReport", mock.Anything, mock.Anything).Return([]repo.GetMonthlyReportRow{
+				{
+					TagName:       sql.NullString{String: "Transport", Valid: true},
+					TotalOutPence: sql.NullFloat64{Float64: 1000, Valid: true},
+				},
+				{
+					TagName:       sql.NullString{String: "Food", Valid: true},
+					TotalOutPence: sql.NullFloat64{Float64: 2000, Valid: true},
+				},
+				{
+					TagName: sql.NullString{String: "Total", Valid: true},
+				},
+				{
+					TagName: sql.NullString{String: `=HYPERLINK("http://example.com")`, Valid: true},
+				},
+			}, nil)
+			mockRepo.On("GetSetting", mock.Anything, "default_currency").Return(repo.Setting{}, sql.ErrNoRows)
+
+			handler := NewHandler(mockRepo, zap.NewNop())
+			router := gin.New()
+			router.GET("/reports/monthly", handler.GetMonthlyReport)
+
+			req := httptest.NewRequest("GET", tt.url, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+			assert.Equal(t, "row,tag,total_in,total_out,total_in_pence,total_out_pence,currency\n"+
+				"tag,\"'=HYPERLINK(\"\"http://example.com\"\")\",0.00,0.00,0,0,GBP\n"+
+				"tag,Food,0.00,20.00,0,2000,GBP\n"+
+				"tag,Total,0.00,0.00,0,0,GBP\n"+
+				"tag,Transport,0.00,10.00,0,1000,GBP\n"+
+				"total,,50.00,30.00,5000,3000,GBP\n", w.Body.String())
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
 // TestGetMonthlyTotals tests the GetMonthlyTotals handler
 func TestGetMonthlyTotals(t *testing.T) {
 	gin.SetMode(gin.TestMode)
@@ -652,12 +1148,24 @@ func TestGetMonthlyTotals(t *testing.T) {
 				TotalInPence:     sql.NullFloat64{Float64: 5000, Valid: true},  // £50.00
 				TotalOutPence:    sql.NullFloat64{Float64: 3000, Valid: true},  // £30.00
 				TransactionCount: 5,
+				MinExpensePence:  sql.NullFloat64{Float64: 500, Valid: true},
+				MaxExpensePence:  sql.NullFloat64{Float64: 1500, Valid: true},
+				AvgExpensePence:  sql.NullFloat64{Float64: 1000, Valid: true},
 			},
 			expectedStatus: http.StatusOK,
 			expectedData: map[string]interface{}{
+				"currency":          "GBP",
 				"total_in":          "50.00",
 				"total_out":         "30.00",
+				"total_in_pence":    float64(5000),
+				"total_out_pence":   float64(3000),
 				"transaction_count": float64(5),
+				"min_expense":       "5.00",
+				"max_expense":       "15.00",
+				"avg_expense":       "10.00",
+				"min_expense_pence": float64(500),
+				"max_expense_pence": float64(1500),
+				"avg_expense_pence": float64(1000),
 				"year_month":        "2025-06",
 			},
 		},
@@ -677,6 +1185,7 @@ func TestGetMonthlyTotals(t *testing.T) {
 			// Setup expectations
 			if tt.expectedStatus == http.StatusOK {
 				mockRepo.On("GetMonthlyTotals", mock.Anything, mock.Anything).Return(tt.mockTotals, nil)
+				mockRepo.On("GetSetting", mock.Anything, "default_currency").Return(repo.Setting{}, sql.ErrNoRows)
 			}
 
 			// Create handler
@@ -711,4 +1220,103 @@ func TestGetMonthlyTotals(t *testing.T) {
 			mockRepo.AssertExpectations(t)
 		})
 	}
-} 
\ No newline at end of file
+} 
+// TestGetUpcomingRecurring tests projecting rule occurrences over a window
+func TestGetUpcomingRecurring(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	from := time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo := new(MockRepository)
+	mockRepo.On("ListActiveRecurring", mock.Anything, repo.ListActiveRecurringParams{UserID: 1, Limit: -1}).Return([]repo.Recurring{
+		{
+			ID: 1, AmountPence: -1000, Frequency: "weekly", IntervalN: 1, Active: true,
+			Description: sql.NullString{String: "Cleaner", Valid: true},
+			NextDueDate: from.AddDate(0, 0, 3),
+		},
+		{
+			ID: 2, AmountPence: -5000, Frequency: "monthly", IntervalN: 1, Active: true,
+			Description: sql.NullString{String: "Gym", Valid: true},
+			NextDueDate: from.AddDate(0, 0, 10),
+		},
+		{
+			// Ends before its second occurrence in the window
+			ID: 3, AmountPence: -200, Frequency: "daily", IntervalN: 1, Active: true,
+			NextDueDate: from,
+			EndDate:     sql.NullTime{Time: from, Valid: true},
+		},
+	}, nil)
+
+	handler := NewHandler(mockRepo, zap.NewNop())
+	req, _ := http.NewRequest("GET", "/api/v1/recurring/upcoming?days=30&from=2031-01-01", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	handler.GetUpcomingRecurring(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Data model.UpcomingRecurringResponse `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "2031-01-01", response.Data.From)
+	assert.Equal(t, "2031-01-31", response.Data.To)
+
+	perRule := map[int64][]string{}
+	for _, o := range response.Data.Occurrences {
+		perRule[o.RecurringID] = append(perRule[o.RecurringID], o.DueDate)
+	}
+	assert.Equal(t, []string{"2031-01-04", "2031-01-11", "2031-01-18", "2031-01-25"}, perRule[1])
+	assert.Equal(t, []string{"2031-01-11"}, perRule[2])
+	assert.Equal(t, []string{"2031-01-01"}, perRule[3])
+
+	// Occurrences are in date order with a running total
+	if assert.Len(t, response.Data.Occurrences, 6) {
+		assert.Equal(t, "2031-01-01", response.Data.Occurrences[0].DueDate)
+		assert.Equal(t, int64(-200), response.Data.Occurrences[0].RunningTotalPence)
+		assert.Equal(t, int64(1), response.Data.Occurrences[2].RecurringID)
+		assert.Equal(t, int64(2), response.Data.Occurrences[3].RecurringID)
+		assert.Equal(t, int64(-200-4000-5000), response.Data.Occurrences[5].RunningTotalPence)
+	}
+	assert.Equal(t, int64(-9200), response.Data.TotalPence)
+	assert.Equal(t, "-92.00", response.Data.Total)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpcomingOccurrencesStopsWhenDueDateNeverAdvances(t *testing.T) {
+	from := time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC)
+	rules := []repo.Recurring{
+		{ID: 1, AmountPence: -100, Frequency: "monthly", IntervalN: 0, NextDueDate: from.AddDate(0, 0, 2)},
+		{ID: 2, AmountPence: -100, Frequency: "weekly", IntervalN: -1, NextDueDate: from.AddDate(0, 0, 3)},
+		{ID: 3, AmountPence: -100, Frequency: "fortnightly", IntervalN: 1, NextDueDate: from.AddDate(0, 0, 4)},
+	}
+
+	occurrences, total := upcomingOccurrences(rules, from, from.AddDate(0, 0, 30))
+
+	// Each rule is due once, as the scheduler would create it before stalling
+	if assert.Len(t, occurrences, 3) {
+		for i, o := range occurrences {
+			assert.Equal(t, rules[i].ID, o.RecurringID)
+			assert.Equal(t, model.FormatDate(rules[i].NextDueDate), o.DueDate)
+		}
+	}
+	assert.Equal(t, int64(-300), total)
+}
+
+func TestGetUpcomingRecurringInvalidDays(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, query := range []string{"days=0", "days=367", "days=abc", "from=01-01-2031"} {
+		mockRepo := new(MockRepository)
+		handler := NewHandler(mockRepo, zap.NewNop())
+		req, _ := http.NewRequest("GET", "/api/v1/recurring/upcoming?"+query, nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+
+		handler.GetUpcomingRecurring(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		mockRepo.AssertNotCalled(t, "ListActiveRecurring", mock.Anything, mock.Anything)
+	}
+}
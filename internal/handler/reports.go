@@ -1,7 +1,15 @@
 package handler
 
 import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -10,15 +18,21 @@ import (
 	"github.com/piotrzalecki/budget-api/pkg/model"
 )
 
+// defaultCurrency is reported when the default_currency setting is absent
+const defaultCurrency = "GBP"
+
 // GetMonthlyReport handles GET /api/v1/reports/monthly
 // @Summary Get monthly report
 // @Description Get a detailed monthly report with totals and breakdown by tags
 // @Tags reports
 // @Accept json
-// @Produce json
+// @Produce json,text/csv
 // @Param ym query string false "Year-month in YYYY-MM format (defaults to current month)"
+// @Param tag_id query int false "Only count transactions carrying this tag; by_tag then lists every tag those transactions carry"
+// @Param direction query string false "Only count income (in) or spending (out) (default all)" Enums(in, out, all)
+// @Param format query string false "Set to csv for a CSV with one row per tag and a totals row, told apart by the row column (Accept: text/csv works too)"
 // @Success 200 {object} map[string]interface{} "Monthly report data"
-// @Failure 400 {object} map[string]interface{} "Invalid year-month format"
+// @Failure 400 {object} map[string]interface{} "Invalid year-month format, tag ID or direction"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security ApiKeyAuth
 // @Router /reports/monthly [get]
@@ -27,13 +41,12 @@ func (h *Handler) GetMonthlyReport(c *gin.Context) {
 	ym := c.Query("ym")
 	if ym == "" {
 		// Default to current month if not provided
-		now := time.Now()
+		now := h.clock.Now()
 		ym = now.Format("2006-01")
 	}
 
-	// Parse the year-month parameter
-	yearMonth, err := time.Parse("2006-01", ym)
-	if err != nil {
+	// Validate the year-month parameter
+	if _, err := time.Parse("2006-01", ym); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "invalid year-month format. Use YYYY-MM (e.g., 2025-06)",
 			"data":  nil,
@@ -41,16 +54,41 @@ func (h *Handler) GetMonthlyReport(c *gin.Context) {
 		return
 	}
 
+	direction, ok := parseDirection(c)
+	if !ok {
+		return
+	}
+
+	// Optionally restrict the report to one tag
+	var tagID sql.NullInt64
+	if tagIDStr := c.Query("tag_id"); tagIDStr != "" {
+		id, err := strconv.ParseInt(tagIDStr, 10, 64)
+		if err != nil || id < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid tag_id",
+				"data":  nil,
+			})
+			return
+		}
+		tagID = sql.NullInt64{Int64: id, Valid: true}
+	}
+
 	// TODO: Get user ID from context when authentication is implemented
 	// For now, use a default user ID of 1
 	userID := int64(1)
 
+	// Reports aggregate over many rows, so bound them by the query timeout
+	ctx, cancel := h.queryContext(c)
+	defer cancel()
+
 	// Get monthly totals
 	totalsParams := repo.GetMonthlyTotalsParams{
-		UserID: userID,
-		TDate:  yearMonth,
+		UserID:    userID,
+		Ym:        ym,
+		Direction: direction,
+		TagID:     tagID,
 	}
-	totals, err := h.repo.GetMonthlyTotals(c.Request.Context(), totalsParams)
+	totals, err := h.repo.GetMonthlyTotals(ctx, totalsParams)
 	if err != nil {
 		h.logger.Error("failed to fetch monthly totals", zap.Error(err), zap.String("ym", ym))
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -62,10 +100,12 @@ func (h *Handler) GetMonthlyReport(c *gin.Context) {
 
 	// Get monthly report by tag
 	reportParams := repo.GetMonthlyReportParams{
-		UserID: userID,
-		TDate:  yearMonth,
+		UserID:    userID,
+		Ym:        ym,
+		Direction: direction,
+		TagID:     tagID,
 	}
-	reportRows, err := h.repo.GetMonthlyReport(c.Request.Context(), reportParams)
+	reportRows, err := h.repo.GetMonthlyReport(ctx, reportParams)
 	if err != nil {
 		h.logger.Error("failed to fetch monthly report", zap.Error(err), zap.String("ym", ym))
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -75,78 +115,240 @@ func (h *Handler) GetMonthlyReport(c *gin.Context) {
 		return
 	}
 
-	// Build response
+	currency, err := h.reportCurrency(ctx)
+	if err != nil {
+		h.logger.Error("failed to fetch currency setting", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch currency setting",
+			"data":  nil,
+		})
+		return
+	}
+
+	response := buildMonthlyReport(currency, totals, reportRows)
+
+	if wantsCSV(c) {
+		h.writeMonthlyReportCSV(c, ym, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// untaggedReportName is the by_tag key of untagged transactions. Tags are
+// keyed by their current name, so no tag may be named this (see
+// reservedTagName).
+const untaggedReportName = "Untagged"
+
+// buildMonthlyReport assembles a monthly report from the month's totals and
+// its per-tag rows. Untagged transactions are reported under "Untagged".
+func buildMonthlyReport(currency string, totals repo.GetMonthlyTotalsRow, rows []repo.GetMonthlyReportRow) model.MonthlyReportResponse {
 	byTag := make(map[string]model.TagReportEntry)
-	for _, row := range reportRows {
-		tagName := "Untagged"
+	for _, row := range rows {
+		tagName := untaggedReportName
 		if row.TagName.Valid {
 			tagName = row.TagName.String
 		}
+		byTag[tagName] = tagReportEntry(nullPence(row.TotalInPence), nullPence(row.TotalOutPence))
+	}
 
-		// Convert pence to currency strings
-		totalIn := "0.00"
-		if row.TotalInPence.Valid {
-			totalIn = model.PenceToCurrency(int64(row.TotalInPence.Float64))
-		}
+	totalInPence := nullPence(totals.TotalInPence)
+	totalOutPence := nullPence(totals.TotalOutPence)
+	return model.MonthlyReportResponse{
+		Currency:      currency,
+		TotalIn:       model.PenceToCurrency(totalInPence),
+		TotalOut:      model.PenceToCurrency(totalOutPence),
+		TotalInPence:  totalInPence,
+		TotalOutPence: totalOutPence,
+		ByTag:         byTag,
+	}
+}
 
-		totalOut := "0.00"
-		if row.TotalOutPence.Valid {
-			totalOut = model.PenceToCurrency(int64(row.TotalOutPence.Float64))
-		}
+// tagReportEntry builds a report entry from pence totals
+func tagReportEntry(totalInPence, totalOutPence int64) model.TagReportEntry {
+	return model.TagReportEntry{
+		TotalIn:       model.PenceToCurrency(totalInPence),
+		TotalOut:      model.PenceToCurrency(totalOutPence),
+		TotalInPence:  totalInPence,
+		TotalOutPence: totalOutPence,
+	}
+}
 
-		byTag[tagName] = model.TagReportEntry{
-			TotalIn:  totalIn,
-			TotalOut: totalOut,
-		}
+// wantsCSV reports whether the client asked for CSV, with ?format=csv or an
+// Accept header preferring text/csv
+func wantsCSV(c *gin.Context) bool {
+	if c.Query("format") == "csv" {
+		return true
 	}
+	return c.NegotiateFormat(gin.MIMEJSON, "text/csv") == "text/csv"
+}
 
-	// Convert totals to currency strings
-	totalIn := "0.00"
-	if totals.TotalInPence.Valid {
-		totalIn = model.PenceToCurrency(int64(totals.TotalInPence.Float64))
+// writeMonthlyReportCSV writes the monthly report as CSV, one row per tag in
+// name order followed by a totals row. The first column says which kind of
+// row it is, so a tag can't be mistaken for the totals.
+func (h *Handler) writeMonthlyReportCSV(c *gin.Context, ym string, report model.MonthlyReportResponse) {
+	tagNames := make([]string, 0, len(report.ByTag))
+	for name := range report.ByTag {
+		tagNames = append(tagNames, name)
 	}
+	sort.Strings(tagNames)
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="report-`+ym+`.csv"`)
+	c.Status(http.StatusOK)
 
-	totalOut := "0.00"
-	if totals.TotalOutPence.Valid {
-		totalOut = model.PenceToCurrency(int64(totals.TotalOutPence.Float64))
+	w := csv.NewWriter(c.Writer)
+	w.Write([]string{"row", "tag", "total_in", "total_out", "total_in_pence", "total_out_pence", "currency"})
+	for _, name := range tagNames {
+		entry := report.ByTag[name]
+		w.Write([]string{
+			"tag",
+			csvText(name),
+			entry.TotalIn,
+			entry.TotalOut,
+			strconv.FormatInt(entry.TotalInPence, 10),
+			strconv.FormatInt(entry.TotalOutPence, 10),
+			report.Currency,
+		})
 	}
+	w.Write([]string{
+		"total",
+		"",
+		report.TotalIn,
+		report.TotalOut,
+		strconv.FormatInt(report.TotalInPence, 10),
+		strconv.FormatInt(report.TotalOutPence, 10),
+		report.Currency,
+	})
+	w.Flush()
+	if err := w.Error(); err != nil {
+		h.logger.Error("failed to write CSV report", zap.Error(err), zap.String("ym", ym))
+	}
+}
 
-	_ = model.MonthlyReportResponse{
-		TotalIn:  totalIn,
-		TotalOut: totalOut,
-		ByTag:    byTag,
+// csvText makes a free-text CSV cell safe to open in a spreadsheet: text
+// starting with a formula character is prefixed with ' so it isn't evaluated
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
 	}
+	return s
+}
 
-	response := model.MonthlyReportResponse{
-		TotalIn:  "100.00",
-		TotalOut: "200.00",
-		ByTag:    map[string]model.TagReportEntry{
-			"family": {
-				TotalIn:  "50.00",
-				TotalOut: "100.00",
-			},
-			"loans": {
-				TotalIn:  "50.00",
-				TotalOut: "100.00",
-			},
-		},
+// GetMonthlyComparison handles GET /api/v1/reports/compare
+// @Summary Compare a month with the previous month
+// @Description Get a month's report alongside the previous month's, with the change in totals overall and per tag (this month minus last month). January is compared with the previous December.
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param ym query string false "Year-month in YYYY-MM format (defaults to current month)"
+// @Success 200 {object} model.MonthlyComparisonResponse "Month-over-month comparison"
+// @Failure 400 {object} map[string]interface{} "Invalid year-month format"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /reports/compare [get]
+func (h *Handler) GetMonthlyComparison(c *gin.Context) {
+	ym := c.DefaultQuery("ym", h.clock.Now().Format("2006-01"))
+	month, err := time.Parse("2006-01", ym)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid year-month format. Use YYYY-MM (e.g., 2025-06)",
+			"data":  nil,
+		})
+		return
+	}
+	// month is the 1st, so stepping back one month never skips February
+	previousYM := month.AddDate(0, -1, 0).Format("2006-01")
+
+	// TODO: Get user ID from context when authentication is implemented
+	// For now, use a default user ID of 1
+	userID := int64(1)
+
+	// Reports aggregate over many rows, so bound them by the query timeout
+	ctx, cancel := h.queryContext(c)
+	defer cancel()
+
+	currency, err := h.reportCurrency(ctx)
+	if err != nil {
+		h.logger.Error("failed to fetch currency setting", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch currency setting",
+			"data":  nil,
+		})
+		return
+	}
+
+	current, err := h.loadMonthlyReport(ctx, userID, ym, currency)
+	if err != nil {
+		h.logger.Error("failed to fetch monthly report", zap.Error(err), zap.String("ym", ym))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch monthly report",
+			"data":  nil,
+		})
+		return
+	}
+	previous, err := h.loadMonthlyReport(ctx, userID, previousYM, currency)
+	if err != nil {
+		h.logger.Error("failed to fetch monthly report", zap.Error(err), zap.String("ym", previousYM))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch monthly report",
+			"data":  nil,
+		})
+		return
+	}
+
+	// A tag missing from one month counts as zero in that month
+	deltaByTag := make(map[string]model.TagReportEntry)
+	for name, entry := range current.ByTag {
+		before := previous.ByTag[name]
+		deltaByTag[name] = tagReportEntry(entry.TotalInPence-before.TotalInPence, entry.TotalOutPence-before.TotalOutPence)
+	}
+	for name, before := range previous.ByTag {
+		if _, ok := current.ByTag[name]; !ok {
+			deltaByTag[name] = tagReportEntry(-before.TotalInPence, -before.TotalOutPence)
+		}
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"data":  response,
+		"data": model.MonthlyComparisonResponse{
+			Currency:          currency,
+			YearMonth:         ym,
+			PreviousYearMonth: previousYM,
+			Current:           current,
+			Previous:          previous,
+			Delta:             tagReportEntry(current.TotalInPence-previous.TotalInPence, current.TotalOutPence-previous.TotalOutPence),
+			DeltaByTag:        deltaByTag,
+		},
 		"error": nil,
 	})
 }
 
+// loadMonthlyReport fetches and assembles the report for one month
+func (h *Handler) loadMonthlyReport(ctx context.Context, userID int64, ym, currency string) (model.MonthlyReportResponse, error) {
+	totals, err := h.repo.GetMonthlyTotals(ctx, repo.GetMonthlyTotalsParams{UserID: userID, Ym: ym})
+	if err != nil {
+		return model.MonthlyReportResponse{}, err
+	}
+	rows, err := h.repo.GetMonthlyReport(ctx, repo.GetMonthlyReportParams{UserID: userID, Ym: ym})
+	if err != nil {
+		return model.MonthlyReportResponse{}, err
+	}
+	return buildMonthlyReport(currency, totals, rows), nil
+}
+
 // GetMonthlyTotals handles GET /api/v1/reports/monthly/totals
 // @Summary Get monthly totals
-// @Description Get monthly income/expense totals and transaction count
+// @Description Get monthly income/expense totals, transaction count, and the smallest, largest and average expense (0 when the month has no expenses)
 // @Tags reports
 // @Accept json
 // @Produce json
 // @Param ym query string false "Year-month in YYYY-MM format (defaults to current month)"
+// @Param direction query string false "Only count income (in) or spending (out) (default all)" Enums(in, out, all)
 // @Success 200 {object} map[string]interface{} "Monthly totals data"
-// @Failure 400 {object} map[string]interface{} "Invalid year-month format"
+// @Failure 400 {object} map[string]interface{} "Invalid year-month format or direction"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security ApiKeyAuth
 // @Router /reports/monthly/totals [get]
@@ -155,13 +357,12 @@ func (h *Handler) GetMonthlyTotals(c *gin.Context) {
 	ym := c.Query("ym")
 	if ym == "" {
 		// Default to current month if not provided
-		now := time.Now()
+		now := h.clock.Now()
 		ym = now.Format("2006-01")
 	}
 
-	// Parse the year-month parameter
-	yearMonth, err := time.Parse("2006-01", ym)
-	if err != nil {
+	// Validate the year-month parameter
+	if _, err := time.Parse("2006-01", ym); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "invalid year-month format. Use YYYY-MM (e.g., 2025-06)",
 			"data":  nil,
@@ -169,16 +370,533 @@ func (h *Handler) GetMonthlyTotals(c *gin.Context) {
 		return
 	}
 
+	direction, ok := parseDirection(c)
+	if !ok {
+		return
+	}
+
 	// TODO: Get user ID from context when authentication is implemented
 	// For now, use a default user ID of 1
 	userID := int64(1)
 
+	// Reports aggregate over many rows, so bound them by the query timeout
+	ctx, cancel := h.queryContext(c)
+	defer cancel()
+
 	// Get monthly totals
 	params := repo.GetMonthlyTotalsParams{
+		UserID:    userID,
+		Ym:        ym,
+		Direction: direction,
+	}
+	totals, err := h.repo.GetMonthlyTotals(ctx, params)
+	if err != nil {
+		h.logger.Error("failed to fetch monthly totals", zap.Error(err), zap.String("ym", ym))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch monthly totals",
+			"data":  nil,
+		})
+		return
+	}
+
+	currency, err := h.reportCurrency(ctx)
+	if err != nil {
+		h.logger.Error("failed to fetch currency setting", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch currency setting",
+			"data":  nil,
+		})
+		return
+	}
+
+	totalInPence := nullPence(totals.TotalInPence)
+	totalOutPence := nullPence(totals.TotalOutPence)
+	minExpensePence := nullPence(totals.MinExpensePence)
+	maxExpensePence := nullPence(totals.MaxExpensePence)
+	avgExpensePence := nullPence(totals.AvgExpensePence)
+	response := gin.H{
+		"currency":          currency,
+		"total_in":          model.PenceToCurrency(totalInPence),
+		"total_out":         model.PenceToCurrency(totalOutPence),
+		"total_in_pence":    totalInPence,
+		"total_out_pence":   totalOutPence,
+		"transaction_count": totals.TransactionCount,
+		"min_expense":       model.PenceToCurrency(minExpensePence),
+		"max_expense":       model.PenceToCurrency(maxExpensePence),
+		"avg_expense":       model.PenceToCurrency(avgExpensePence),
+		"min_expense_pence": minExpensePence,
+		"max_expense_pence": maxExpensePence,
+		"avg_expense_pence": avgExpensePence,
+		"year_month":        ym,
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// GetTopTags handles GET /api/v1/reports/top-tags
+// @Summary Get top spending tags
+// @Description Get the tags with the highest outgoing spend in a month, highest first
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param ym query string false "Year-month in YYYY-MM format (defaults to current month)"
+// @Param n query int false "Number of tags to return (1-50, default 5)"
+// @Success 200 {object} model.TopTagsResponse "Top spending tags"
+// @Failure 400 {object} map[string]interface{} "Invalid parameters"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /reports/top-tags [get]
+func (h *Handler) GetTopTags(c *gin.Context) {
+	ym := c.DefaultQuery("ym", h.clock.Now().Format("2006-01"))
+	if _, err := time.Parse("2006-01", ym); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid year-month format. Use YYYY-MM (e.g., 2025-06)",
+			"data":  nil,
+		})
+		return
+	}
+
+	n := 5
+	if nStr := c.Query("n"); nStr != "" {
+		parsed, err := strconv.Atoi(nStr)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "n must be a positive integer",
+				"data":  nil,
+			})
+			return
+		}
+		n = min(parsed, 50)
+	}
+
+	// TODO: Get user ID from context when authentication is implemented
+	// For now, use a default user ID of 1
+	userID := int64(1)
+
+	// Reports aggregate over many rows, so bound them by the query timeout
+	ctx, cancel := h.queryContext(c)
+	defer cancel()
+
+	rows, err := h.repo.GetTopSpendingTags(ctx, repo.GetTopSpendingTagsParams{
 		UserID: userID,
-		TDate:  yearMonth,
+		Ym:     ym,
+		Limit:  int64(n),
+	})
+	if err != nil {
+		h.logger.Error("failed to fetch top spending tags", zap.Error(err), zap.String("ym", ym))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch top spending tags",
+			"data":  nil,
+		})
+		return
 	}
-	totals, err := h.repo.GetMonthlyTotals(c.Request.Context(), params)
+
+	currency, err := h.reportCurrency(ctx)
+	if err != nil {
+		h.logger.Error("failed to fetch currency setting", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch currency setting",
+			"data":  nil,
+		})
+		return
+	}
+
+	tags := make([]model.TopTagEntry, len(rows))
+	for i, row := range rows {
+		spendPence := nullPence(row.SpendPence)
+		tags[i] = model.TopTagEntry{
+			TagID:            row.TagID,
+			TagName:          row.TagName,
+			Spend:            model.PenceToCurrency(spendPence),
+			SpendPence:       spendPence,
+			TransactionCount: row.TransactionCount,
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": model.TopTagsResponse{
+			Currency:  currency,
+			YearMonth: ym,
+			Tags:      tags,
+		},
+		"error": nil,
+	})
+}
+
+// GetBalanceReport handles GET /api/v1/reports/balance
+// @Summary Get balance over time
+// @Description Get the running balance at the end of each day with transactions between from and to (inclusive), starting from the balance of all earlier transactions
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param from query string false "Start date in YYYY-MM-DD format (defaults to the first day of the current month)"
+// @Param to query string false "End date in YYYY-MM-DD format (defaults to today)"
+// @Success 200 {object} model.BalanceReportResponse "Balance series"
+// @Failure 400 {object} map[string]interface{} "Invalid date range"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /reports/balance [get]
+func (h *Handler) GetBalanceReport(c *gin.Context) {
+	now := h.clock.Now()
+	fromStr := c.DefaultQuery("from", now.Format("2006-01")+"-01")
+	toStr := c.DefaultQuery("to", model.FormatDate(now))
+
+	from, err := model.ParseDate(fromStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid from date format. Use YYYY-MM-DD",
+			"data":  nil,
+		})
+		return
+	}
+	to, err := model.ParseDate(toStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid to date format. Use YYYY-MM-DD",
+			"data":  nil,
+		})
+		return
+	}
+	if to.Before(from) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "to date must not be before from date",
+			"data":  nil,
+		})
+		return
+	}
+
+	// TODO: Get user ID from context when authentication is implemented
+	// For now, use a default user ID of 1
+	userID := int64(1)
+
+	// Reports aggregate over many rows, so bound them by the query timeout
+	ctx, cancel := h.queryContext(c)
+	defer cancel()
+
+	opening, err := h.repo.GetBalanceBefore(ctx, repo.GetBalanceBeforeParams{
+		UserID: userID,
+		TDate:  from,
+	})
+	if err != nil {
+		h.logger.Error("failed to fetch opening balance", zap.Error(err), zap.String("from", fromStr))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch opening balance",
+			"data":  nil,
+		})
+		return
+	}
+
+	rows, err := h.repo.ListTransactionAmountsByDateRange(ctx, repo.ListTransactionAmountsByDateRangeParams{
+		UserID:  userID,
+		TDate:   from,
+		TDate_2: to,
+	})
+	if err != nil {
+		h.logger.Error("failed to fetch transactions for balance", zap.Error(err),
+			zap.String("from", fromStr), zap.String("to", toStr))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch transactions",
+			"data":  nil,
+		})
+		return
+	}
+
+	// Rows are in date order, so each day's change can be folded into the
+	// last point of the series
+	openingPence := nullPence(opening)
+	balance := openingPence
+	series := make([]model.BalancePoint, 0)
+	for _, row := range rows {
+		balance += row.AmountPence
+		date := model.FormatDate(row.TDate)
+		if n := len(series); n > 0 && series[n-1].Date == date {
+			series[n-1].ChangePence += row.AmountPence
+			series[n-1].BalancePence = balance
+			continue
+		}
+		series = append(series, model.BalancePoint{
+			Date:         date,
+			ChangePence:  row.AmountPence,
+			BalancePence: balance,
+		})
+	}
+	for i := range series {
+		series[i].Change = model.PenceToCurrency(series[i].ChangePence)
+		series[i].Balance = model.PenceToCurrency(series[i].BalancePence)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": model.BalanceReportResponse{
+			From:                model.FormatDate(from),
+			To:                  model.FormatDate(to),
+			OpeningBalance:      model.PenceToCurrency(openingPence),
+			OpeningBalancePence: openingPence,
+			ClosingBalance:      model.PenceToCurrency(balance),
+			ClosingBalancePence: balance,
+			Series:              series,
+		},
+		"error": nil,
+	})
+}
+
+// maxNetPeriods bounds the number of periods GetNetByPeriod reports for each
+// group_by: ten years of months, five of weeks or one of days
+var maxNetPeriods = map[string]int{
+	"month": 120,
+	"week":  260,
+	"day":   366,
+}
+
+// GetNetByPeriod handles GET /api/v1/reports/net, and GET
+// /api/v1/reports/net-by-month for clients written before group_by
+// @Summary Get net income per month, week or day
+// @Description Get income, spending and net (in minus out) for each period from from to to (inclusive). Periods without transactions are reported as zero. Weeks are ISO weeks (Monday to Sunday); the first and last may extend past the range, but only transactions inside it are counted.
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param from query string false "Start of the range as YYYY-MM (its first day) or YYYY-MM-DD (defaults to 11 months before to)"
+// @Param to query string false "End of the range as YYYY-MM (its last day) or YYYY-MM-DD (defaults to current month)"
+// @Param group_by query string false "Period to total by (default month)" Enums(month, week, day)
+// @Param direction query string false "Only count income (in) or spending (out) (default all)" Enums(in, out, all)
+// @Success 200 {object} model.NetByPeriodResponse "Net per period"
+// @Failure 400 {object} map[string]interface{} "Invalid range, group_by or direction"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /reports/net [get]
+// @Router /reports/net-by-month [get]
+func (h *Handler) GetNetByPeriod(c *gin.Context) {
+	groupBy := c.DefaultQuery("group_by", "month")
+	maxPeriods, ok := maxNetPeriods[groupBy]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "group_by must be month, week or day",
+			"data":  nil,
+		})
+		return
+	}
+
+	direction, ok := parseDirection(c)
+	if !ok {
+		return
+	}
+
+	toStr := c.DefaultQuery("to", h.clock.Now().Format("2006-01"))
+	to, err := parseRangeBound(toStr, true)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid to format. Use YYYY-MM or YYYY-MM-DD (e.g., 2025-06)",
+			"data":  nil,
+		})
+		return
+	}
+	fromStr := c.DefaultQuery("from", time.Date(to.Year(), to.Month()-11, 1, 0, 0, 0, 0, time.UTC).Format("2006-01"))
+	from, err := parseRangeBound(fromStr, false)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid from format. Use YYYY-MM or YYYY-MM-DD (e.g., 2025-06)",
+			"data":  nil,
+		})
+		return
+	}
+	if to.Before(from) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "to must not be before from",
+			"data":  nil,
+		})
+		return
+	}
+
+	// List the periods first, so the span check happens before querying
+	var starts []time.Time
+	for start := netPeriodStart(groupBy, from); !start.After(to); start = netPeriodNext(groupBy, start) {
+		if len(starts) == maxPeriods {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "range must cover at most " + strconv.Itoa(maxPeriods) + " " + groupBy + "s",
+				"data":  nil,
+			})
+			return
+		}
+		starts = append(starts, start)
+	}
+
+	// TODO: Get user ID from context when authentication is implemented
+	// For now, use a default user ID of 1
+	userID := int64(1)
+
+	// Reports aggregate over many rows, so bound them by the query timeout
+	ctx, cancel := h.queryContext(c)
+	defer cancel()
+
+	rows, err := h.repo.GetNetByPeriod(ctx, repo.GetNetByPeriodParams{
+		GroupBy:   groupBy,
+		UserID:    userID,
+		FromDate:  model.FormatDate(from),
+		ToDate:    model.FormatDate(to),
+		Direction: direction,
+	})
+	if err != nil {
+		h.logger.Error("failed to fetch net by period", zap.Error(err),
+			zap.String("from", fromStr), zap.String("to", toStr), zap.String("group_by", groupBy))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch net by period",
+			"data":  nil,
+		})
+		return
+	}
+
+	currency, err := h.reportCurrency(ctx)
+	if err != nil {
+		h.logger.Error("failed to fetch currency setting", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch currency setting",
+			"data":  nil,
+		})
+		return
+	}
+
+	// The query only returns periods with transactions, so walk the whole
+	// range and fill the gaps with zeros
+	byPeriod := make(map[string]repo.GetNetByPeriodRow, len(rows))
+	for _, row := range rows {
+		byPeriod[row.Period] = row
+	}
+	periods := make([]model.PeriodNet, 0, len(starts))
+	for _, start := range starts {
+		row := byPeriod[netPeriodKey(groupBy, start)]
+		totalInPence := nullPence(row.TotalInPence)
+		totalOutPence := nullPence(row.TotalOutPence)
+		netPence := totalInPence - totalOutPence
+		periods = append(periods, model.PeriodNet{
+			Period:        netPeriodLabel(groupBy, start),
+			Start:         model.FormatDate(start),
+			End:           model.FormatDate(netPeriodNext(groupBy, start).AddDate(0, 0, -1)),
+			TotalIn:       model.PenceToCurrency(totalInPence),
+			TotalOut:      model.PenceToCurrency(totalOutPence),
+			Net:           model.PenceToCurrency(netPence),
+			TotalInPence:  totalInPence,
+			TotalOutPence: totalOutPence,
+			NetPence:      netPence,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": model.NetByPeriodResponse{
+			Currency: currency,
+			GroupBy:  groupBy,
+			From:     fromStr,
+			To:       toStr,
+			Periods:  periods,
+		},
+		"error": nil,
+	})
+}
+
+// parseRangeBound parses a report range bound given as YYYY-MM-DD or YYYY-MM.
+// A month stands for its first day, or its last when end is true.
+func parseRangeBound(s string, end bool) (time.Time, error) {
+	if date, err := model.ParseDate(s); err == nil {
+		return date, nil
+	}
+	month, err := time.Parse("2006-01", s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if end {
+		return month.AddDate(0, 1, -1), nil
+	}
+	return month, nil
+}
+
+// netPeriodStart returns the first day of the month, ISO week or day that
+// contains date
+func netPeriodStart(groupBy string, date time.Time) time.Time {
+	switch groupBy {
+	case "day":
+		return date
+	case "week":
+		// ISO weeks start on Monday
+		return date.AddDate(0, 0, -((int(date.Weekday()) + 6) % 7))
+	}
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// netPeriodNext returns the first day of the period after the one starting
+// at start
+func netPeriodNext(groupBy string, start time.Time) time.Time {
+	switch groupBy {
+	case "day":
+		return start.AddDate(0, 0, 1)
+	case "week":
+		return start.AddDate(0, 0, 7)
+	}
+	return start.AddDate(0, 1, 0)
+}
+
+// netPeriodKey returns the period column GetNetByPeriod reports for the
+// period starting at start
+func netPeriodKey(groupBy string, start time.Time) string {
+	if groupBy == "month" {
+		return start.Format("2006-01")
+	}
+	return model.FormatDate(start)
+}
+
+// netPeriodLabel names the period starting at start: YYYY-MM, YYYY-Www or
+// YYYY-MM-DD
+func netPeriodLabel(groupBy string, start time.Time) string {
+	if groupBy == "week" {
+		year, week := start.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d", year, week)
+	}
+	return netPeriodKey(groupBy, start)
+}
+
+// GetForecast handles GET /api/v1/reports/forecast
+// @Summary Forecast the end-of-month totals
+// @Description Estimate a month's totals as the transactions booked so far plus the occurrences of active recurring rules still due before the month ends. Projection starts today for the current month and on the 1st for future months; past months are not projected.
+// @Tags reports
+// @Produce json
+// @Param ym query string false "Year-month in YYYY-MM format (defaults to current month)"
+// @Success 200 {object} model.ForecastResponse "Month forecast"
+// @Failure 400 {object} map[string]interface{} "Invalid year-month format"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /reports/forecast [get]
+func (h *Handler) GetForecast(c *gin.Context) {
+	today := h.clock.Now().UTC().Truncate(24 * time.Hour)
+	ym := c.DefaultQuery("ym", today.Format("2006-01"))
+
+	monthStart, err := time.Parse("2006-01", ym)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid year-month format. Use YYYY-MM (e.g., 2025-06)",
+			"data":  nil,
+		})
+		return
+	}
+	monthEnd := monthStart.AddDate(0, 1, -1)
+
+	// Rules due today may not have been generated yet, so today is projected
+	from := monthStart
+	if today.After(from) {
+		from = today
+	}
+
+	// TODO: Get user ID from context when authentication is implemented
+	// For now, use a default user ID of 1
+	userID := int64(1)
+
+	// Reports aggregate over many rows, so bound them by the query timeout
+	ctx, cancel := h.queryContext(c)
+	defer cancel()
+
+	totals, err := h.repo.GetMonthlyTotals(ctx, repo.GetMonthlyTotalsParams{
+		UserID: userID,
+		Ym:     ym,
+	})
 	if err != nil {
 		h.logger.Error("failed to fetch monthly totals", zap.Error(err), zap.String("ym", ym))
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -188,33 +906,107 @@ func (h *Handler) GetMonthlyTotals(c *gin.Context) {
 		return
 	}
 
-	// Convert to currency strings
-	totalIn := "0.00"
-	if totals.TotalInPence.Valid {
-		totalIn = model.PenceToCurrency(int64(totals.TotalInPence.Float64))
+	rules, err := h.repo.ListActiveRecurring(ctx, repo.ListActiveRecurringParams{
+		UserID: userID,
+		Limit:  -1,
+	})
+	if err != nil {
+		h.logger.Error("failed to fetch active recurring rules", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch active recurring rules",
+			"data":  nil,
+		})
+		return
 	}
 
-	totalOut := "0.00"
-	if totals.TotalOutPence.Valid {
-		totalOut = model.PenceToCurrency(int64(totals.TotalOutPence.Float64))
+	currency, err := h.reportCurrency(ctx)
+	if err != nil {
+		h.logger.Error("failed to fetch currency setting", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch currency setting",
+			"data":  nil,
+		})
+		return
 	}
 
-	_ = gin.H{
-		"total_in":         totalIn,
-		"total_out":        totalOut,
-		"transaction_count": totals.TransactionCount,
-		"year_month":       ym,
+	// A past month has nothing left to project
+	occurrences := []model.UpcomingOccurrence{}
+	if !from.After(monthEnd) {
+		occurrences, _ = upcomingOccurrences(rules, from, monthEnd)
+	}
+	var projectedInPence, projectedOutPence int64
+	for _, o := range occurrences {
+		if o.AmountPence > 0 {
+			projectedInPence += o.AmountPence
+		} else {
+			projectedOutPence -= o.AmountPence
+		}
 	}
 
-	response := gin.H{
-		"total_in":         "1000.00",
-		"total_out":        "1300.00",
-		"transaction_count": 10,
-		"year_month":       ym,
+	actualInPence := nullPence(totals.TotalInPence)
+	actualOutPence := nullPence(totals.TotalOutPence)
+	response := model.ForecastResponse{
+		Currency:      currency,
+		YearMonth:     ym,
+		ProjectedFrom: model.FormatDate(from),
+		Actual:        forecastTotals(actualInPence, actualOutPence),
+		Projected:     forecastTotals(projectedInPence, projectedOutPence),
+		Estimated:     forecastTotals(actualInPence+projectedInPence, actualOutPence+projectedOutPence),
+		Occurrences:   occurrences,
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"data":  response,
 		"error": nil,
 	})
-} 
\ No newline at end of file
+}
+
+// forecastTotals formats income and spending in pence with their net
+func forecastTotals(totalInPence, totalOutPence int64) model.ForecastTotals {
+	return model.ForecastTotals{
+		TotalIn:       model.PenceToCurrency(totalInPence),
+		TotalOut:      model.PenceToCurrency(totalOutPence),
+		Net:           model.PenceToCurrency(totalInPence - totalOutPence),
+		TotalInPence:  totalInPence,
+		TotalOutPence: totalOutPence,
+		NetPence:      totalInPence - totalOutPence,
+	}
+}
+
+// parseDirection reads the direction query parameter, which restricts a
+// report to income (in), spending (out) or both (all, the default). On an
+// invalid value it writes a 400 response and returns false.
+func parseDirection(c *gin.Context) (string, bool) {
+	direction := c.DefaultQuery("direction", "all")
+	switch direction {
+	case "in", "out", "all":
+		return direction, true
+	}
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error": "direction must be in, out or all",
+		"data":  nil,
+	})
+	return "", false
+}
+
+// reportCurrency returns the currency code from the default_currency setting,
+// falling back to defaultCurrency when it has not been configured
+func (h *Handler) reportCurrency(ctx context.Context) (string, error) {
+	setting, err := h.repo.GetSetting(ctx, "default_currency")
+	if errors.Is(err, sql.ErrNoRows) {
+		return defaultCurrency, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return setting.Value, nil
+}
+
+// nullPence converts a nullable SUM() of pence to an integer, treating NULL
+// (no matching rows) as zero
+func nullPence(v sql.NullFloat64) int64 {
+	if !v.Valid {
+		return 0
+	}
+	return int64(v.Float64)
+}
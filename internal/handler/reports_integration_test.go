@@ -0,0 +1,880 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+	"github.com/piotrzalecki/budget-api/pkg/model"
+)
+
+func TestGetMonthlyReportIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	// Use a month the dev seed data does not touch
+	tag, err := repository.CreateTag(ctx, "report-test")
+	require.NoError(t, err)
+	for _, amount := range []int64{250000, -1999, -3001} {
+		tx, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+			UserID:      1,
+			AmountPence: amount,
+			TDate:       time.Date(2031, 2, 10, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+		if amount < 0 {
+			require.NoError(t, repository.CreateTransactionTag(ctx, repo.CreateTransactionTagParams{
+				TransactionID: tx.ID,
+				TagID:         tag.ID,
+			}))
+		}
+	}
+	// Outside the requested month
+	_, err = repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+		UserID:      1,
+		AmountPence: -700,
+		TDate:       time.Date(2031, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.GET("/reports/monthly", h.GetMonthlyReport)
+
+	req := httptest.NewRequest("GET", "/reports/monthly?ym=2031-02", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data model.MonthlyReportResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+	report := response.Data
+	assert.Equal(t, "GBP", report.Currency)
+	assert.Equal(t, int64(250000), report.TotalInPence)
+	assert.Equal(t, int64(5000), report.TotalOutPence)
+	assert.Equal(t, "2500.00", report.TotalIn)
+	assert.Equal(t, "50.00", report.TotalOut)
+
+	require.Contains(t, report.ByTag, "report-test")
+	assert.Equal(t, int64(5000), report.ByTag["report-test"].TotalOutPence)
+	assert.Equal(t, "50.00", report.ByTag["report-test"].TotalOut)
+	require.Contains(t, report.ByTag, "Untagged")
+	assert.Equal(t, int64(250000), report.ByTag["Untagged"].TotalInPence)
+}
+
+func TestGetMonthlyReportTagFilterIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	groceries, err := repository.CreateTag(ctx, "filter-groceries")
+	require.NoError(t, err)
+	household, err := repository.CreateTag(ctx, "filter-household")
+	require.NoError(t, err)
+
+	create := func(amount int64, tagIDs ...int64) {
+		tx, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+			UserID:      1,
+			AmountPence: amount,
+			TDate:       time.Date(2031, 4, 12, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+		for _, tagID := range tagIDs {
+			require.NoError(t, repository.CreateTransactionTag(ctx, repo.CreateTransactionTagParams{
+				TransactionID: tx.ID,
+				TagID:         tagID,
+			}))
+		}
+	}
+	create(-4000, groceries.ID)
+	create(-1500, groceries.ID, household.ID)
+	create(-9000, household.ID)
+	create(200000)
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.GET("/reports/monthly", h.GetMonthlyReport)
+
+	report := func(query string) (int, model.MonthlyReportResponse) {
+		req := httptest.NewRequest("GET", "/reports/monthly?ym=2031-04"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var response struct {
+			Data model.MonthlyReportResponse `json:"data"`
+		}
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w.Code, response.Data
+	}
+
+	code, all := report("")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int64(200000), all.TotalInPence)
+	assert.Equal(t, int64(14500), all.TotalOutPence)
+
+	t.Run("only transactions carrying the tag", func(t *testing.T) {
+		code, filtered := report("&tag_id=" + strconv.FormatInt(groceries.ID, 10))
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, int64(0), filtered.TotalInPence)
+		assert.Equal(t, int64(5500), filtered.TotalOutPence)
+		assert.NotEqual(t, all.TotalOutPence, filtered.TotalOutPence)
+
+		assert.Equal(t, int64(5500), filtered.ByTag["filter-groceries"].TotalOutPence)
+		// The shared transaction still shows under its other tag
+		assert.Equal(t, int64(1500), filtered.ByTag["filter-household"].TotalOutPence)
+		assert.NotContains(t, filtered.ByTag, "Untagged")
+	})
+
+	t.Run("unknown tag reports nothing", func(t *testing.T) {
+		code, filtered := report("&tag_id=999999")
+		require.Equal(t, http.StatusOK, code)
+		assert.Zero(t, filtered.TotalOutPence)
+		assert.Empty(t, filtered.ByTag)
+	})
+
+	t.Run("invalid tag_id", func(t *testing.T) {
+		code, _ := report("&tag_id=abc")
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+func TestReportDirectionIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	salary, err := repository.CreateTag(ctx, "direction-salary")
+	require.NoError(t, err)
+	food, err := repository.CreateTag(ctx, "direction-food")
+	require.NoError(t, err)
+	for _, txn := range []struct {
+		amount int64
+		tagID  int64
+	}{
+		{310000, salary.ID},
+		{-2500, food.ID},
+		{-4000, food.ID},
+	} {
+		tx, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+			UserID:      1,
+			AmountPence: txn.amount,
+			TDate:       time.Date(2031, 5, 9, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+		require.NoError(t, repository.CreateTransactionTag(ctx, repo.CreateTransactionTagParams{
+			TransactionID: tx.ID,
+			TagID:         txn.tagID,
+		}))
+	}
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.GET("/reports/monthly", h.GetMonthlyReport)
+	router.GET("/reports/monthly/totals", h.GetMonthlyTotals)
+	router.GET("/reports/net", h.GetNetByPeriod)
+
+	get := func(url string, data interface{}) int {
+		req := httptest.NewRequest("GET", url, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code == http.StatusOK {
+			response := struct {
+				Data interface{} `json:"data"`
+			}{Data: data}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w.Code
+	}
+
+	t.Run("monthly report of expenses only", func(t *testing.T) {
+		var report model.MonthlyReportResponse
+		require.Equal(t, http.StatusOK, get("/reports/monthly?ym=2031-05&direction=out", &report))
+		assert.Equal(t, int64(0), report.TotalInPence)
+		assert.Equal(t, int64(6500), report.TotalOutPence)
+		assert.NotContains(t, report.ByTag, "direction-salary")
+		assert.Equal(t, int64(6500), report.ByTag["direction-food"].TotalOutPence)
+	})
+
+	t.Run("monthly totals of expenses only", func(t *testing.T) {
+		var totals struct {
+			TotalInPence     int64 `json:"total_in_pence"`
+			TotalOutPence    int64 `json:"total_out_pence"`
+			TransactionCount int64 `json:"transaction_count"`
+		}
+		require.Equal(t, http.StatusOK, get("/reports/monthly/totals?ym=2031-05&direction=out", &totals))
+		assert.Equal(t, int64(0), totals.TotalInPence)
+		assert.Equal(t, int64(6500), totals.TotalOutPence)
+		assert.Equal(t, int64(2), totals.TransactionCount)
+	})
+
+	t.Run("net by month of income only", func(t *testing.T) {
+		var net model.NetByPeriodResponse
+		require.Equal(t, http.StatusOK, get("/reports/net?from=2031-05&to=2031-05&direction=in", &net))
+		require.Len(t, net.Periods, 1)
+		assert.Equal(t, int64(310000), net.Periods[0].TotalInPence)
+		assert.Equal(t, int64(0), net.Periods[0].TotalOutPence)
+	})
+
+	t.Run("all is the default", func(t *testing.T) {
+		var all, unfiltered model.MonthlyReportResponse
+		require.Equal(t, http.StatusOK, get("/reports/monthly?ym=2031-05&direction=all", &all))
+		require.Equal(t, http.StatusOK, get("/reports/monthly?ym=2031-05", &unfiltered))
+		assert.Equal(t, unfiltered, all)
+		assert.Equal(t, int64(310000), all.TotalInPence)
+	})
+
+	t.Run("invalid direction", func(t *testing.T) {
+		for _, url := range []string{
+			"/reports/monthly?direction=sideways",
+			"/reports/monthly/totals?direction=IN",
+			"/reports/net?direction=",
+		} {
+			assert.Equal(t, http.StatusBadRequest, get(url, nil), url)
+		}
+	})
+}
+
+func TestGetMonthlyTotalsExpenseStatsIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	// Income is left out of the expense statistics
+	for _, amount := range []int64{-1250, 500000, -400, -2001, -3000} {
+		_, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+			UserID:      1,
+			AmountPence: amount,
+			TDate:       time.Date(2031, 7, 14, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+	}
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.GET("/reports/monthly/totals", h.GetMonthlyTotals)
+
+	get := func(ym string) map[string]interface{} {
+		req := httptest.NewRequest("GET", "/reports/monthly/totals?ym="+ym, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data
+	}
+
+	data := get("2031-07")
+	assert.Equal(t, float64(400), data["min_expense_pence"])
+	assert.Equal(t, float64(3000), data["max_expense_pence"])
+	// (1250 + 400 + 2001 + 3000) / 4 = 1662.75
+	assert.Equal(t, float64(1663), data["avg_expense_pence"])
+	assert.Equal(t, "4.00", data["min_expense"])
+	assert.Equal(t, "30.00", data["max_expense"])
+	assert.Equal(t, "16.63", data["avg_expense"])
+
+	// A month without expenses reports zeros
+	empty := get("2031-08")
+	assert.Equal(t, float64(0), empty["min_expense_pence"])
+	assert.Equal(t, float64(0), empty["avg_expense_pence"])
+}
+
+func TestGetTopTagsIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	spend := func(tagID, amountPence int64, tDate time.Time) {
+		tx, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+			UserID:      1,
+			AmountPence: amountPence,
+			TDate:       tDate,
+		})
+		require.NoError(t, err)
+		require.NoError(t, repository.CreateTransactionTag(ctx, repo.CreateTransactionTagParams{
+			TransactionID: tx.ID,
+			TagID:         tagID,
+		}))
+	}
+
+	september := time.Date(2031, 9, 3, 0, 0, 0, 0, time.UTC)
+	small, err := repository.CreateTag(ctx, "top-small")
+	require.NoError(t, err)
+	medium, err := repository.CreateTag(ctx, "top-medium")
+	require.NoError(t, err)
+	large, err := repository.CreateTag(ctx, "top-large")
+	require.NoError(t, err)
+	spend(small.ID, -500, september)
+	spend(medium.ID, -1500, september)
+	spend(medium.ID, -1000, september)
+	spend(large.ID, -9000, september)
+	// Income and other months don't count
+	spend(small.ID, 100000, september)
+	spend(small.ID, -50000, time.Date(2031, 10, 1, 0, 0, 0, 0, time.UTC))
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.GET("/reports/top-tags", h.GetTopTags)
+
+	get := func(query string) (int, model.TopTagsResponse) {
+		req := httptest.NewRequest("GET", "/reports/top-tags?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var response struct {
+			Data model.TopTagsResponse `json:"data"`
+		}
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w.Code, response.Data
+	}
+
+	t.Run("orders by spend descending", func(t *testing.T) {
+		code, data := get("ym=2031-09")
+		require.Equal(t, http.StatusOK, code)
+		require.Len(t, data.Tags, 3)
+		assert.Equal(t, []string{"top-large", "top-medium", "top-small"},
+			[]string{data.Tags[0].TagName, data.Tags[1].TagName, data.Tags[2].TagName})
+		assert.Equal(t, int64(9000), data.Tags[0].SpendPence)
+		assert.Equal(t, "25.00", data.Tags[1].Spend)
+		assert.Equal(t, int64(2), data.Tags[1].TransactionCount)
+		assert.Equal(t, int64(500), data.Tags[2].SpendPence)
+	})
+
+	t.Run("limits to n", func(t *testing.T) {
+		code, data := get("ym=2031-09&n=2")
+		require.Equal(t, http.StatusOK, code)
+		require.Len(t, data.Tags, 2)
+		assert.Equal(t, large.ID, data.Tags[0].TagID)
+		assert.Equal(t, medium.ID, data.Tags[1].TagID)
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		code, _ := get("ym=2031-09&n=0")
+		assert.Equal(t, http.StatusBadRequest, code)
+		code, _ = get("ym=September")
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+func TestGetBalanceReportIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	create := func(amount int64, day int) repo.Transaction {
+		tx, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+			UserID:      1,
+			AmountPence: amount,
+			TDate:       time.Date(2031, 4, day, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+		return tx
+	}
+	create(10000, 1) // before the window, counted in the opening balance
+	create(250000, 5)
+	create(-1500, 5)
+	create(-4000, 12)
+	deleted := create(-99900, 12)
+	require.NoError(t, repository.SoftDeleteTransaction(ctx, deleted.ID))
+	create(-700, 20)
+	create(-5000, 30) // after the window
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.GET("/reports/balance", h.GetBalanceReport)
+
+	t.Run("running balance", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/reports/balance?from=2031-04-02&to=2031-04-25", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data model.BalanceReportResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		report := response.Data
+
+		// The dev seed may hold earlier transactions, so check relative to them
+		before, err := repository.GetBalanceBefore(ctx, repo.GetBalanceBeforeParams{
+			UserID: 1,
+			TDate:  time.Date(2031, 4, 2, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+		opening := nullPence(before)
+		assert.Equal(t, opening, report.OpeningBalancePence)
+
+		require.Len(t, report.Series, 3)
+		assert.Equal(t, "2031-04-05", report.Series[0].Date)
+		assert.Equal(t, int64(248500), report.Series[0].ChangePence)
+		assert.Equal(t, opening+248500, report.Series[0].BalancePence)
+		assert.Equal(t, "2031-04-12", report.Series[1].Date)
+		assert.Equal(t, int64(-4000), report.Series[1].ChangePence)
+		assert.Equal(t, opening+244500, report.Series[1].BalancePence)
+		assert.Equal(t, "2031-04-20", report.Series[2].Date)
+		assert.Equal(t, opening+243800, report.Series[2].BalancePence)
+		assert.Equal(t, opening+243800, report.ClosingBalancePence)
+		assert.Equal(t, model.PenceToCurrency(opening+243800), report.ClosingBalance)
+	})
+
+	t.Run("rejects an inverted range", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/reports/balance?from=2031-04-25&to=2031-04-02", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGetMonthlyComparisonAcrossYearBoundaryIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	food, err := repository.CreateTag(ctx, "compare-food")
+	require.NoError(t, err)
+	travel, err := repository.CreateTag(ctx, "compare-travel")
+	require.NoError(t, err)
+
+	create := func(amount int64, date time.Time, tagID int64) {
+		tx, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+			UserID:      1,
+			AmountPence: amount,
+			TDate:       date,
+		})
+		require.NoError(t, err)
+		if tagID != 0 {
+			require.NoError(t, repository.CreateTransactionTag(ctx, repo.CreateTransactionTagParams{
+				TransactionID: tx.ID,
+				TagID:         tagID,
+			}))
+		}
+	}
+	december := time.Date(2031, 12, 15, 0, 0, 0, 0, time.UTC)
+	january := time.Date(2032, 1, 15, 0, 0, 0, 0, time.UTC)
+	create(-4000, december, food.ID)
+	create(-10000, december, travel.ID)
+	create(200000, december, 0)
+	create(-6500, january, food.ID)
+	create(210000, january, 0)
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.GET("/reports/compare", h.GetMonthlyComparison)
+
+	req := httptest.NewRequest("GET", "/reports/compare?ym=2032-01", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data model.MonthlyComparisonResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+	comparison := response.Data
+	assert.Equal(t, "2032-01", comparison.YearMonth)
+	assert.Equal(t, "2031-12", comparison.PreviousYearMonth)
+	assert.Equal(t, int64(14000), comparison.Previous.TotalOutPence)
+	assert.Equal(t, int64(6500), comparison.Current.TotalOutPence)
+
+	assert.Equal(t, int64(10000), comparison.Delta.TotalInPence)
+	assert.Equal(t, int64(-7500), comparison.Delta.TotalOutPence)
+	assert.Equal(t, "-75.00", comparison.Delta.TotalOut)
+
+	require.Contains(t, comparison.DeltaByTag, "compare-food")
+	assert.Equal(t, int64(2500), comparison.DeltaByTag["compare-food"].TotalOutPence)
+	// Only spent on in December, so the whole amount is a decrease
+	require.Contains(t, comparison.DeltaByTag, "compare-travel")
+	assert.Equal(t, int64(-10000), comparison.DeltaByTag["compare-travel"].TotalOutPence)
+	require.Contains(t, comparison.DeltaByTag, "Untagged")
+	assert.Equal(t, int64(10000), comparison.DeltaByTag["Untagged"].TotalInPence)
+
+	t.Run("rejects an invalid month", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/reports/compare?ym=2032-13", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGetNetByPeriodIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	create := func(amount int64, date time.Time) int64 {
+		tx, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+			UserID:      1,
+			AmountPence: amount,
+			TDate:       date,
+		})
+		require.NoError(t, err)
+		return tx.ID
+	}
+	// September: income and spending; October: nothing; November: spending
+	// only; December: income only
+	create(300000, time.Date(2031, 9, 1, 0, 0, 0, 0, time.UTC))
+	create(-120050, time.Date(2031, 9, 30, 0, 0, 0, 0, time.UTC))
+	create(-4500, time.Date(2031, 11, 10, 0, 0, 0, 0, time.UTC))
+	create(-500, time.Date(2031, 11, 11, 0, 0, 0, 0, time.UTC))
+	create(15000, time.Date(2031, 12, 24, 0, 0, 0, 0, time.UTC))
+	deleted := create(-99999, time.Date(2031, 12, 25, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, repository.SoftDeleteTransaction(ctx, deleted))
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.GET("/reports/net", h.GetNetByPeriod)
+	router.GET("/reports/net-by-month", h.GetNetByPeriod)
+
+	get := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/reports/net"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("?from=2031-09&to=2031-12")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data model.NetByPeriodResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+	report := response.Data
+	assert.Equal(t, "2031-09", report.From)
+	assert.Equal(t, "2031-12", report.To)
+	require.Len(t, report.Periods, 4)
+
+	months := make([]string, len(report.Periods))
+	nets := make([]int64, len(report.Periods))
+	for i, month := range report.Periods {
+		months[i] = month.Period
+		nets[i] = month.NetPence
+	}
+	assert.Equal(t, []string{"2031-09", "2031-10", "2031-11", "2031-12"}, months)
+	assert.Equal(t, []int64{179950, 0, -5000, 15000}, nets)
+
+	assert.Equal(t, "1799.50", report.Periods[0].Net)
+	assert.Equal(t, int64(300000), report.Periods[0].TotalInPence)
+	assert.Equal(t, int64(120050), report.Periods[0].TotalOutPence)
+	assert.Equal(t, "0.00", report.Periods[1].Net)
+	assert.Equal(t, "-50.00", report.Periods[2].Net)
+
+	t.Run("net-by-month is still served", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/reports/net-by-month?from=2031-09&to=2031-12", nil)
+		alias := httptest.NewRecorder()
+		router.ServeHTTP(alias, req)
+
+		require.Equal(t, http.StatusOK, alias.Code)
+		assert.JSONEq(t, w.Body.String(), alias.Body.String())
+	})
+
+	t.Run("rejects a reversed range", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, get("?from=2031-12&to=2031-09").Code)
+	})
+
+	t.Run("rejects an invalid month", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, get("?from=2031-9&to=2031-12").Code)
+	})
+
+	t.Run("rejects an overly long range", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, get("?from=2001-01&to=2031-12").Code)
+		assert.Equal(t, http.StatusBadRequest, get("?from=2030-01-01&to=2031-12-31&group_by=day").Code)
+	})
+
+	t.Run("rejects an unknown group_by", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, get("?from=2031-09&to=2031-12&group_by=quarter").Code)
+	})
+
+	t.Run("groups by ISO week", func(t *testing.T) {
+		// 2031-09-03 is a Wednesday in week 36; 2031-09-30 a Tuesday in week 40
+		w := get("?from=2031-09-03&to=2031-09-30&group_by=week")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data model.NetByPeriodResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		report := response.Data
+		assert.Equal(t, "week", report.GroupBy)
+
+		type bucket struct{ period, start, end string }
+		buckets := make([]bucket, len(report.Periods))
+		nets := make([]int64, len(report.Periods))
+		for i, period := range report.Periods {
+			buckets[i] = bucket{period.Period, period.Start, period.End}
+			nets[i] = period.NetPence
+		}
+		assert.Equal(t, []bucket{
+			{"2031-W36", "2031-09-01", "2031-09-07"},
+			{"2031-W37", "2031-09-08", "2031-09-14"},
+			{"2031-W38", "2031-09-15", "2031-09-21"},
+			{"2031-W39", "2031-09-22", "2031-09-28"},
+			{"2031-W40", "2031-09-29", "2031-10-05"},
+		}, buckets)
+		// The Monday 2031-09-01 income is in week 36 but before from
+		assert.Equal(t, []int64{0, 0, 0, 0, -120050}, nets)
+	})
+
+	t.Run("weeks cross the year boundary", func(t *testing.T) {
+		// 2031-12-29 to 2032-01-04 is ISO week 1 of 2032
+		w := get("?from=2031-12-22&to=2031-12-31&group_by=week")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data model.NetByPeriodResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data.Periods, 2)
+		assert.Equal(t, "2031-W52", response.Data.Periods[0].Period)
+		assert.Equal(t, int64(15000), response.Data.Periods[0].NetPence)
+		assert.Equal(t, "2032-W01", response.Data.Periods[1].Period)
+		assert.Equal(t, "2031-12-29", response.Data.Periods[1].Start)
+	})
+
+	t.Run("groups by day", func(t *testing.T) {
+		w := get("?from=2031-11-10&to=2031-11-12&group_by=day")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data model.NetByPeriodResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data.Periods, 3)
+		assert.Equal(t, "2031-11-10", response.Data.Periods[0].Period)
+		assert.Equal(t, int64(-4500), response.Data.Periods[0].NetPence)
+		assert.Equal(t, int64(-500), response.Data.Periods[1].NetPence)
+		assert.Equal(t, int64(0), response.Data.Periods[2].NetPence)
+	})
+}
+
+func TestGetForecastIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	// Deactivate the seeded rules so only the ones below are projected
+	_, err := db.Exec("UPDATE recurring SET active = 0")
+	require.NoError(t, err)
+
+	for _, tx := range []struct {
+		amount int64
+		day    int
+	}{
+		{300000, 1},
+		{-2000, 3},
+		{-4500, 14},
+	} {
+		_, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+			UserID:      1,
+			AmountPence: tx.amount,
+			TDate:       time.Date(2031, 2, tx.day, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+	}
+
+	rule := func(amount int64, frequency string, nextDue time.Time) {
+		_, err := repository.CreateRecurring(ctx, repo.CreateRecurringParams{
+			UserID:       1,
+			AmountPence:  amount,
+			Frequency:    frequency,
+			IntervalN:    1,
+			FirstDueDate: nextDue,
+			NextDueDate:  nextDue,
+			Active:       true,
+		})
+		require.NoError(t, err)
+	}
+	// Rent is due later this month, the gym weekly on the 15th and 22nd, and
+	// the insurance not until next month
+	rule(-85000, "monthly", time.Date(2031, 2, 20, 0, 0, 0, 0, time.UTC))
+	rule(-1000, "weekly", time.Date(2031, 2, 15, 0, 0, 0, 0, time.UTC))
+	rule(-6000, "monthly", time.Date(2031, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	h := NewHandler(repository, zap.NewNop())
+	h.clock = fixedClock(time.Date(2031, 2, 15, 9, 0, 0, 0, time.UTC))
+	router := gin.New()
+	router.GET("/reports/forecast", h.GetForecast)
+
+	get := func(query string) (int, model.ForecastResponse) {
+		req := httptest.NewRequest("GET", "/reports/forecast"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var response struct {
+			Data model.ForecastResponse `json:"data"`
+		}
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w.Code, response.Data
+	}
+
+	t.Run("current month", func(t *testing.T) {
+		code, forecast := get("")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "2031-02", forecast.YearMonth)
+		assert.Equal(t, "2031-02-15", forecast.ProjectedFrom)
+
+		assert.Equal(t, int64(300000), forecast.Actual.TotalInPence)
+		assert.Equal(t, int64(6500), forecast.Actual.TotalOutPence)
+
+		require.Len(t, forecast.Occurrences, 3)
+		assert.Equal(t, "2031-02-15", forecast.Occurrences[0].DueDate)
+		assert.Equal(t, "2031-02-20", forecast.Occurrences[1].DueDate)
+		assert.Equal(t, "2031-02-22", forecast.Occurrences[2].DueDate)
+		assert.Equal(t, int64(0), forecast.Projected.TotalInPence)
+		assert.Equal(t, int64(87000), forecast.Projected.TotalOutPence)
+
+		assert.Equal(t, int64(300000), forecast.Estimated.TotalInPence)
+		assert.Equal(t, int64(93500), forecast.Estimated.TotalOutPence)
+		assert.Equal(t, int64(206500), forecast.Estimated.NetPence)
+		assert.Equal(t, "935.00", forecast.Estimated.TotalOut)
+	})
+
+	t.Run("past month has no projection", func(t *testing.T) {
+		code, forecast := get("?ym=2031-01")
+		require.Equal(t, http.StatusOK, code)
+		assert.Empty(t, forecast.Occurrences)
+		assert.Equal(t, forecast.Actual, forecast.Estimated)
+	})
+
+	t.Run("future month is projected from the 1st", func(t *testing.T) {
+		code, forecast := get("?ym=2031-03")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "2031-03-01", forecast.ProjectedFrom)
+		assert.Equal(t, int64(0), forecast.Actual.TotalOutPence)
+		// Rent and insurance once each, the gym on the 1st, 8th, 15th, 22nd and 29th
+		assert.Equal(t, int64(85000+6000+5*1000), forecast.Projected.TotalOutPence)
+	})
+
+	t.Run("invalid year-month", func(t *testing.T) {
+		code, _ := get("?ym=2031-13")
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+func TestMonthlyReportAfterTagRenameIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	tag, err := repository.CreateTag(ctx, "rename-before")
+	require.NoError(t, err)
+	tagged, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+		UserID:      1,
+		AmountPence: -2500,
+		TDate:       time.Date(2031, 8, 3, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.NoError(t, repository.CreateTransactionTag(ctx, repo.CreateTransactionTagParams{
+		TransactionID: tagged.ID,
+		TagID:         tag.ID,
+	}))
+	_, err = repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+		UserID:      1,
+		AmountPence: -700,
+		TDate:       time.Date(2031, 8, 4, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.PATCH("/tags/:id", ValidateRequest[model.UpdateTagRequest](), h.UpdateTag)
+	router.GET("/reports/monthly", h.GetMonthlyReport)
+
+	rename := func(name string) int {
+		body, _ := json.Marshal(map[string]string{"name": name})
+		req := httptest.NewRequest("PATCH", "/tags/"+strconv.FormatInt(tag.ID, 10), bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	byTag := func() map[string]model.TagReportEntry {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/reports/monthly?ym=2031-08", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Data model.MonthlyReportResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data.ByTag
+	}
+
+	require.Contains(t, byTag(), "rename-before")
+
+	require.Equal(t, http.StatusNoContent, rename("rename-after"))
+	report := byTag()
+	assert.NotContains(t, report, "rename-before")
+	require.Contains(t, report, "rename-after")
+	assert.Equal(t, int64(2500), report["rename-after"].TotalOutPence)
+	require.Contains(t, report, "Untagged")
+	assert.Equal(t, int64(700), report["Untagged"].TotalOutPence)
+
+	t.Run("the untagged bucket's name is reserved", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, rename("untagged"))
+		report := byTag()
+		assert.Equal(t, int64(2500), report["rename-after"].TotalOutPence)
+		assert.Equal(t, int64(700), report["Untagged"].TotalOutPence)
+	})
+}
@@ -1,8 +1,9 @@
 package handler
 
 import (
+	"context"
+	"errors"
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -18,6 +19,7 @@ import (
 // @Accept json
 // @Produce json
 // @Success 200 {object} map[string]interface{} "Scheduler execution result"
+// @Failure 409 {object} map[string]interface{} "Scheduler already running"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security ApiKeyAuth
 // @Router /admin/run-scheduler [post]
@@ -32,9 +34,15 @@ func (h *Handler) RunScheduler(c *gin.Context) {
 		return
 	}
 
-	// Run the scheduler with today's date
-	today := time.Now().UTC().Truncate(24 * time.Hour)
-	processed, err := scheduler.RunScheduler(c.Request.Context(), db, today, h.logger)
+	// Run the scheduler; each user's rules are due by their local date
+	summary, err := scheduler.Run(c.Request.Context(), db, h.clock.Now(), h.logger)
+	if errors.Is(err, scheduler.ErrAlreadyRunning) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "scheduler already running",
+			"data":  nil,
+		})
+		return
+	}
 	if err != nil {
 		h.logger.Error("scheduler failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -44,13 +52,36 @@ func (h *Handler) RunScheduler(c *gin.Context) {
 		return
 	}
 
+	// Tell the webhook, if configured; a failed delivery doesn't fail the run
+	if h.notifier != nil {
+		if err := h.notifier.Notify(c.Request.Context(), summary); err != nil {
+			h.logger.Error("failed to send scheduler webhook", zap.Error(err))
+		}
+		h.evaluateTagAlerts(c.Request.Context(), summary.Date.Format("2006-01"))
+	}
+
 	// Return success response with processed count
 	response := model.SchedulerResponse{
-		Processed: processed,
+		Processed: summary.Processed,
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"data":  response,
 		"error": nil,
 	})
-} 
\ No newline at end of file
+}
+
+// evaluateTagAlerts checks every user's tag alerts for month ym, logging
+// rather than returning failures so they don't fail the scheduler run
+func (h *Handler) evaluateTagAlerts(ctx context.Context, ym string) {
+	users, err := h.repo.ListUsers(ctx)
+	if err != nil {
+		h.logger.Error("failed to list users for tag alerts", zap.Error(err))
+		return
+	}
+	for _, user := range users {
+		if _, err := scheduler.EvaluateTagAlerts(ctx, h.repo, user.ID, ym, h.notifier, h.logger); err != nil {
+			h.logger.Error("failed to evaluate tag alerts", zap.Error(err), zap.Int64("user_id", user.ID))
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+	"github.com/piotrzalecki/budget-api/internal/scheduler"
+	"github.com/piotrzalecki/budget-api/pkg/model"
+)
+
+// recordingNotifier keeps what it is sent and fails with err
+type recordingNotifier struct {
+	summaries []scheduler.Summary
+	alerts    []scheduler.TagAlertEvent
+	err       error
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, summary scheduler.Summary) error {
+	n.summaries = append(n.summaries, summary)
+	return n.err
+}
+
+func (n *recordingNotifier) NotifyTagAlert(ctx context.Context, event scheduler.TagAlertEvent) error {
+	n.alerts = append(n.alerts, event)
+	return n.err
+}
+
+func TestRunSchedulerNotifiesIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name      string
+		notifyErr error
+	}{
+		{name: "delivered"},
+		{name: "delivery failure does not fail the run", notifyErr: errors.New("webhook down")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			defer db.Close()
+
+			notifier := &recordingNotifier{err: tt.notifyErr}
+			h := NewHandler(repo.NewRepository(db), zap.NewNop())
+			h.notifier = notifier
+			router := gin.New()
+			router.POST("/admin/run-scheduler", h.RunScheduler)
+
+			req := httptest.NewRequest("POST", "/admin/run-scheduler", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code)
+
+			var response struct {
+				Data model.SchedulerResponse `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+			require.Len(t, notifier.summaries, 1)
+			assert.Equal(t, response.Data.Processed, notifier.summaries[0].Processed)
+			assert.False(t, notifier.summaries[0].Date.IsZero())
+		})
+	}
+}
+
+func TestRunSchedulerConflictIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.POST("/admin/run-scheduler", h.RunScheduler)
+
+	// Another run holds the scheduler lock
+	_, err := repository.CreateSetting(context.Background(), repo.CreateSettingParams{
+		Key:   "scheduler_lock",
+		Value: time.Now().UTC().Format("2006-01-02T15:04:05.000000000Z"),
+	})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/admin/run-scheduler", nil))
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "scheduler already running")
+
+	require.NoError(t, repository.DeleteSetting(context.Background(), "scheduler_lock"))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/admin/run-scheduler", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,325 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+	"github.com/piotrzalecki/budget-api/internal/scheduler"
+	"github.com/piotrzalecki/budget-api/pkg/model"
+)
+
+// GetTagAlerts handles GET /api/v1/tag-alerts
+// @Summary List tag spending alerts
+// @Description List the monthly spending thresholds set on tags
+// @Tags tags
+// @Produce json
+// @Success 200 {array} model.TagAlertResponse "Tag alerts"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /tag-alerts [get]
+func (h *Handler) GetTagAlerts(c *gin.Context) {
+	// TODO: Get user ID from context when authentication is implemented
+	// For now, use a default user ID of 1
+	userID := int64(1)
+
+	alerts, err := h.repo.ListTagAlerts(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Error("failed to fetch tag alerts", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch tag alerts",
+			"data":  nil,
+		})
+		return
+	}
+
+	response := make([]model.TagAlertResponse, 0, len(alerts))
+	for _, alert := range alerts {
+		response = append(response, tagAlertToResponse(alert))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// CreateTagAlert handles POST /api/v1/tag-alerts
+// @Summary Create a tag spending alert
+// @Description Alert (via the webhook) when the month's spending on a tag reaches the threshold. A tag can have one alert.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param request body model.CreateTagAlertRequest true "Tag and threshold"
+// @Success 201 {object} model.TagAlertResponse "Tag alert created"
+// @Failure 400 {object} map[string]interface{} "Invalid request data"
+// @Failure 409 {object} map[string]interface{} "Tag already has an alert"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /tag-alerts [post]
+func (h *Handler) CreateTagAlert(c *gin.Context) {
+	request, ok := GetValidatedRequest[model.CreateTagAlertRequest](c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to get validated request",
+			"data":  nil,
+		})
+		return
+	}
+
+	thresholdPence, ok := h.parseAlertThreshold(c, request.Threshold)
+	if !ok {
+		return
+	}
+
+	// TODO: Get user ID from context when authentication is implemented
+	// For now, use a default user ID of 1
+	userID := int64(1)
+
+	if _, err := h.repo.GetTagByID(c.Request.Context(), request.TagID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid tag ID: " + strconv.FormatInt(request.TagID, 10),
+			"data":  nil,
+		})
+		return
+	}
+
+	existing, err := h.repo.ListTagAlerts(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Error("failed to fetch tag alerts", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch tag alerts",
+			"data":  nil,
+		})
+		return
+	}
+	for _, alert := range existing {
+		if alert.TagID == request.TagID {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "tag already has an alert",
+				"data":  nil,
+			})
+			return
+		}
+	}
+
+	alert, err := h.repo.CreateTagAlert(c.Request.Context(), repo.CreateTagAlertParams{
+		UserID:         userID,
+		TagID:          request.TagID,
+		ThresholdPence: thresholdPence,
+	})
+	if err != nil {
+		h.logger.Error("failed to create tag alert", zap.Error(err), zap.Int64("tag_id", request.TagID))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to create tag alert",
+			"data":  nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":  tagAlertToResponse(alert),
+		"error": nil,
+	})
+}
+
+// UpdateTagAlert handles PATCH /api/v1/tag-alerts/:id
+// @Summary Update a tag spending alert
+// @Description Change a tag alert's monthly threshold
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Tag alert ID"
+// @Param request body model.UpdateTagAlertRequest true "New threshold"
+// @Success 204 "Tag alert updated"
+// @Failure 400 {object} map[string]interface{} "Invalid request data"
+// @Failure 404 {object} map[string]interface{} "Tag alert not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /tag-alerts/{id} [patch]
+func (h *Handler) UpdateTagAlert(c *gin.Context) {
+	id, ok := h.findTagAlert(c)
+	if !ok {
+		return
+	}
+
+	request, ok := GetValidatedRequest[model.UpdateTagAlertRequest](c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to get validated request",
+			"data":  nil,
+		})
+		return
+	}
+
+	thresholdPence, ok := h.parseAlertThreshold(c, request.Threshold)
+	if !ok {
+		return
+	}
+
+	_, err := h.repo.UpdateTagAlertThreshold(c.Request.Context(), repo.UpdateTagAlertThresholdParams{
+		ThresholdPence: thresholdPence,
+		ID:             id,
+	})
+	if err != nil {
+		h.logger.Error("failed to update tag alert", zap.Error(err), zap.Int64("id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to update tag alert",
+			"data":  nil,
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteTagAlert handles DELETE /api/v1/tag-alerts/:id
+// @Summary Delete a tag spending alert
+// @Description Delete a tag alert
+// @Tags tags
+// @Param id path int true "Tag alert ID"
+// @Success 204 "Tag alert deleted"
+// @Failure 400 {object} map[string]interface{} "Invalid tag alert ID"
+// @Failure 404 {object} map[string]interface{} "Tag alert not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /tag-alerts/{id} [delete]
+func (h *Handler) DeleteTagAlert(c *gin.Context) {
+	id, ok := h.findTagAlert(c)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.DeleteTagAlert(c.Request.Context(), id); err != nil {
+		h.logger.Error("failed to delete tag alert", zap.Error(err), zap.Int64("id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to delete tag alert",
+			"data":  nil,
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// EvaluateTagAlerts handles POST /api/v1/tag-alerts/evaluate
+// @Summary Evaluate tag spending alerts
+// @Description Compare the month's spending on each alerted tag with its threshold. Alerts that have reached it and not yet fired that month are sent to the webhook, if configured, and returned.
+// @Tags tags
+// @Produce json
+// @Param ym query string false "Year-month in YYYY-MM format (defaults to current month)"
+// @Success 200 {array} model.TagAlertEventResponse "Alerts that fired"
+// @Failure 400 {object} map[string]interface{} "Invalid year-month format"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /tag-alerts/evaluate [post]
+func (h *Handler) EvaluateTagAlerts(c *gin.Context) {
+	ym := c.DefaultQuery("ym", h.clock.Now().Format("2006-01"))
+	if _, err := time.Parse("2006-01", ym); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid year-month format. Use YYYY-MM (e.g., 2025-06)",
+			"data":  nil,
+		})
+		return
+	}
+
+	// TODO: Get user ID from context when authentication is implemented
+	// For now, use a default user ID of 1
+	userID := int64(1)
+
+	events, err := scheduler.EvaluateTagAlerts(c.Request.Context(), h.repo, userID, ym, h.notifier, h.logger)
+	if err != nil {
+		h.logger.Error("failed to evaluate tag alerts", zap.Error(err), zap.String("ym", ym))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to evaluate tag alerts",
+			"data":  nil,
+		})
+		return
+	}
+
+	response := make([]model.TagAlertEventResponse, 0, len(events))
+	for _, event := range events {
+		response = append(response, model.TagAlertEventResponse{
+			TagID:          event.TagID,
+			TagName:        event.TagName,
+			YearMonth:      event.YearMonth,
+			Spend:          model.PenceToCurrency(event.SpendPence),
+			SpendPence:     event.SpendPence,
+			Threshold:      model.PenceToCurrency(event.ThresholdPence),
+			ThresholdPence: event.ThresholdPence,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// findTagAlert parses the :id parameter and checks the alert exists and
+// belongs to the user, writing a 400 or 404 response when it does not
+func (h *Handler) findTagAlert(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid tag alert ID",
+			"data":  nil,
+		})
+		return 0, false
+	}
+
+	// TODO: Get user ID from context when authentication is implemented
+	// For now, use a default user ID of 1
+	userID := int64(1)
+
+	alert, err := h.repo.GetTagAlertByID(c.Request.Context(), id)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && alert.UserID != userID) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "tag alert not found",
+			"data":  nil,
+		})
+		return 0, false
+	}
+	if err != nil {
+		h.logger.Error("failed to fetch tag alert", zap.Error(err), zap.Int64("id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch tag alert",
+			"data":  nil,
+		})
+		return 0, false
+	}
+	return id, true
+}
+
+// parseAlertThreshold converts a threshold to pence, writing a 400 response
+// unless it is a positive amount
+func (h *Handler) parseAlertThreshold(c *gin.Context, threshold string) (int64, bool) {
+	pence, err := model.CurrencyToPence(threshold)
+	if err != nil || pence <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "threshold must be a positive amount",
+			"data":  nil,
+		})
+		return 0, false
+	}
+	return pence, true
+}
+
+func tagAlertToResponse(alert repo.TagAlert) model.TagAlertResponse {
+	response := model.TagAlertResponse{
+		ID:             alert.ID,
+		TagID:          alert.TagID,
+		Threshold:      model.PenceToCurrency(alert.ThresholdPence),
+		ThresholdPence: alert.ThresholdPence,
+	}
+	if alert.LastNotifiedYm.Valid {
+		response.LastNotified = &alert.LastNotifiedYm.String
+	}
+	return response
+}
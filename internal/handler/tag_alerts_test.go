@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+	"github.com/piotrzalecki/budget-api/pkg/model"
+)
+
+func TestTagAlertsIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	tag, err := repository.CreateTag(ctx, "alert-tag")
+	require.NoError(t, err)
+	txn, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+		UserID:      1,
+		AmountPence: -12000,
+		TDate:       time.Date(2031, 6, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.NoError(t, repository.CreateTransactionTag(ctx, repo.CreateTransactionTagParams{
+		TransactionID: txn.ID,
+		TagID:         tag.ID,
+	}))
+
+	notifier := &recordingNotifier{}
+	h := NewHandler(repository, zap.NewNop())
+	h.notifier = notifier
+	router := gin.New()
+	router.POST("/tag-alerts", ValidateRequest[model.CreateTagAlertRequest](), h.CreateTagAlert)
+	router.POST("/tag-alerts/evaluate", h.EvaluateTagAlerts)
+	router.PATCH("/tag-alerts/:id", ValidateRequest[model.UpdateTagAlertRequest](), h.UpdateTagAlert)
+
+	create := func(tagID int64, threshold string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(model.CreateTagAlertRequest{TagID: tagID, Threshold: threshold})
+		req := httptest.NewRequest("POST", "/tag-alerts", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("rejects an unknown tag", func(t *testing.T) {
+		w := create(999999, "100.00")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("rejects a zero threshold", func(t *testing.T) {
+		w := create(tag.ID, "0.00")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("creates an alert once per tag", func(t *testing.T) {
+		w := create(tag.ID, "100.00")
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		var response struct {
+			Data model.TagAlertResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, tag.ID, response.Data.TagID)
+		assert.Equal(t, int64(10000), response.Data.ThresholdPence)
+		assert.Nil(t, response.Data.LastNotified)
+
+		w = create(tag.ID, "50.00")
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("update answers 204 with no body", func(t *testing.T) {
+		alerts, err := repository.ListTagAlerts(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, alerts, 1)
+
+		body, _ := json.Marshal(model.UpdateTagAlertRequest{Threshold: "110.00"})
+		req := httptest.NewRequest("PATCH", "/tag-alerts/"+strconv.FormatInt(alerts[0].ID, 10), bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusNoContent, w.Code)
+		assert.Empty(t, w.Body.String())
+
+		updated, err := repository.GetTagAlertByID(ctx, alerts[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(11000), updated.ThresholdPence)
+	})
+
+	t.Run("evaluate fires alerts over the threshold", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/tag-alerts/evaluate?ym=2031-06", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data []model.TagAlertEventResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data, 1)
+		assert.Equal(t, "alert-tag", response.Data[0].TagName)
+		assert.Equal(t, "120.00", response.Data[0].Spend)
+		require.Len(t, notifier.alerts, 1)
+	})
+
+	t.Run("evaluate rejects a bad month", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/tag-alerts/evaluate?ym=June", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -12,13 +13,16 @@ import (
 
 // CreateTag handles POST /api/v1/tags
 // @Summary Create a new tag
-// @Description Create a new tag for categorizing transactions
+// @Description Create a new tag for categorizing transactions. The name "Untagged" is reserved for reports.
 // @Tags tags
 // @Accept json
 // @Produce json
 // @Param tag body model.CreateTagRequest true "Tag data"
-// @Success 200 {object} map[string]interface{} "Tag created successfully"
+// @Param upsert query bool false "Return the existing tag with 200 when the name is taken, instead of 409"
+// @Success 200 {object} model.TagResponse "Existing tag returned (upsert only)"
+// @Success 201 {object} model.TagResponse "Tag created successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid request data"
+// @Failure 409 {object} map[string]interface{} "Tag already exists"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security ApiKeyAuth
 // @Router /tags [post]
@@ -33,8 +37,57 @@ func (h *Handler) CreateTag(c *gin.Context) {
 		return
 	}
 
+	if reservedTagName(request.Name) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "tag name \"" + untaggedReportName + "\" is reserved",
+			"data":  nil,
+		})
+		return
+	}
+
+	upsert := false
+	if value := c.Query("upsert"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "upsert must be true or false",
+				"data":  nil,
+			})
+			return
+		}
+		upsert = parsed
+	}
+
+	if upsert {
+		tag, created, err := h.repo.GetOrCreateTag(c.Request.Context(), request.Name)
+		if err != nil {
+			h.logger.Error("failed to get or create tag", zap.Error(err), zap.String("name", request.Name))
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "failed to create tag: " + err.Error(),
+				"data":  nil,
+			})
+			return
+		}
+		status := http.StatusOK
+		if created {
+			status = http.StatusCreated
+		}
+		c.JSON(status, gin.H{
+			"data":  model.TagResponse{ID: tag.ID, Name: tag.Name, CreatedAt: tag.CreatedAt.Time},
+			"error": nil,
+		})
+		return
+	}
+
 	// Create tag using the repository
 	tag, err := h.repo.CreateTag(c.Request.Context(), request.Name)
+	if repo.IsUniqueViolation(err) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "tag already exists",
+			"data":  nil,
+		})
+		return
+	}
 	if err != nil {
 		h.logger.Error("failed to create tag", zap.Error(err), zap.String("name", request.Name))
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -45,20 +98,26 @@ func (h *Handler) CreateTag(c *gin.Context) {
 	}
 	
 	c.JSON(http.StatusCreated, gin.H{
-		"data":  model.TagResponse{ID: tag.ID, Name: tag.Name},
+		"data":  model.TagResponse{ID: tag.ID, Name: tag.Name, CreatedAt: tag.CreatedAt.Time},
 		"error": nil,
 	})
 }
 
+// reservedTagName reports whether name, ignoring case, is the one reports
+// use for untagged transactions. A tag by that name would clash with them.
+func reservedTagName(name string) bool {
+	return strings.EqualFold(strings.TrimSpace(name), untaggedReportName)
+}
+
 // UpdateTag handles PATCH /api/v1/tags/:id
 // @Summary Update a tag
-// @Description Update an existing tag's name
+// @Description Update an existing tag's name. Reports group by tag ID, so they show the new name straight away. The name "Untagged" is reserved for reports.
 // @Tags tags
 // @Accept json
 // @Produce json
 // @Param id path int true "Tag ID"
 // @Param tag body model.UpdateTagRequest true "Tag update data"
-// @Success 200 {object} map[string]interface{} "Tag updated successfully"
+// @Success 204 "Tag updated successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid request data"
 // @Failure 404 {object} map[string]interface{} "Tag not found"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
@@ -84,6 +143,14 @@ func (h *Handler) UpdateTag(c *gin.Context) {
 		return
 	}
 
+	if reservedTagName(request.Name) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "tag name \"" + untaggedReportName + "\" is reserved",
+			"data":  nil,
+		})
+		return
+	}
+
 	_, err = h.repo.GetTagByID(c.Request.Context(), id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
@@ -93,7 +160,7 @@ func (h *Handler) UpdateTag(c *gin.Context) {
 		return
 	}
 
-	tag, err := h.repo.UpdateTag(c.Request.Context(), repo.UpdateTagParams{ID: id, Name: request.Name})
+	_, err = h.repo.UpdateTag(c.Request.Context(), repo.UpdateTagParams{ID: id, Name: request.Name})
 	if err != nil {
 		h.logger.Error("failed to update tag", zap.Error(err), zap.Int64("id", id))
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -103,10 +170,7 @@ func (h *Handler) UpdateTag(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"data":  model.TagResponse{ID: tag.ID, Name: tag.Name},
-		"error": nil,
-	})
+	c.Status(http.StatusNoContent)
 }
 
 // DeleteTag handles DELETE /api/v1/tags/:id
@@ -142,7 +206,16 @@ func (h *Handler) DeleteTag(c *gin.Context) {
 		return
 	}
 
-	err = h.repo.DeleteTag(c.Request.Context(), id)
+	// Unlink the tag from transactions and recurring rules before deleting it
+	err = h.repo.WithTx(c.Request.Context(), func(txRepo repo.Repository) error {
+		if _, err := txRepo.DeleteTransactionTagsByTag(c.Request.Context(), id); err != nil {
+			return err
+		}
+		if err := txRepo.DeleteRecurringTagsByTag(c.Request.Context(), id); err != nil {
+			return err
+		}
+		return txRepo.DeleteTag(c.Request.Context(), id)
+	})
 	if err != nil {
 		h.logger.Error("failed to delete tag", zap.Error(err), zap.Int64("id", id))
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -155,6 +228,99 @@ func (h *Handler) DeleteTag(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// ReassignTag handles POST /api/v1/tags/:id/reassign
+// @Summary Reassign a tag's transactions
+// @Description Move every transaction from this tag to another tag. The source tag is kept. Transactions that already carry the target tag are skipped.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Source tag ID"
+// @Param request body model.ReassignTagRequest true "Target tag"
+// @Success 200 {object} model.ReassignTagResponse "Number of transactions moved"
+// @Failure 400 {object} map[string]interface{} "Invalid request data"
+// @Failure 404 {object} map[string]interface{} "Tag not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /tags/{id}/reassign [post]
+func (h *Handler) ReassignTag(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid tag ID",
+			"data":  nil,
+		})
+		return
+	}
+
+	request, ok := GetValidatedRequest[model.ReassignTagRequest](c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to get validated request",
+			"data":  nil,
+		})
+		return
+	}
+
+	if request.ToTagID == id {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "to_tag_id must differ from the source tag",
+			"data":  nil,
+		})
+		return
+	}
+
+	_, err = h.repo.GetTagByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "tag not found",
+			"data":  nil,
+		})
+		return
+	}
+
+	_, err = h.repo.GetTagByID(c.Request.Context(), request.ToTagID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid tag ID: " + strconv.FormatInt(request.ToTagID, 10),
+			"data":  nil,
+		})
+		return
+	}
+
+	// Move the links, then drop those left behind because the transaction
+	// already had the target tag
+	var response model.ReassignTagResponse
+	err = h.repo.WithTx(c.Request.Context(), func(txRepo repo.Repository) error {
+		moved, err := txRepo.ReassignTransactionTags(c.Request.Context(), repo.ReassignTransactionTagsParams{
+			ToTagID:   request.ToTagID,
+			FromTagID: id,
+		})
+		if err != nil {
+			return err
+		}
+		skipped, err := txRepo.DeleteTransactionTagsByTag(c.Request.Context(), id)
+		if err != nil {
+			return err
+		}
+		response = model.ReassignTagResponse{Moved: moved, Skipped: skipped}
+		return nil
+	})
+	if err != nil {
+		h.logger.Error("failed to reassign tag", zap.Error(err), zap.Int64("id", id), zap.Int64("to_tag_id", request.ToTagID))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to reassign tag",
+			"data":  nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
 // GetTags handles GET /api/v1/tags
 // @Summary Get all tags
 // @Description Get all available tags for the authenticated user
@@ -181,8 +347,9 @@ func (h *Handler) GetTags(c *gin.Context) {
 	tagResponses := make([]model.TagResponse, len(tags))
 	for i, tag := range tags {
 		tagResponses[i] = model.TagResponse{
-			ID:   tag.ID,
-			Name: tag.Name,
+			ID:        tag.ID,
+			Name:      tag.Name,
+			CreatedAt: tag.CreatedAt.Time,
 		}
 	}
 
@@ -190,4 +357,60 @@ func (h *Handler) GetTags(c *gin.Context) {
 		"data":  tagResponses,
 		"error": nil,
 	})
-} 
\ No newline at end of file
+}
+
+// maxTagSearchResults caps the tags returned by a tag search
+const maxTagSearchResults = 20
+
+// likeEscaper escapes the LIKE wildcards in a search query, matching the
+// ESCAPE '\' clause of the SearchTags query
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// SearchTags handles GET /api/v1/tags/search
+// @Summary Search tags
+// @Description Find tags whose name contains the query, ignoring case, for autocomplete. Tags starting with the query come first; at most 20 are returned.
+// @Tags tags
+// @Produce json
+// @Param q query string true "Text to search tag names for"
+// @Success 200 {object} map[string]interface{} "Matching tags"
+// @Failure 400 {object} map[string]interface{} "Missing query"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /tags/search [get]
+func (h *Handler) SearchTags(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "q is required",
+			"data":  nil,
+		})
+		return
+	}
+
+	tags, err := h.repo.SearchTags(c.Request.Context(), repo.SearchTagsParams{
+		Query: likeEscaper.Replace(query),
+		Limit: maxTagSearchResults,
+	})
+	if err != nil {
+		h.logger.Error("failed to search tags", zap.Error(err), zap.String("query", query))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to search tags",
+			"data":  nil,
+		})
+		return
+	}
+
+	tagResponses := make([]model.TagResponse, len(tags))
+	for i, tag := range tags {
+		tagResponses[i] = model.TagResponse{
+			ID:        tag.ID,
+			Name:      tag.Name,
+			CreatedAt: tag.CreatedAt.Time,
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  tagResponses,
+		"error": nil,
+	})
+}
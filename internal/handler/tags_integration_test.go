@@ -0,0 +1,272 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+	"github.com/piotrzalecki/budget-api/pkg/model"
+)
+
+func TestReassignTagIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	source, err := repository.CreateTag(ctx, "reassign-source")
+	require.NoError(t, err)
+	target, err := repository.CreateTag(ctx, "reassign-target")
+	require.NoError(t, err)
+
+	createTagged := func(tagIDs ...int64) int64 {
+		txn, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+			UserID:      1,
+			AmountPence: -250,
+			TDate:       time.Date(2031, 6, 1, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+		for _, tagID := range tagIDs {
+			require.NoError(t, repository.CreateTransactionTag(ctx, repo.CreateTransactionTagParams{
+				TransactionID: txn.ID,
+				TagID:         tagID,
+			}))
+		}
+		return txn.ID
+	}
+
+	onlySource1 := createTagged(source.ID)
+	onlySource2 := createTagged(source.ID)
+	both := createTagged(source.ID, target.ID)
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.POST("/tags/:id/reassign", ValidateRequest[model.ReassignTagRequest](), h.ReassignTag)
+
+	reassign := func(fromID, toID int64) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]interface{}{"to_tag_id": toID})
+		req := httptest.NewRequest("POST", "/tags/"+strconv.FormatInt(fromID, 10)+"/reassign", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	tagIDsOf := func(transactionID int64) []int64 {
+		tags, err := repository.GetTransactionTags(ctx, transactionID)
+		require.NoError(t, err)
+		ids := make([]int64, len(tags))
+		for i, tag := range tags {
+			ids[i] = tag.ID
+		}
+		return ids
+	}
+
+	t.Run("rejects reassigning to itself", func(t *testing.T) {
+		w := reassign(source.ID, source.ID)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("rejects an unknown target", func(t *testing.T) {
+		w := reassign(source.ID, 999999)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, []int64{source.ID}, tagIDsOf(onlySource1))
+	})
+
+	t.Run("rejects an unknown source", func(t *testing.T) {
+		w := reassign(999999, target.ID)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("moves associations and skips duplicates", func(t *testing.T) {
+		w := reassign(source.ID, target.ID)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data model.ReassignTagResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, int64(2), response.Data.Moved)
+		assert.Equal(t, int64(1), response.Data.Skipped)
+
+		assert.Equal(t, []int64{target.ID}, tagIDsOf(onlySource1))
+		assert.Equal(t, []int64{target.ID}, tagIDsOf(onlySource2))
+		assert.Equal(t, []int64{target.ID}, tagIDsOf(both))
+
+		remaining, err := repository.GetTransactionsByTag(ctx, source.ID)
+		require.NoError(t, err)
+		assert.Empty(t, remaining)
+
+		// The source tag itself is kept
+		_, err = repository.GetTagByID(ctx, source.ID)
+		assert.NoError(t, err)
+	})
+}
+
+func TestSearchTagsIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ctx := context.Background()
+
+	// The dev seed provides "groceries" and "entertainment"
+	for _, name := range []string{"Grocery-Run", "organic-groceries", "100%_done"} {
+		_, err := repository.CreateTag(ctx, name)
+		require.NoError(t, err)
+	}
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.GET("/tags/search", h.SearchTags)
+
+	search := func(query string) []string {
+		req := httptest.NewRequest("GET", "/tags/search?q="+url.QueryEscape(query), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data []model.TagResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		names := make([]string, 0, len(response.Data))
+		for _, tag := range response.Data {
+			names = append(names, tag.Name)
+		}
+		return names
+	}
+
+	t.Run("matches by prefix before other matches, ignoring case", func(t *testing.T) {
+		names := search("gro")
+		assert.Equal(t, []string{"Grocery-Run", "groceries", "organic-groceries"}, names)
+		assert.NotContains(t, names, "entertainment")
+		assert.Equal(t, names, search("GRO"))
+	})
+
+	t.Run("matches inside names", func(t *testing.T) {
+		assert.Equal(t, []string{"entertainment"}, search("tain"))
+	})
+
+	t.Run("treats wildcards literally", func(t *testing.T) {
+		assert.Equal(t, []string{"100%_done"}, search("%_"))
+		assert.Empty(t, search("_x"))
+	})
+
+	t.Run("returns at most 20 tags", func(t *testing.T) {
+		for i := 0; i < 25; i++ {
+			_, err := repository.CreateTag(ctx, "bulk-"+strconv.Itoa(i))
+			require.NoError(t, err)
+		}
+		assert.Len(t, search("bulk"), maxTagSearchResults)
+	})
+
+	t.Run("requires a query", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/tags/search?q=%20", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestTagCreatedAtIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	h := NewHandler(repo.NewRepository(db), zap.NewNop())
+	router := gin.New()
+	router.POST("/tags", ValidateRequest[model.CreateTagRequest](), h.CreateTag)
+	router.GET("/tags", h.GetTags)
+
+	req := httptest.NewRequest("POST", "/tags", bytes.NewBufferString(`{"name": "created-at"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var created struct {
+		Data model.TagResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.False(t, created.Data.CreatedAt.IsZero())
+	assert.WithinDuration(t, time.Now(), created.Data.CreatedAt, time.Minute)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tags", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var listed struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &listed))
+	require.NotEmpty(t, listed.Data)
+	for _, tag := range listed.Data {
+		// Tags from the seed were stamped by the migration
+		require.Contains(t, tag, "created_at")
+		createdAt, err := time.Parse(time.RFC3339, tag["created_at"].(string))
+		require.NoError(t, err)
+		assert.False(t, createdAt.IsZero(), "tag %v", tag["name"])
+	}
+}
+
+func TestCreateTagUpsertIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	h := NewHandler(repo.NewRepository(db), zap.NewNop())
+	router := gin.New()
+	router.POST("/tags", ValidateRequest[model.CreateTagRequest](), h.CreateTag)
+
+	create := func(query, name string) (int, model.TagResponse) {
+		body, _ := json.Marshal(map[string]string{"name": name})
+		req := httptest.NewRequest("POST", "/tags"+query, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response struct {
+			Data model.TagResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response.Data
+	}
+
+	code, first := create("?upsert=true", "imported")
+	require.Equal(t, http.StatusCreated, code)
+
+	t.Run("returns the existing tag", func(t *testing.T) {
+		code, second := create("?upsert=true", "imported")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, first.ID, second.ID)
+		assert.Equal(t, "imported", second.Name)
+	})
+
+	t.Run("rejects a duplicate without upsert", func(t *testing.T) {
+		code, _ := create("", "imported")
+		assert.Equal(t, http.StatusConflict, code)
+		code, _ = create("?upsert=false", "imported")
+		assert.Equal(t, http.StatusConflict, code)
+	})
+
+	t.Run("rejects an invalid upsert flag", func(t *testing.T) {
+		code, _ := create("?upsert=maybe", "imported")
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
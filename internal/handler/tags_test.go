@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mattn/go-sqlite3"
 	"github.com/piotrzalecki/budget-api/internal/repo"
 	"github.com/piotrzalecki/budget-api/pkg/model"
 	"github.com/stretchr/testify/assert"
@@ -39,7 +40,7 @@ func (m *mockRepo) CreateTag(ctx context.Context, name string) (repo.Tag, error)
 	}
 	for _, t := range m.tags {
 		if t.Name == name {
-			return repo.Tag{}, errors.New("duplicate name")
+			return repo.Tag{}, sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintUnique}
 		}
 	}
 	tag := repo.Tag{ID: int64(len(m.tags) + 1), Name: name}
@@ -78,7 +79,7 @@ func (m *mockRepo) GetTransactionsByTag(ctx context.Context, tagID int64) ([]rep
 func (m *mockRepo) UpdateTransaction(ctx context.Context, arg repo.UpdateTransactionParams) (repo.Transaction, error) { panic("not implemented") }
 func (m *mockRepo) SoftDeleteTransaction(ctx context.Context, id int64) error { panic("not implemented") }
 func (m *mockRepo) HardDeleteTransaction(ctx context.Context, id int64) error { panic("not implemented") }
-func (m *mockRepo) PurgeSoftDeletedTransactions(ctx context.Context, deletedAt sql.NullTime) error { panic("not implemented") }
+func (m *mockRepo) PurgeSoftDeletedTransactions(ctx context.Context, arg repo.PurgeSoftDeletedTransactionsParams) (int64, error) { panic("not implemented") }
 func (m *mockRepo) GetTagByID(ctx context.Context, id int64) (repo.Tag, error) {
 	for _, t := range m.tags {
 		if t.ID == id {
@@ -87,7 +88,14 @@ func (m *mockRepo) GetTagByID(ctx context.Context, id int64) (repo.Tag, error) {
 	}
 	return repo.Tag{}, errors.New("not found")
 }
-func (m *mockRepo) GetTagByName(ctx context.Context, name string) (repo.Tag, error) { panic("not implemented") }
+func (m *mockRepo) GetTagByName(ctx context.Context, name string) (repo.Tag, error) {
+	for _, t := range m.tags {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+	return repo.Tag{}, sql.ErrNoRows
+}
 func (m *mockRepo) UpdateTag(ctx context.Context, arg repo.UpdateTagParams) (repo.Tag, error) {
 	for i, t := range m.tags {
 		if t.ID == arg.ID {
@@ -106,14 +114,23 @@ func (m *mockRepo) DeleteTag(ctx context.Context, id int64) error {
 	}
 	return errors.New("not found")
 }
+
+// The mock keeps no tag links, so unlinking a tag is a no-op
+func (m *mockRepo) DeleteTransactionTagsByTag(ctx context.Context, tagID int64) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockRepo) DeleteRecurringTagsByTag(ctx context.Context, tagID int64) error {
+	return nil
+}
 func (m *mockRepo) CreateTransactionTag(ctx context.Context, arg repo.CreateTransactionTagParams) error { panic("not implemented") }
 func (m *mockRepo) GetTransactionTags(ctx context.Context, transactionID int64) ([]repo.Tag, error) { panic("not implemented") }
 func (m *mockRepo) DeleteTransactionTag(ctx context.Context, arg repo.DeleteTransactionTagParams) error { panic("not implemented") }
 func (m *mockRepo) DeleteAllTransactionTags(ctx context.Context, transactionID int64) error { panic("not implemented") }
 func (m *mockRepo) CreateRecurring(ctx context.Context, arg repo.CreateRecurringParams) (repo.Recurring, error) { panic("not implemented") }
 func (m *mockRepo) GetRecurringByID(ctx context.Context, id int64) (repo.Recurring, error) { panic("not implemented") }
-func (m *mockRepo) ListRecurring(ctx context.Context, userID int64) ([]repo.Recurring, error) { panic("not implemented") }
-func (m *mockRepo) ListActiveRecurring(ctx context.Context, userID int64) ([]repo.Recurring, error) { panic("not implemented") }
+func (m *mockRepo) ListRecurring(ctx context.Context, arg repo.ListRecurringParams) ([]repo.Recurring, error) { panic("not implemented") }
+func (m *mockRepo) ListActiveRecurring(ctx context.Context, arg repo.ListActiveRecurringParams) ([]repo.Recurring, error) { panic("not implemented") }
 func (m *mockRepo) GetRecurringByTag(ctx context.Context, tagID int64) ([]repo.Recurring, error) { panic("not implemented") }
 func (m *mockRepo) GetRecurringDueOnDate(ctx context.Context, nextDueDate time.Time) ([]repo.Recurring, error) { panic("not implemented") }
 func (m *mockRepo) UpdateRecurring(ctx context.Context, arg repo.UpdateRecurringParams) (repo.Recurring, error) { panic("not implemented") }
@@ -135,6 +152,58 @@ func (m *mockRepo) CreateSession(ctx context.Context, arg repo.CreateSessionPara
 func (m *mockRepo) GetSessionByToken(ctx context.Context, token string) (repo.GetSessionByTokenRow, error) { panic("not implemented") }
 func (m *mockRepo) DeleteSession(ctx context.Context, token string) error { panic("not implemented") }
 func (m *mockRepo) DeleteAllSessionsByUserID(ctx context.Context, userID int64) error { panic("not implemented") }
+func (m *mockRepo) SoftDeleteAllTransactionsByUser(ctx context.Context, userID int64) (int64, error) { panic("not implemented") }
+func (m *mockRepo) CreateRecurringHistory(ctx context.Context, arg repo.CreateRecurringHistoryParams) (repo.RecurringHistory, error) { panic("not implemented") }
+func (m *mockRepo) ListRecurringHistory(ctx context.Context, arg repo.ListRecurringHistoryParams) ([]repo.RecurringHistory, error) { panic("not implemented") }
+func (m *mockRepo) CountRecurringHistory(ctx context.Context, recurringID int64) (int64, error) { panic("not implemented") }
+func (m *mockRepo) DeleteRecurringHistory(ctx context.Context, recurringID int64) error { panic("not implemented") }
+func (m *mockRepo) ListTransactionTagsByDateRange(ctx context.Context, arg repo.ListTransactionTagsByDateRangeParams) ([]repo.ListTransactionTagsByDateRangeRow, error) { panic("not implemented") }
+func (m *mockRepo) CountTransactions(ctx context.Context, arg repo.CountTransactionsParams) (int64, error) { panic("not implemented") }
+func (m *mockRepo) CountRecurring(ctx context.Context, arg repo.CountRecurringParams) (int64, error) { panic("not implemented") }
+func (m *mockRepo) CountActiveRecurring(ctx context.Context, arg repo.CountActiveRecurringParams) (int64, error) { panic("not implemented") }
+func (m *mockRepo) PurgeAllSoftDeleted(ctx context.Context, userID int64) (int64, error) { panic("not implemented") }
+func (m *mockRepo) ReassignTransactionTags(ctx context.Context, arg repo.ReassignTransactionTagsParams) (int64, error) { panic("not implemented") }
+func (m *mockRepo) ListTransactionAmountsByDateRange(ctx context.Context, arg repo.ListTransactionAmountsByDateRangeParams) ([]repo.ListTransactionAmountsByDateRangeRow, error) { panic("not implemented") }
+func (m *mockRepo) GetBalanceBefore(ctx context.Context, arg repo.GetBalanceBeforeParams) (sql.NullFloat64, error) { panic("not implemented") }
+func (m *mockRepo) DetachRecurringTransactions(ctx context.Context, sourceRecurring sql.NullInt64) error { panic("not implemented") }
+func (m *mockRepo) CreateTagAlert(ctx context.Context, arg repo.CreateTagAlertParams) (repo.TagAlert, error) { panic("not implemented") }
+func (m *mockRepo) GetTagAlertByID(ctx context.Context, id int64) (repo.TagAlert, error) { panic("not implemented") }
+func (m *mockRepo) ListTagAlerts(ctx context.Context, userID int64) ([]repo.TagAlert, error) { panic("not implemented") }
+func (m *mockRepo) UpdateTagAlertThreshold(ctx context.Context, arg repo.UpdateTagAlertThresholdParams) (repo.TagAlert, error) { panic("not implemented") }
+func (m *mockRepo) MarkTagAlertNotified(ctx context.Context, arg repo.MarkTagAlertNotifiedParams) error { panic("not implemented") }
+func (m *mockRepo) DeleteTagAlert(ctx context.Context, id int64) error { panic("not implemented") }
+func (m *mockRepo) GetTagMonthlySpend(ctx context.Context, arg repo.GetTagMonthlySpendParams) (sql.NullFloat64, error) { panic("not implemented") }
+func (m *mockRepo) GetTopSpendingTags(ctx context.Context, arg repo.GetTopSpendingTagsParams) ([]repo.GetTopSpendingTagsRow, error) { panic("not implemented") }
+func (m *mockRepo) SoftDeleteRecurring(ctx context.Context, id int64) error { panic("not implemented") }
+func (m *mockRepo) CreateReceipt(ctx context.Context, arg repo.CreateReceiptParams) (repo.Receipt, error) { panic("not implemented") }
+func (m *mockRepo) GetReceiptByID(ctx context.Context, id int64) (repo.Receipt, error) { panic("not implemented") }
+func (m *mockRepo) ListReceipts(ctx context.Context, transactionID int64) ([]repo.Receipt, error) { panic("not implemented") }
+func (m *mockRepo) DeleteReceipt(ctx context.Context, id int64) error { panic("not implemented") }
+func (m *mockRepo) GetTransactionByExternalID(ctx context.Context, arg repo.GetTransactionByExternalIDParams) (repo.Transaction, error) { panic("not implemented") }
+func (m *mockRepo) ToggleTransactionCleared(ctx context.Context, id int64) error { panic("not implemented") }
+func (m *mockRepo) SetTransactionCleared(ctx context.Context, arg repo.SetTransactionClearedParams) error { panic("not implemented") }
+func (m *mockRepo) GetNetByPeriod(ctx context.Context, arg repo.GetNetByPeriodParams) ([]repo.GetNetByPeriodRow, error) { panic("not implemented") }
+func (m *mockRepo) DeleteTransactionsByUser(ctx context.Context, userID int64) (int64, error) { panic("not implemented") }
+func (m *mockRepo) DeleteRecurringByUser(ctx context.Context, userID int64) (int64, error) { panic("not implemented") }
+func (m *mockRepo) ListStaleRecurring(ctx context.Context, arg repo.ListStaleRecurringParams) ([]repo.ListStaleRecurringRow, error) { panic("not implemented") }
+func (m *mockRepo) ListDailyActivity(ctx context.Context, arg repo.ListDailyActivityParams) ([]repo.ListDailyActivityRow, error) { panic("not implemented") }
+func (m *mockRepo) PurgeSoftDeletedTransaction(ctx context.Context, arg repo.PurgeSoftDeletedTransactionParams) (int64, error) { panic("not implemented") }
+func (m *mockRepo) AcquireSettingLock(ctx context.Context, arg repo.AcquireSettingLockParams) (int64, error) { panic("not implemented") }
+func (m *mockRepo) ReleaseSettingLock(ctx context.Context, arg repo.ReleaseSettingLockParams) error { panic("not implemented") }
+func (m *mockRepo) SearchTags(ctx context.Context, arg repo.SearchTagsParams) ([]repo.Tag, error) { panic("not implemented") }
+func (m *mockRepo) GetOrCreateTag(ctx context.Context, name string) (repo.Tag, bool, error) {
+	if tag, err := m.GetTagByName(ctx, name); err == nil {
+		return tag, false, nil
+	}
+	tag, err := m.CreateTag(ctx, name)
+	return tag, err == nil, err
+}
+func (m *mockRepo) DeleteOtherSessionsByUserID(ctx context.Context, arg repo.DeleteOtherSessionsByUserIDParams) error { panic("not implemented") }
+func (m *mockRepo) DeleteTransactionTagsByUser(ctx context.Context, userID int64) error { panic("not implemented") }
+func (m *mockRepo) PurgeSoftDeletedTransactionTags(ctx context.Context, arg repo.PurgeSoftDeletedTransactionTagsParams) error { panic("not implemented") }
+func (m *mockRepo) PurgeAllSoftDeletedTags(ctx context.Context, userID int64) error { panic("not implemented") }
+func (m *mockRepo) PurgeSoftDeletedTransactionTagsByID(ctx context.Context, arg repo.PurgeSoftDeletedTransactionTagsByIDParams) error { panic("not implemented") }
+func (m *mockRepo) DeleteRecurringTagsByUser(ctx context.Context, userID int64) error { panic("not implemented") }
 
 func TestCreateTag(t *testing.T) {
 	gin.SetMode(gin.TestMode)
@@ -157,6 +226,14 @@ func TestCreateTag(t *testing.T) {
 			expectedStatus: http.StatusCreated,
 			expectedError:  false,
 		},
+		{
+			name: "duplicate name",
+			requestBody: map[string]interface{}{
+				"name": "groceries",
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError:  true,
+		},
 		{
 			name: "empty name",
 			requestBody: map[string]interface{}{
@@ -171,6 +248,14 @@ func TestCreateTag(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			expectedError:  true,
 		},
+		{
+			name: "reserved name",
+			requestBody: map[string]interface{}{
+				"name": "Untagged",
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  true,
+		},
 		{
 			name: "name too long",
 			requestBody: map[string]interface{}{
@@ -225,7 +310,7 @@ func TestUpdateTag(t *testing.T) {
 			name:           "valid update",
 			id:             "1",
 			requestBody:    map[string]interface{}{"name": "food"},
-			expectedStatus: http.StatusOK,
+			expectedStatus: http.StatusNoContent,
 		},
 		{
 			name:           "tag not found",
@@ -255,14 +340,9 @@ func TestUpdateTag(t *testing.T) {
 			w := httptest.NewRecorder()
 			router.ServeHTTP(w, req)
 			assert.Equal(t, tt.expectedStatus, w.Code)
-			if tt.expectedStatus == http.StatusOK {
-				var response map[string]interface{}
-				err := json.Unmarshal(w.Body.Bytes(), &response)
-				assert.NoError(t, err)
-				data, ok := response["data"].(map[string]interface{})
-				assert.True(t, ok)
-				assert.Contains(t, data, "id")
-				assert.Contains(t, data, "name")
+			if tt.expectedStatus == http.StatusNoContent {
+				assert.Empty(t, w.Body.String())
+				assert.Equal(t, "food", mock.tags[0].Name)
 			}
 		})
 	}
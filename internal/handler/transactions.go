@@ -2,14 +2,17 @@ package handler
 
 import (
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"go.uber.org/zap"
 	"github.com/piotrzalecki/budget-api/internal/repo"
 	"github.com/piotrzalecki/budget-api/pkg/model"
+	"go.uber.org/zap"
 )
 
 // CreateTransaction handles POST /api/v1/transactions
@@ -19,7 +22,8 @@ import (
 // @Accept json
 // @Produce json
 // @Param transaction body model.CreateTransactionRequest true "Transaction data"
-// @Success 200 {object} map[string]interface{} "Transaction created successfully"
+// @Success 201 {object} map[string]interface{} "ID of the created transaction"
+// @Success 200 {object} map[string]interface{} "ID of the existing transaction, with skipped true, when external_id was already imported"
 // @Failure 400 {object} map[string]interface{} "Invalid request data"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security ApiKeyAuth
@@ -64,32 +68,51 @@ func (h *Handler) CreateTransaction(c *gin.Context) {
 		UserID:          userID,
 		AmountPence:     amountPence,
 		TDate:           tDate,
-		Note:            model.StringToSQLNullString(request.Note),
+		Note:            model.StringToSQLNullString(model.TrimStringPtr(request.Note)),
 		SourceRecurring: sql.NullInt64{Valid: false}, // Manual transaction
+		ExternalID:      model.StringToSQLNullString(model.TrimStringPtr(request.ExternalID)),
 	}
 
-	// Create transaction in database
-	transaction, err := h.repo.CreateTransaction(c.Request.Context(), params)
-	if err != nil {
-		h.logger.Error("failed to create transaction", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "failed to create transaction",
-			"data":  nil,
+	// A blank reference is no reference
+	if params.ExternalID.String == "" {
+		params.ExternalID.Valid = false
+	}
+
+	// Skip transactions already imported under the same external reference,
+	// answering with the existing one so imports can be retried safely
+	if params.ExternalID.Valid {
+		existing, err := h.repo.GetTransactionByExternalID(c.Request.Context(), repo.GetTransactionByExternalIDParams{
+			UserID:     userID,
+			ExternalID: params.ExternalID,
 		})
-		return
+		if err == nil {
+			respondSkippedTransaction(c, existing.ID)
+			return
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			h.logger.Error("failed to look up transaction by external ID", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "failed to create transaction",
+				"data":  nil,
+			})
+			return
+		}
 	}
 
-	// Handle tag associations if provided
-	if len(request.TagIDs) > 0 {
+	// Create the transaction and its tag associations atomically
+	var transaction repo.Transaction
+	err = h.repo.WithTx(c.Request.Context(), func(txRepo repo.Repository) error {
+		var err error
+		transaction, err = txRepo.CreateTransaction(c.Request.Context(), params)
+		if err != nil {
+			h.logger.Error("failed to create transaction", zap.Error(err))
+			return err
+		}
+
 		for _, tagID := range request.TagIDs {
 			// Verify tag exists
-			_, err := h.repo.GetTagByID(c.Request.Context(), tagID)
-			if err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"error": "invalid tag ID: " + strconv.FormatInt(tagID, 10),
-					"data":  nil,
-				})
-				return
+			if _, err := txRepo.GetTagByID(c.Request.Context(), tagID); err != nil {
+				return &invalidTagError{tagID: tagID}
 			}
 
 			// Create transaction-tag association
@@ -97,19 +120,42 @@ func (h *Handler) CreateTransaction(c *gin.Context) {
 				TransactionID: transaction.ID,
 				TagID:         tagID,
 			}
-			err = h.repo.CreateTransactionTag(c.Request.Context(), tagParams)
-			if err != nil {
+			if err := txRepo.CreateTransactionTag(c.Request.Context(), tagParams); err != nil {
 				h.logger.Error("failed to associate tag with transaction", zap.Error(err), zap.Int64("tag_id", tagID))
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"error": "failed to associate tag with transaction",
-					"data":  nil,
-				})
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		var tagErr *invalidTagError
+		if errors.As(err, &tagErr) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": tagErr.Error(),
+				"data":  nil,
+			})
+			return
+		}
+		// A concurrent import of the same reference got in after the lookup
+		if params.ExternalID.Valid && repo.IsUniqueViolation(err) {
+			existing, err := h.repo.GetTransactionByExternalID(c.Request.Context(), repo.GetTransactionByExternalIDParams{
+				UserID:     userID,
+				ExternalID: params.ExternalID,
+			})
+			if err == nil {
+				respondSkippedTransaction(c, existing.ID)
 				return
 			}
+			h.logger.Error("failed to look up transaction by external ID", zap.Error(err))
 		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to create transaction",
+			"data":  nil,
+		})
+		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	c.JSON(http.StatusCreated, gin.H{
 		"data": gin.H{
 			"id": transaction.ID,
 		},
@@ -117,16 +163,34 @@ func (h *Handler) CreateTransaction(c *gin.Context) {
 	})
 }
 
+// respondSkippedTransaction answers a create whose external reference was
+// already imported with the existing transaction's ID
+func respondSkippedTransaction(c *gin.Context, id int64) {
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"id":      id,
+			"skipped": true,
+		},
+		"error": nil,
+	})
+}
+
 // GetTransactions handles GET /api/v1/transactions
 // @Summary Get transactions
-// @Description Get all transactions for the authenticated user, optionally filtered by date range
+// @Description Get all transactions for the authenticated user, optionally filtered by date range and by when they were entered
 // @Tags transactions
 // @Accept json
 // @Produce json
 // @Param from query string false "Start date (YYYY-MM-DD format)"
 // @Param to query string false "End date (YYYY-MM-DD format)"
-// @Success 200 {object} map[string]interface{} "List of transactions"
-// @Failure 400 {object} map[string]interface{} "Invalid date format"
+// @Param created_from query string false "Only transactions entered on or after this date (YYYY-MM-DD format)"
+// @Param created_to query string false "Only transactions entered on or before this date (YYYY-MM-DD format)"
+// @Param cleared query bool false "Only cleared (true) or uncleared (false) transactions"
+// @Param tags query string false "Comma-separated tag IDs, e.g. 1,2,3"
+// @Param tag_mode query string false "any (default) matches transactions with at least one of the tags, all those with every tag" Enums(any, all)
+// @Success 200 {object} map[string]interface{} "List of transactions, with the number returned in meta.count"
+// @Header 200 {integer} X-Total-Count "Number of transactions matching the filters"
+// @Failure 400 {object} map[string]interface{} "Invalid filter"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security ApiKeyAuth
 // @Router /transactions [get]
@@ -139,8 +203,7 @@ func (h *Handler) GetTransactions(c *gin.Context) {
 	// For now, use a default user ID of 1
 	userID := int64(1)
 
-	var transactions []repo.Transaction
-	var err error
+	var params repo.ListTransactionsParams
 
 	if from != "" && to != "" {
 		// Parse date range
@@ -163,27 +226,90 @@ func (h *Handler) GetTransactions(c *gin.Context) {
 		}
 
 		// Use date range query with proper parameters
-		params := repo.ListTransactionsParams{
+		params = repo.ListTransactionsParams{
 			UserID:  userID,
 			TDate:   fromDate,
 			Column3: nil, // This represents the "OR ? IS NULL" condition
 			TDate_2: toDate,
 			Column5: nil, // This represents the "OR ? IS NULL" condition
 		}
-		transactions, err = h.repo.ListTransactions(c.Request.Context(), params)
 	} else {
 		// Get all transactions for user (no date filtering)
 		// Use a very wide date range to get all transactions
-		params := repo.ListTransactionsParams{
+		params = repo.ListTransactionsParams{
 			UserID:  userID,
 			TDate:   time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC), // Very old date
 			Column3: nil,
 			TDate_2: time.Date(2100, 12, 31, 23, 59, 59, 0, time.UTC), // Very future date
 			Column5: nil,
 		}
-		transactions, err = h.repo.ListTransactions(c.Request.Context(), params)
 	}
 
+	// Filter on when transactions were entered, as opposed to their t_date
+	if createdFrom := c.Query("created_from"); createdFrom != "" {
+		createdFromDate, err := model.ParseDate(createdFrom)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid created_from date format",
+				"data":  nil,
+			})
+			return
+		}
+		params.CreatedFrom = sql.NullTime{Time: createdFromDate, Valid: true}
+	}
+	if createdTo := c.Query("created_to"); createdTo != "" {
+		createdToDate, err := model.ParseDate(createdTo)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid created_to date format",
+				"data":  nil,
+			})
+			return
+		}
+		// created_at has second precision, so this covers the whole day
+		params.CreatedTo = sql.NullTime{Time: createdToDate.Add(24*time.Hour - time.Second), Valid: true}
+	}
+
+	// Filter on whether transactions have been reconciled
+	if cleared := c.Query("cleared"); cleared != "" {
+		clearedValue, err := strconv.ParseBool(cleared)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "cleared must be true or false",
+				"data":  nil,
+			})
+			return
+		}
+		params.Cleared = sql.NullBool{Bool: clearedValue, Valid: true}
+	}
+
+	// Filter on tags, matching transactions with any or all of them
+	if tags := c.Query("tags"); tags != "" {
+		tagIDs, ok := parseTagIDList(tags)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "tags must be a comma-separated list of tag IDs",
+				"data":  nil,
+			})
+			return
+		}
+		params.MinTagMatches = 1
+		switch c.DefaultQuery("tag_mode", "any") {
+		case "any":
+		case "all":
+			params.MinTagMatches = int64(len(tagIDs))
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "tag_mode must be all or any",
+				"data":  nil,
+			})
+			return
+		}
+		encoded, _ := json.Marshal(tagIDs)
+		params.TagIds = sql.NullString{String: string(encoded), Valid: true}
+	}
+
+	transactions, err := h.repo.ListTransactions(c.Request.Context(), params)
 	if err != nil {
 		h.logger.Error("failed to fetch transactions", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -193,6 +319,17 @@ func (h *Handler) GetTransactions(c *gin.Context) {
 		return
 	}
 
+	// Count with the same filters so paginated clients know the full size
+	total, err := h.repo.CountTransactions(c.Request.Context(), repo.CountTransactionsParams(params))
+	if err != nil {
+		h.logger.Error("failed to count transactions", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to count transactions",
+			"data":  nil,
+		})
+		return
+	}
+
 	// Convert to response DTOs
 	response := make([]model.TransactionResponse, len(transactions))
 	for i, txn := range transactions {
@@ -214,26 +351,32 @@ func (h *Handler) GetTransactions(c *gin.Context) {
 		}
 
 		response[i] = model.TransactionResponse{
-			ID:             txn.ID,
-			Amount:         model.PenceToCurrency(txn.AmountPence),
-			TDate:          model.FormatDate(txn.TDate),
-			Note:           model.SQLNullStringToString(txn.Note),
-			CreatedAt:      txn.CreatedAt.Time,
+			ID:              txn.ID,
+			Amount:          model.PenceToCurrency(txn.AmountPence),
+			TDate:           model.FormatDate(txn.TDate),
+			Note:            model.SQLNullStringToString(txn.Note),
+			CreatedAt:       txn.CreatedAt.Time,
 			SourceRecurring: model.SQLNullInt64ToInt64(txn.SourceRecurring),
-			DeletedAt:      model.SQLNullTimeToTimePtr(txn.DeletedAt),
-			TagIDs:         tagIDs,
+			DeletedAt:       model.SQLNullTimeToTimePtr(txn.DeletedAt),
+			TagIDs:          tagIDs,
+			Version:         txn.Version,
+			ExternalID:      model.SQLNullStringToString(txn.ExternalID),
+			Cleared:         txn.Cleared,
 		}
 	}
 
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
 	c.JSON(http.StatusOK, gin.H{
 		"data":  response,
 		"error": nil,
+		"total": total,
+		"meta":  model.ListMeta{Count: len(response)},
 	})
 }
 
 // UpdateTransaction handles PATCH /api/v1/transactions/{id}
 // @Summary Update a transaction
-// @Description Update an existing transaction's note, tags, or soft delete it
+// @Description Update an existing transaction's note, tags or cleared flag, or soft delete it
 // @Tags transactions
 // @Accept json
 // @Produce json
@@ -243,6 +386,7 @@ func (h *Handler) GetTransactions(c *gin.Context) {
 // @Success 204 "Transaction deleted successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid request data"
 // @Failure 404 {object} map[string]interface{} "Transaction not found"
+// @Failure 409 {object} map[string]interface{} "Version does not match the stored transaction"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security ApiKeyAuth
 // @Router /transactions/{id} [patch]
@@ -286,6 +430,15 @@ func (h *Handler) UpdateTransaction(c *gin.Context) {
 		return
 	}
 
+	// Reject edits made against an outdated copy of the transaction
+	if request.Version != nil && *request.Version != transaction.Version {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "transaction has been modified, reload and try again",
+			"data":  nil,
+		})
+		return
+	}
+
 	// Handle soft delete if requested
 	if request.Deleted != nil && *request.Deleted {
 		err = h.repo.SoftDeleteTransaction(c.Request.Context(), id)
@@ -311,11 +464,29 @@ func (h *Handler) UpdateTransaction(c *gin.Context) {
 
 	// Update note if provided
 	if request.Note != nil {
-		updateParams.Note = model.StringToSQLNullString(request.Note)
+		updateParams.Note = model.StringToSQLNullString(model.TrimStringPtr(request.Note))
+	}
+
+	// Update cleared if provided
+	if request.Cleared != nil {
+		updateParams.Cleared = sql.NullBool{Bool: *request.Cleared, Valid: true}
+	}
+
+	// The version is checked again in the UPDATE itself so a write that lands
+	// between the fetch above and this statement is still detected
+	if request.Version != nil {
+		updateParams.ExpectedVersion = sql.NullInt64{Int64: *request.Version, Valid: true}
 	}
 
 	// Update transaction
 	_, err = h.repo.UpdateTransaction(c.Request.Context(), updateParams)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "transaction has been modified, reload and try again",
+			"data":  nil,
+		})
+		return
+	}
 	if err != nil {
 		h.logger.Error("failed to update transaction", zap.Error(err), zap.Int64("id", id))
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -370,6 +541,186 @@ func (h *Handler) UpdateTransaction(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// MakeTransactionRecurring handles POST /api/v1/transactions/{id}/make-recurring
+// @Summary Convert a transaction into a recurring rule
+// @Description Create a recurring rule with the transaction's amount, note and tags on the given schedule
+// @Tags transactions
+// @Accept json
+// @Produce json
+// @Param id path int true "Transaction ID"
+// @Param schedule body model.MakeRecurringRequest true "Recurring schedule"
+// @Param backfill query bool false "Allow a first_due_date more than 90 days (or the recurring_max_backfill_days setting) ago"
+// @Success 201 {object} map[string]interface{} "ID of the created recurring rule"
+// @Failure 400 {object} map[string]interface{} "Invalid request data"
+// @Failure 404 {object} map[string]interface{} "Transaction not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /transactions/{id}/make-recurring [post]
+func (h *Handler) MakeTransactionRecurring(c *gin.Context) {
+	// Get transaction ID from URL
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid transaction ID",
+			"data":  nil,
+		})
+		return
+	}
+
+	// Get the validated request from context
+	request, ok := GetValidatedRequest[model.MakeRecurringRequest](c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to get validated request",
+			"data":  nil,
+		})
+		return
+	}
+
+	firstDueDate, err := model.ParseDate(request.FirstDueDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid first_due_date format",
+			"data":  nil,
+		})
+		return
+	}
+
+	var endDate sql.NullTime
+	if request.EndDate != nil {
+		parsedEndDate, err := model.ParseDate(*request.EndDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid end_date format",
+				"data":  nil,
+			})
+			return
+		}
+		endDate = sql.NullTime{Time: parsedEndDate, Valid: true}
+	}
+
+	transaction, err := h.repo.GetTransactionByID(c.Request.Context(), id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "transaction not found",
+				"data":  nil,
+			})
+			return
+		}
+		h.logger.Error("failed to fetch transaction", zap.Error(err), zap.Int64("id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch transaction",
+			"data":  nil,
+		})
+		return
+	}
+
+	// Starting far in the past would backfill every missed occurrence
+	if h.rejectLongBackfill(c, transaction.UserID, firstDueDate) {
+		return
+	}
+
+	tags, err := h.repo.GetTransactionTags(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("failed to fetch transaction tags", zap.Error(err), zap.Int64("transaction_id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch transaction tags",
+			"data":  nil,
+		})
+		return
+	}
+	tagIDs := make([]int64, len(tags))
+	for i, tag := range tags {
+		tagIDs[i] = tag.ID
+	}
+
+	params := repo.CreateRecurringParams{
+		UserID:       transaction.UserID,
+		AmountPence:  transaction.AmountPence,
+		Description:  transaction.Note,
+		Frequency:    request.Frequency,
+		IntervalN:    int64(request.IntervalN),
+		FirstDueDate: firstDueDate,
+		NextDueDate:  firstDueDate,
+		EndDate:      endDate,
+		Active:       true,
+	}
+
+	recurring, err := h.createRecurringWithTags(c, params, tagIDs)
+	if err != nil {
+		h.logger.Error("failed to create recurring rule from transaction", zap.Error(err), zap.Int64("transaction_id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to create recurring rule",
+			"data":  nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": gin.H{
+			"id": recurring.ID,
+		},
+		"error": nil,
+	})
+}
+
+// ToggleTransactionCleared handles PATCH /api/v1/transactions/{id}/toggle-cleared
+// @Summary Toggle transaction cleared status
+// @Description Mark a transaction as cleared against a bank statement, or uncleared if it already was
+// @Tags transactions
+// @Produce json
+// @Param id path int true "Transaction ID"
+// @Success 204 "Cleared status toggled"
+// @Failure 400 {object} map[string]interface{} "Invalid transaction ID"
+// @Failure 404 {object} map[string]interface{} "Transaction not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /transactions/{id}/toggle-cleared [patch]
+func (h *Handler) ToggleTransactionCleared(c *gin.Context) {
+	// Parse ID from URL
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid transaction ID",
+			"data":  nil,
+		})
+		return
+	}
+
+	// Check if transaction exists
+	if _, err := h.repo.GetTransactionByID(c.Request.Context(), id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "transaction not found",
+				"data":  nil,
+			})
+			return
+		}
+		h.logger.Error("failed to fetch transaction", zap.Error(err), zap.Int64("id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch transaction",
+			"data":  nil,
+		})
+		return
+	}
+
+	// TODO: Check if user has access to this transaction when authentication is implemented
+
+	if err := h.repo.ToggleTransactionCleared(c.Request.Context(), id); err != nil {
+		h.logger.Error("failed to toggle transaction cleared status", zap.Error(err), zap.Int64("id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to toggle transaction cleared status",
+			"data":  nil,
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 // GetTransactionByID handles GET /api/v1/transactions/{id}
 // @Summary Get transaction by ID
 // @Description Get a specific transaction by its ID
@@ -432,14 +783,17 @@ func (h *Handler) GetTransactionByID(c *gin.Context) {
 
 	// Convert to response DTO
 	response := model.TransactionResponse{
-		ID:             transaction.ID,
-		Amount:         model.PenceToCurrency(transaction.AmountPence),
-		TDate:          model.FormatDate(transaction.TDate),
-		Note:           model.SQLNullStringToString(transaction.Note),
-		CreatedAt:      transaction.CreatedAt.Time,
+		ID:              transaction.ID,
+		Amount:          model.PenceToCurrency(transaction.AmountPence),
+		TDate:           model.FormatDate(transaction.TDate),
+		Note:            model.SQLNullStringToString(transaction.Note),
+		CreatedAt:       transaction.CreatedAt.Time,
 		SourceRecurring: model.SQLNullInt64ToInt64(transaction.SourceRecurring),
-		DeletedAt:      model.SQLNullTimeToTimePtr(transaction.DeletedAt),
-		TagIDs:         tagIDs,
+		DeletedAt:       model.SQLNullTimeToTimePtr(transaction.DeletedAt),
+		TagIDs:          tagIDs,
+		Version:         transaction.Version,
+		ExternalID:      model.SQLNullStringToString(transaction.ExternalID),
+		Cleared:         transaction.Cleared,
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -505,14 +859,17 @@ func (h *Handler) GetTransactionsByRecurringID(c *gin.Context) {
 		}
 
 		response[i] = model.TransactionResponse{
-			ID:             txn.ID,
-			Amount:         model.PenceToCurrency(txn.AmountPence),
-			TDate:          model.FormatDate(txn.TDate),
-			Note:           model.SQLNullStringToString(txn.Note),
-			CreatedAt:      txn.CreatedAt.Time,
+			ID:              txn.ID,
+			Amount:          model.PenceToCurrency(txn.AmountPence),
+			TDate:           model.FormatDate(txn.TDate),
+			Note:            model.SQLNullStringToString(txn.Note),
+			CreatedAt:       txn.CreatedAt.Time,
 			SourceRecurring: model.SQLNullInt64ToInt64(txn.SourceRecurring),
-			DeletedAt:      model.SQLNullTimeToTimePtr(txn.DeletedAt),
-			TagIDs:         tagIDs,
+			DeletedAt:       model.SQLNullTimeToTimePtr(txn.DeletedAt),
+			TagIDs:          tagIDs,
+			Version:         txn.Version,
+			ExternalID:      model.SQLNullStringToString(txn.ExternalID),
+			Cleared:         txn.Cleared,
 		}
 	}
 
@@ -596,14 +953,17 @@ func (h *Handler) GetTransactionsByTag(c *gin.Context) {
 		}
 
 		response[i] = model.TransactionResponse{
-			ID:             txn.ID,
-			Amount:         model.PenceToCurrency(txn.AmountPence),
-			TDate:          model.FormatDate(txn.TDate),
-			Note:           model.SQLNullStringToString(txn.Note),
-			CreatedAt:      txn.CreatedAt.Time,
+			ID:              txn.ID,
+			Amount:          model.PenceToCurrency(txn.AmountPence),
+			TDate:           model.FormatDate(txn.TDate),
+			Note:            model.SQLNullStringToString(txn.Note),
+			CreatedAt:       txn.CreatedAt.Time,
 			SourceRecurring: model.SQLNullInt64ToInt64(txn.SourceRecurring),
-			DeletedAt:      model.SQLNullTimeToTimePtr(txn.DeletedAt),
-			TagIDs:         tagIDs,
+			DeletedAt:       model.SQLNullTimeToTimePtr(txn.DeletedAt),
+			TagIDs:          tagIDs,
+			Version:         txn.Version,
+			ExternalID:      model.SQLNullStringToString(txn.ExternalID),
+			Cleared:         txn.Cleared,
 		}
 	}
 
@@ -613,17 +973,240 @@ func (h *Handler) GetTransactionsByTag(c *gin.Context) {
 	})
 }
 
-// HardDeleteTransaction handles DELETE /api/v1/transactions/{id}
-func (h *Handler) HardDeleteTransaction(c *gin.Context) {
-	// Get transaction ID from URL
-	idStr := c.Param("id")
-	id, err := strconv.ParseInt(idStr, 10, 64)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "invalid transaction ID",
-			"data":  nil,
-		})
-		return
+// GetTransactionsGroupedByTag handles GET /api/v1/transactions/by-tag-grouped
+// @Summary Get transactions grouped by tag
+// @Description Get the user's transactions grouped by tag, optionally filtered by date range. A transaction with several tags appears in each of their groups; transactions without tags are returned in a final "untagged" group.
+// @Tags transactions
+// @Accept json
+// @Produce json
+// @Param from query string false "Start date (YYYY-MM-DD format)"
+// @Param to query string false "End date (YYYY-MM-DD format)"
+// @Success 200 {array} model.TagTransactionsGroup "Transactions grouped by tag"
+// @Failure 400 {object} map[string]interface{} "Invalid date format"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /transactions/by-tag-grouped [get]
+func (h *Handler) GetTransactionsGroupedByTag(c *gin.Context) {
+	// TODO: Get user ID from context when authentication is implemented
+	// For now, use a default user ID of 1
+	userID := int64(1)
+
+	// Parse optional date range, defaulting to a very wide one
+	fromDate := time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(2100, 12, 31, 23, 59, 59, 0, time.UTC)
+	if from := c.Query("from"); from != "" {
+		parsed, err := model.ParseDate(from)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid from date format",
+				"data":  nil,
+			})
+			return
+		}
+		fromDate = parsed
+	}
+	if to := c.Query("to"); to != "" {
+		parsed, err := model.ParseDate(to)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid to date format",
+				"data":  nil,
+			})
+			return
+		}
+		toDate = parsed
+	}
+
+	// Fetch the transactions and all their tag links in two queries
+	transactions, err := h.repo.ListTransactions(c.Request.Context(), repo.ListTransactionsParams{
+		UserID:  userID,
+		TDate:   fromDate,
+		Column3: nil,
+		TDate_2: toDate,
+		Column5: nil,
+	})
+	if err != nil {
+		h.logger.Error("failed to fetch transactions", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch transactions",
+			"data":  nil,
+		})
+		return
+	}
+
+	links, err := h.repo.ListTransactionTagsByDateRange(c.Request.Context(), repo.ListTransactionTagsByDateRangeParams{
+		UserID:  userID,
+		TDate:   fromDate,
+		TDate_2: toDate,
+	})
+	if err != nil {
+		h.logger.Error("failed to fetch transaction tags", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch transaction tags",
+			"data":  nil,
+		})
+		return
+	}
+
+	// Build one group per tag, ordered by tag name
+	groups := make([]model.TagTransactionsGroup, 0)
+	groupIndex := make(map[int64]int)
+	tagIDsByTransaction := make(map[int64][]int64)
+	for _, link := range links {
+		tagIDsByTransaction[link.TransactionID] = append(tagIDsByTransaction[link.TransactionID], link.ID)
+		if _, ok := groupIndex[link.ID]; !ok {
+			tagID := link.ID
+			groupIndex[link.ID] = len(groups)
+			groups = append(groups, model.TagTransactionsGroup{
+				TagID:        &tagID,
+				TagName:      link.Name,
+				Transactions: make([]model.TransactionResponse, 0),
+			})
+		}
+	}
+
+	untagged := model.TagTransactionsGroup{
+		TagName:      "untagged",
+		Transactions: make([]model.TransactionResponse, 0),
+	}
+	for _, txn := range transactions {
+		tagIDs := tagIDsByTransaction[txn.ID]
+		if tagIDs == nil {
+			tagIDs = make([]int64, 0)
+		}
+
+		response := model.TransactionResponse{
+			ID:              txn.ID,
+			Amount:          model.PenceToCurrency(txn.AmountPence),
+			TDate:           model.FormatDate(txn.TDate),
+			Note:            model.SQLNullStringToString(txn.Note),
+			CreatedAt:       txn.CreatedAt.Time,
+			SourceRecurring: model.SQLNullInt64ToInt64(txn.SourceRecurring),
+			DeletedAt:       model.SQLNullTimeToTimePtr(txn.DeletedAt),
+			TagIDs:          tagIDs,
+			Version:         txn.Version,
+			ExternalID:      model.SQLNullStringToString(txn.ExternalID),
+			Cleared:         txn.Cleared,
+		}
+
+		if len(tagIDs) == 0 {
+			untagged.Transactions = append(untagged.Transactions, response)
+			continue
+		}
+		for _, tagID := range tagIDs {
+			group := &groups[groupIndex[tagID]]
+			group.Transactions = append(group.Transactions, response)
+		}
+	}
+	groups = append(groups, untagged)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  groups,
+		"error": nil,
+	})
+}
+
+// maxActivityDays bounds the range GetTransactionActivity reports on
+const maxActivityDays = 366
+
+// GetTransactionActivity handles GET /api/v1/transactions/activity
+// @Summary Get days with transactions
+// @Description Get each date in the range that has at least one transaction, with that day's transaction count and net amount, for a calendar heatmap. Days without transactions are not listed. The range may span up to 366 days.
+// @Tags transactions
+// @Produce json
+// @Param from query string false "Start date (YYYY-MM-DD, defaults to the first of the current month)"
+// @Param to query string false "End date (YYYY-MM-DD, defaults to today)"
+// @Success 200 {object} model.ActivityResponse "Days with transactions"
+// @Failure 400 {object} map[string]interface{} "Invalid date range"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /transactions/activity [get]
+func (h *Handler) GetTransactionActivity(c *gin.Context) {
+	now := h.clock.Now()
+	fromStr := c.DefaultQuery("from", now.Format("2006-01")+"-01")
+	toStr := c.DefaultQuery("to", model.FormatDate(now))
+
+	from, err := model.ParseDate(fromStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid from date format. Use YYYY-MM-DD",
+			"data":  nil,
+		})
+		return
+	}
+	to, err := model.ParseDate(toStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid to date format. Use YYYY-MM-DD",
+			"data":  nil,
+		})
+		return
+	}
+	if to.Before(from) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "to date must not be before from date",
+			"data":  nil,
+		})
+		return
+	}
+	if to.After(from.AddDate(0, 0, maxActivityDays-1)) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "date range must not exceed " + strconv.Itoa(maxActivityDays) + " days",
+			"data":  nil,
+		})
+		return
+	}
+
+	// TODO: Get user ID from context when authentication is implemented
+	// For now, use a default user ID of 1
+	userID := int64(1)
+
+	rows, err := h.repo.ListDailyActivity(c.Request.Context(), repo.ListDailyActivityParams{
+		UserID:   userID,
+		FromDate: model.FormatDate(from),
+		ToDate:   model.FormatDate(to),
+	})
+	if err != nil {
+		h.logger.Error("failed to fetch transaction activity", zap.Error(err),
+			zap.String("from", fromStr), zap.String("to", toStr))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch transaction activity",
+			"data":  nil,
+		})
+		return
+	}
+
+	response := model.ActivityResponse{
+		From: model.FormatDate(from),
+		To:   model.FormatDate(to),
+		Days: make([]model.DailyActivity, len(rows)),
+	}
+	for i, row := range rows {
+		response.Days[i] = model.DailyActivity{
+			Date:             row.Day,
+			TransactionCount: row.TransactionCount,
+			Net:              model.PenceToCurrency(row.NetPence),
+			NetPence:         row.NetPence,
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// HardDeleteTransaction handles DELETE /api/v1/transactions/{id}
+func (h *Handler) HardDeleteTransaction(c *gin.Context) {
+	// Get transaction ID from URL
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid transaction ID",
+			"data":  nil,
+		})
+		return
 	}
 
 	// Check if transaction exists
@@ -644,8 +1227,13 @@ func (h *Handler) HardDeleteTransaction(c *gin.Context) {
 		return
 	}
 
-	// Hard delete transaction
-	err = h.repo.HardDeleteTransaction(c.Request.Context(), id)
+	// Hard delete transaction along with its tag links
+	err = h.repo.WithTx(c.Request.Context(), func(txRepo repo.Repository) error {
+		if err := txRepo.DeleteAllTransactionTags(c.Request.Context(), id); err != nil {
+			return err
+		}
+		return txRepo.HardDeleteTransaction(c.Request.Context(), id)
+	})
 	if err != nil {
 		h.logger.Error("failed to hard delete transaction", zap.Error(err), zap.Int64("id", id))
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -660,7 +1248,7 @@ func (h *Handler) HardDeleteTransaction(c *gin.Context) {
 
 // PurgeSoftDeletedTransactions handles POST /api/v1/transactions/purge
 // @Summary Purge soft deleted transactions
-// @Description Permanently delete transactions that were soft deleted before a specified date
+// @Description Permanently delete the user's transactions that were soft deleted before a specified date
 // @Tags transactions
 // @Accept json
 // @Produce json
@@ -691,9 +1279,25 @@ func (h *Handler) PurgeSoftDeletedTransactions(c *gin.Context) {
 		return
 	}
 
-	// Purge soft deleted transactions
-	deletedAt := sql.NullTime{Time: cutoffDate, Valid: true}
-	err = h.repo.PurgeSoftDeletedTransactions(c.Request.Context(), deletedAt)
+	// TODO: Get user ID from context when authentication is implemented
+	// For now, use a default user ID of 1
+	userID := int64(1)
+
+	// Purge the user's soft deleted transactions along with their tag links
+	err = h.repo.WithTx(c.Request.Context(), func(txRepo repo.Repository) error {
+		cutoff := sql.NullTime{Time: cutoffDate, Valid: true}
+		if err := txRepo.PurgeSoftDeletedTransactionTags(c.Request.Context(), repo.PurgeSoftDeletedTransactionTagsParams{
+			UserID:    userID,
+			DeletedAt: cutoff,
+		}); err != nil {
+			return err
+		}
+		_, err := txRepo.PurgeSoftDeletedTransactions(c.Request.Context(), repo.PurgeSoftDeletedTransactionsParams{
+			UserID:    userID,
+			DeletedAt: cutoff,
+		})
+		return err
+	})
 	if err != nil {
 		h.logger.Error("failed to purge soft deleted transactions", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -709,4 +1313,508 @@ func (h *Handler) PurgeSoftDeletedTransactions(c *gin.Context) {
 		},
 		"error": nil,
 	})
-} 
\ No newline at end of file
+}
+
+// ClearTransactions handles POST /api/v1/transactions/clear
+// @Summary Clear all transactions
+// @Description Soft delete all of the user's transactions (recurring rules are kept). Requires an explicit confirm flag.
+// @Tags transactions
+// @Accept json
+// @Produce json
+// @Param request body model.ClearTransactionsRequest true "Confirmation"
+// @Success 200 {object} map[string]interface{} "Number of transactions cleared"
+// @Failure 400 {object} map[string]interface{} "Missing confirmation"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /transactions/clear [post]
+func (h *Handler) ClearTransactions(c *gin.Context) {
+	// Get the validated request from context
+	request, ok := GetValidatedRequest[model.ClearTransactionsRequest](c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to get validated request",
+			"data":  nil,
+		})
+		return
+	}
+
+	if !request.Confirm {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "confirm must be true to clear all transactions",
+			"data":  nil,
+		})
+		return
+	}
+
+	// TODO: Get user ID from context when authentication is implemented
+	// For now, use a default user ID of 1
+	userID := int64(1)
+
+	// Soft delete all live transactions for the user
+	var cleared int64
+	err := h.repo.WithTx(c.Request.Context(), func(txRepo repo.Repository) error {
+		var err error
+		cleared, err = txRepo.SoftDeleteAllTransactionsByUser(c.Request.Context(), userID)
+		return err
+	})
+	if err != nil {
+		h.logger.Error("failed to clear transactions", zap.Error(err), zap.Int64("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to clear transactions",
+			"data":  nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  model.ClearTransactionsResponse{Cleared: cleared},
+		"error": nil,
+	})
+}
+
+// ReconcileTransactions handles POST /api/v1/transactions/reconcile
+// @Summary Mark transactions cleared or uncleared
+// @Description Set the cleared flag on many transactions at once. Every ID must belong to the user; if any does not, nothing is changed.
+// @Tags transactions
+// @Accept json
+// @Produce json
+// @Param request body model.ReconcileTransactionsRequest true "Transaction IDs and cleared state"
+// @Success 200 {object} model.ReconcileTransactionsResponse "Number of transactions updated"
+// @Failure 400 {object} map[string]interface{} "Invalid request body"
+// @Failure 404 {object} map[string]interface{} "Transaction not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /transactions/reconcile [post]
+func (h *Handler) ReconcileTransactions(c *gin.Context) {
+	// Get the validated request from context
+	request, ok := GetValidatedRequest[model.ReconcileTransactionsRequest](c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to get validated request",
+			"data":  nil,
+		})
+		return
+	}
+
+	// TODO: Get user ID from context when authentication is implemented
+	// For now, use a default user ID of 1
+	userID := int64(1)
+
+	cleared := *request.Cleared
+	var updated int64
+	err := h.repo.WithTx(c.Request.Context(), func(txRepo repo.Repository) error {
+		seen := make(map[int64]bool, len(request.TransactionIDs))
+		for _, id := range request.TransactionIDs {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			transaction, err := txRepo.GetTransactionByID(c.Request.Context(), id)
+			if errors.Is(err, sql.ErrNoRows) || (err == nil && transaction.UserID != userID) {
+				return &transactionNotFoundError{transactionID: id}
+			}
+			if err != nil {
+				return err
+			}
+
+			if err := txRepo.SetTransactionCleared(c.Request.Context(), repo.SetTransactionClearedParams{
+				Cleared: cleared,
+				ID:      id,
+			}); err != nil {
+				return err
+			}
+			updated++
+		}
+		return nil
+	})
+	if err != nil {
+		var notFound *transactionNotFoundError
+		if errors.As(err, &notFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": notFound.Error(),
+				"data":  nil,
+			})
+			return
+		}
+		h.logger.Error("failed to reconcile transactions", zap.Error(err), zap.Int64("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to reconcile transactions",
+			"data":  nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  model.ReconcileTransactionsResponse{Updated: updated, Cleared: cleared},
+		"error": nil,
+	})
+}
+
+// BulkDeleteTransactions handles POST /api/v1/transactions/bulk-delete
+// @Summary Soft delete many transactions
+// @Description Soft delete several transactions at once. Every ID must be a live transaction of the user; if any is not, nothing is deleted.
+// @Tags transactions
+// @Accept json
+// @Produce json
+// @Param request body model.BulkDeleteTransactionsRequest true "Transaction IDs"
+// @Success 200 {object} model.BulkDeleteTransactionsResponse "Number of transactions deleted"
+// @Failure 400 {object} map[string]interface{} "Invalid request body"
+// @Failure 404 {object} map[string]interface{} "Transaction not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /transactions/bulk-delete [post]
+func (h *Handler) BulkDeleteTransactions(c *gin.Context) {
+	// Get the validated request from context
+	request, ok := GetValidatedRequest[model.BulkDeleteTransactionsRequest](c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to get validated request",
+			"data":  nil,
+		})
+		return
+	}
+
+	// TODO: Get user ID from context when authentication is implemented
+	// For now, use a default user ID of 1
+	userID := int64(1)
+
+	var deleted int64
+	err := h.repo.WithTx(c.Request.Context(), func(txRepo repo.Repository) error {
+		seen := make(map[int64]bool, len(request.TransactionIDs))
+		for _, id := range request.TransactionIDs {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			transaction, err := txRepo.GetTransactionByID(c.Request.Context(), id)
+			if errors.Is(err, sql.ErrNoRows) || (err == nil && transaction.UserID != userID) {
+				return &transactionNotFoundError{transactionID: id}
+			}
+			if err != nil {
+				return err
+			}
+
+			if err := txRepo.SoftDeleteTransaction(c.Request.Context(), id); err != nil {
+				return err
+			}
+			deleted++
+		}
+		return nil
+	})
+	if err != nil {
+		var notFound *transactionNotFoundError
+		if errors.As(err, &notFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": notFound.Error(),
+				"data":  nil,
+			})
+			return
+		}
+		h.logger.Error("failed to bulk delete transactions", zap.Error(err), zap.Int64("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to delete transactions",
+			"data":  nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  model.BulkDeleteTransactionsResponse{Deleted: deleted},
+		"error": nil,
+	})
+}
+
+// EmptyTrash handles POST /api/v1/transactions/trash/empty
+// @Summary Empty the transaction trash
+// @Description Permanently delete all of the user's soft-deleted transactions, regardless of when they were deleted
+// @Tags transactions
+// @Produce json
+// @Success 200 {object} model.EmptyTrashResponse "Number of transactions purged"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /transactions/trash/empty [post]
+func (h *Handler) EmptyTrash(c *gin.Context) {
+	// TODO: Get user ID from context when authentication is implemented
+	// For now, use a default user ID of 1
+	userID := int64(1)
+
+	var purged int64
+	err := h.repo.WithTx(c.Request.Context(), func(txRepo repo.Repository) error {
+		if err := txRepo.PurgeAllSoftDeletedTags(c.Request.Context(), userID); err != nil {
+			return err
+		}
+		var err error
+		purged, err = txRepo.PurgeAllSoftDeleted(c.Request.Context(), userID)
+		return err
+	})
+	if err != nil {
+		h.logger.Error("failed to empty trash", zap.Error(err), zap.Int64("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to empty trash",
+			"data":  nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  model.EmptyTrashResponse{Purged: purged},
+		"error": nil,
+	})
+}
+
+// PurgeSoftDeletedTransaction handles DELETE /api/v1/transactions/:id/purge
+// @Summary Purge one soft deleted transaction
+// @Description Permanently delete a single soft-deleted transaction straight away. Live transactions cannot be purged.
+// @Tags transactions
+// @Produce json
+// @Param id path int true "Transaction ID"
+// @Success 204 "Transaction purged"
+// @Failure 400 {object} map[string]interface{} "Invalid transaction ID"
+// @Failure 404 {object} map[string]interface{} "Soft deleted transaction not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /transactions/{id}/purge [delete]
+func (h *Handler) PurgeSoftDeletedTransaction(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid transaction ID",
+			"data":  nil,
+		})
+		return
+	}
+
+	// TODO: Get user ID from context when authentication is implemented
+	// For now, use a default user ID of 1
+	userID := int64(1)
+
+	var purged int64
+	err = h.repo.WithTx(c.Request.Context(), func(txRepo repo.Repository) error {
+		if err := txRepo.PurgeSoftDeletedTransactionTagsByID(c.Request.Context(), repo.PurgeSoftDeletedTransactionTagsByIDParams{
+			ID:     id,
+			UserID: userID,
+		}); err != nil {
+			return err
+		}
+		var err error
+		purged, err = txRepo.PurgeSoftDeletedTransaction(c.Request.Context(), repo.PurgeSoftDeletedTransactionParams{
+			ID:     id,
+			UserID: userID,
+		})
+		return err
+	})
+	if err != nil {
+		h.logger.Error("failed to purge transaction", zap.Error(err), zap.Int64("id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to purge transaction",
+			"data":  nil,
+		})
+		return
+	}
+
+	// Live transactions, other users' and unknown IDs all delete nothing
+	if purged == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "soft deleted transaction not found",
+			"data":  nil,
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AddTransactionTag handles POST /api/v1/transactions/:id/tags/:tag_id
+// @Summary Add a tag to a transaction
+// @Description Attach one tag to a transaction, leaving its other tags in place. Adding a tag the transaction already has is a no-op.
+// @Tags transactions
+// @Produce json
+// @Param id path int true "Transaction ID"
+// @Param tag_id path int true "Tag ID"
+// @Success 200 {object} map[string]interface{} "The transaction's tag IDs after the change"
+// @Failure 400 {object} map[string]interface{} "Invalid transaction or tag ID"
+// @Failure 404 {object} map[string]interface{} "Transaction or tag not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /transactions/{id}/tags/{tag_id} [post]
+func (h *Handler) AddTransactionTag(c *gin.Context) {
+	id, tagID, ok := h.parseTransactionTagParams(c)
+	if !ok {
+		return
+	}
+
+	err := h.repo.CreateTransactionTag(c.Request.Context(), repo.CreateTransactionTagParams{
+		TransactionID: id,
+		TagID:         tagID,
+	})
+	if err != nil {
+		h.logger.Error("failed to add transaction tag", zap.Error(err), zap.Int64("id", id), zap.Int64("tag_id", tagID))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to add tag to transaction",
+			"data":  nil,
+		})
+		return
+	}
+
+	h.respondTransactionTagIDs(c, id)
+}
+
+// RemoveTransactionTag handles DELETE /api/v1/transactions/:id/tags/:tag_id
+// @Summary Remove a tag from a transaction
+// @Description Detach one tag from a transaction, leaving its other tags in place
+// @Tags transactions
+// @Produce json
+// @Param id path int true "Transaction ID"
+// @Param tag_id path int true "Tag ID"
+// @Success 204 "Tag removed"
+// @Failure 400 {object} map[string]interface{} "Invalid transaction or tag ID"
+// @Failure 404 {object} map[string]interface{} "Transaction or tag not found, or tag not on the transaction"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /transactions/{id}/tags/{tag_id} [delete]
+func (h *Handler) RemoveTransactionTag(c *gin.Context) {
+	id, tagID, ok := h.parseTransactionTagParams(c)
+	if !ok {
+		return
+	}
+
+	tags, err := h.repo.GetTransactionTags(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("failed to fetch transaction tags", zap.Error(err), zap.Int64("id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch transaction tags",
+			"data":  nil,
+		})
+		return
+	}
+	attached := false
+	for _, tag := range tags {
+		if tag.ID == tagID {
+			attached = true
+			break
+		}
+	}
+	if !attached {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "tag is not on this transaction",
+			"data":  nil,
+		})
+		return
+	}
+
+	err = h.repo.DeleteTransactionTag(c.Request.Context(), repo.DeleteTransactionTagParams{
+		TransactionID: id,
+		TagID:         tagID,
+	})
+	if err != nil {
+		h.logger.Error("failed to remove transaction tag", zap.Error(err), zap.Int64("id", id), zap.Int64("tag_id", tagID))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to remove tag from transaction",
+			"data":  nil,
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseTransactionTagParams parses the :id and :tag_id parameters and checks
+// both the transaction and the tag exist, writing an error response when not
+func (h *Handler) parseTransactionTagParams(c *gin.Context) (int64, int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid transaction ID",
+			"data":  nil,
+		})
+		return 0, 0, false
+	}
+	tagID, err := strconv.ParseInt(c.Param("tag_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid tag ID",
+			"data":  nil,
+		})
+		return 0, 0, false
+	}
+
+	// TODO: Check if user has access to this transaction when authentication is implemented
+	if _, err := h.repo.GetTransactionByID(c.Request.Context(), id); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "transaction not found",
+				"data":  nil,
+			})
+			return 0, 0, false
+		}
+		h.logger.Error("failed to fetch transaction", zap.Error(err), zap.Int64("id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch transaction",
+			"data":  nil,
+		})
+		return 0, 0, false
+	}
+
+	if _, err := h.repo.GetTagByID(c.Request.Context(), tagID); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "tag not found",
+				"data":  nil,
+			})
+			return 0, 0, false
+		}
+		h.logger.Error("failed to fetch tag", zap.Error(err), zap.Int64("tag_id", tagID))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch tag",
+			"data":  nil,
+		})
+		return 0, 0, false
+	}
+	return id, tagID, true
+}
+
+// respondTransactionTagIDs answers with the tag IDs now on the transaction
+func (h *Handler) respondTransactionTagIDs(c *gin.Context, id int64) {
+	tags, err := h.repo.GetTransactionTags(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("failed to fetch transaction tags", zap.Error(err), zap.Int64("id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to fetch transaction tags",
+			"data":  nil,
+		})
+		return
+	}
+
+	tagIDs := make([]int64, len(tags))
+	for i, tag := range tags {
+		tagIDs[i] = tag.ID
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"id":      id,
+			"tag_ids": tagIDs,
+		},
+		"error": nil,
+	})
+}
+
+// parseTagIDList parses a comma-separated list of positive tag IDs, dropping
+// repeats so "all" mode counts each tag once
+func parseTagIDList(list string) ([]int64, bool) {
+	var ids []int64
+	seen := make(map[int64]bool)
+	for _, part := range strings.Split(list, ",") {
+		id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+		if err != nil || id <= 0 {
+			return nil, false
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, true
+}
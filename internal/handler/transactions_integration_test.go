@@ -0,0 +1,1368 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+	"github.com/piotrzalecki/budget-api/pkg/model"
+)
+
+// setupTestDB creates an in-memory SQLite database and runs migrations
+func setupTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	// Use the production driver so foreign keys are enforced as in the app
+	db, err := sql.Open(repo.SQLiteDriver, ":memory:")
+	require.NoError(t, err)
+	// Every pooled connection to ":memory:" is a separate database, so pin
+	// the pool to a single connection for WithTx to see the migrated schema
+	db.SetMaxOpenConns(1)
+
+	err = goose.SetDialect("sqlite3")
+	require.NoError(t, err)
+
+	err = goose.Up(db, "../../migrations")
+	require.NoError(t, err)
+
+	return db
+}
+
+// ensureDefaultUser makes sure user 1 (the handlers' default user) exists
+func ensureDefaultUser(t *testing.T, repository repo.Repository) {
+	t.Helper()
+
+	if _, err := repository.GetUserByID(context.Background(), 1); err == nil {
+		return
+	}
+	user, err := repository.CreateUser(context.Background(), repo.CreateUserParams{
+		Email:  "default@example.com",
+		PwHash: "hashedpassword",
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), user.ID)
+}
+
+// countLiveTransactions returns the number of non-deleted transactions for a user
+func countLiveTransactions(t *testing.T, repository repo.Repository, userID int64) int {
+	t.Helper()
+
+	transactions, err := repository.ListTransactions(context.Background(), repo.ListTransactionsParams{
+		UserID:  userID,
+		TDate:   time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC),
+		TDate_2: time.Date(2100, 12, 31, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	return len(transactions)
+}
+
+func TestClearTransactionsIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	// A second user whose transactions must survive the clear
+	other, err := repository.CreateUser(ctx, repo.CreateUserParams{
+		Email:  "other@example.com",
+		PwHash: "hashedpassword",
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+			UserID:      1,
+			AmountPence: -1000 - int64(i),
+			TDate:       time.Date(2025, 6, 1+i, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+	}
+	_, err = repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+		UserID:      other.ID,
+		AmountPence: -500,
+		TDate:       time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	liveBefore := countLiveTransactions(t, repository, 1)
+	require.GreaterOrEqual(t, liveBefore, 3)
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.POST("/transactions/clear", ValidateRequest[model.ClearTransactionsRequest](), h.ClearTransactions)
+
+	t.Run("missing confirmation is rejected", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]interface{}{"confirm": false})
+		req := httptest.NewRequest("POST", "/transactions/clear", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, liveBefore, countLiveTransactions(t, repository, 1))
+	})
+
+	t.Run("confirmed clear soft deletes all user transactions", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]interface{}{"confirm": true})
+		req := httptest.NewRequest("POST", "/transactions/clear", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Data  model.ClearTransactionsResponse `json:"data"`
+			Error interface{}                     `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Nil(t, response.Error)
+		assert.Equal(t, int64(liveBefore), response.Data.Cleared)
+
+		assert.Equal(t, 0, countLiveTransactions(t, repository, 1))
+		assert.Equal(t, 1, countLiveTransactions(t, repository, other.ID))
+	})
+}
+
+// failingTagLinkRepo wraps a repository and fails every tag association,
+// so tests can check that the surrounding create is rolled back
+type failingTagLinkRepo struct {
+	repo.Repository
+}
+
+func (r failingTagLinkRepo) WithTx(ctx context.Context, fn func(repo.Repository) error) error {
+	return r.Repository.WithTx(ctx, func(txRepo repo.Repository) error {
+		return fn(failingTagLinkRepo{txRepo})
+	})
+}
+
+func (r failingTagLinkRepo) CreateTransactionTag(ctx context.Context, arg repo.CreateTransactionTagParams) error {
+	return errors.New("injected tag link failure")
+}
+
+func (r failingTagLinkRepo) CreateRecurringTag(ctx context.Context, arg repo.CreateRecurringTagParams) error {
+	return errors.New("injected tag link failure")
+}
+
+func TestCreateWithTagsIsAtomicIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	tag, err := repository.CreateTag(ctx, "atomic-test")
+	require.NoError(t, err)
+
+	h := NewHandler(failingTagLinkRepo{repository}, zap.NewNop())
+	router := gin.New()
+	router.POST("/transactions", ValidateRequest[model.CreateTransactionRequest](), h.CreateTransaction)
+	router.POST("/recurring", ValidateRequest[model.CreateRecurringRequest](), h.CreateRecurring)
+
+	t.Run("transaction row is not persisted when tag link fails", func(t *testing.T) {
+		liveBefore := countLiveTransactions(t, repository, 1)
+
+		body, _ := json.Marshal(map[string]interface{}{
+			"amount":  "-12.34",
+			"t_date":  "2025-06-15",
+			"note":    "should roll back",
+			"tag_ids": []int64{tag.ID},
+		})
+		req := httptest.NewRequest("POST", "/transactions", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, liveBefore, countLiveTransactions(t, repository, 1))
+	})
+
+	t.Run("recurring row is not persisted when tag link fails", func(t *testing.T) {
+		rulesBefore, err := repository.ListRecurring(ctx, repo.ListRecurringParams{UserID: 1, Limit: -1})
+		require.NoError(t, err)
+
+		body, _ := json.Marshal(map[string]interface{}{
+			"amount":         "-9.99",
+			"description":    "should roll back",
+			"frequency":      "monthly",
+			"interval_n":     1,
+			"first_due_date": "2031-06-01",
+			"tag_ids":        []int64{tag.ID},
+		})
+		req := httptest.NewRequest("POST", "/recurring", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		rulesAfter, err := repository.ListRecurring(ctx, repo.ListRecurringParams{UserID: 1, Limit: -1})
+		require.NoError(t, err)
+		assert.Len(t, rulesAfter, len(rulesBefore))
+	})
+}
+
+func TestUpdateTransactionVersionIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	txn, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+		UserID:      1,
+		AmountPence: -1000,
+		TDate:       time.Date(2031, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), txn.Version)
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.PATCH("/transactions/:id", ValidateRequest[model.UpdateTransactionRequest](), h.UpdateTransaction)
+
+	patch := func(body map[string]interface{}) int {
+		payload, _ := json.Marshal(body)
+		req := httptest.NewRequest("PATCH", "/transactions/"+strconv.FormatInt(txn.ID, 10), bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	currentVersion := func() int64 {
+		stored, err := repository.GetTransactionByID(ctx, txn.ID)
+		require.NoError(t, err)
+		return stored.Version
+	}
+
+	t.Run("matching version updates and increments", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, patch(map[string]interface{}{"note": "first", "version": 1}))
+		assert.Equal(t, int64(2), currentVersion())
+	})
+
+	t.Run("stale version is rejected", func(t *testing.T) {
+		assert.Equal(t, http.StatusConflict, patch(map[string]interface{}{"note": "stale", "version": 1}))
+		stored, err := repository.GetTransactionByID(ctx, txn.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "first", stored.Note.String)
+		assert.Equal(t, int64(2), stored.Version)
+	})
+
+	t.Run("stale version cannot delete", func(t *testing.T) {
+		assert.Equal(t, http.StatusConflict, patch(map[string]interface{}{"deleted": true, "version": 1}))
+		_, err := repository.GetTransactionByID(ctx, txn.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("missing version skips the check", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, patch(map[string]interface{}{"note": "unchecked"}))
+		assert.Equal(t, int64(3), currentVersion())
+	})
+
+	t.Run("concurrent write between fetch and update is detected", func(t *testing.T) {
+		_, err := repository.UpdateTransaction(ctx, repo.UpdateTransactionParams{
+			ID:              txn.ID,
+			AmountPence:     txn.AmountPence,
+			TDate:           txn.TDate,
+			ExpectedVersion: sql.NullInt64{Int64: 2, Valid: true},
+		})
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.Equal(t, int64(3), currentVersion())
+	})
+}
+
+func TestEmptyTrashIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	other, err := repository.CreateUser(ctx, repo.CreateUserParams{
+		Email:  "other@example.com",
+		PwHash: "hashedpassword",
+	})
+	require.NoError(t, err)
+	tag, err := repository.CreateTag(ctx, "trash")
+	require.NoError(t, err)
+
+	// Every transaction is tagged, so purging has links to remove
+	create := func(userID int64, deleted bool) int64 {
+		txn, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+			UserID:      userID,
+			AmountPence: -750,
+			TDate:       time.Date(2031, 4, 1, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+		require.NoError(t, repository.CreateTransactionTag(ctx, repo.CreateTransactionTagParams{TransactionID: txn.ID, TagID: tag.ID}))
+		if deleted {
+			require.NoError(t, repository.SoftDeleteTransaction(ctx, txn.ID))
+		}
+		return txn.ID
+	}
+
+	var trashed []int64
+	for i := 0; i < 3; i++ {
+		trashed = append(trashed, create(1, true))
+	}
+	live := create(1, false)
+	otherTrashed := create(other.ID, true)
+
+	countRows := func(id int64) int {
+		var n int
+		require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM transactions WHERE id = ?", id).Scan(&n))
+		return n
+	}
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.POST("/transactions/trash/empty", h.EmptyTrash)
+
+	req := httptest.NewRequest("POST", "/transactions/trash/empty", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data model.EmptyTrashResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, int64(3), response.Data.Purged)
+
+	for _, id := range trashed {
+		assert.Equal(t, 0, countRows(id), "soft-deleted transaction %d should be purged", id)
+	}
+	assert.Equal(t, 1, countRows(live))
+	assert.Equal(t, 1, countRows(otherTrashed))
+	tags, err := repository.GetTransactionTags(ctx, live)
+	require.NoError(t, err)
+	assert.Len(t, tags, 1, "live transactions keep their tags")
+
+	// A second call finds nothing left to purge
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/transactions/trash/empty", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, int64(0), response.Data.Purged)
+}
+
+func TestPurgeSoftDeletedTransactionIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	other, err := repository.CreateUser(ctx, repo.CreateUserParams{
+		Email:  "purge-one-other@example.com",
+		PwHash: "hashedpassword",
+	})
+	require.NoError(t, err)
+	tag, err := repository.CreateTag(ctx, "trash")
+	require.NoError(t, err)
+
+	// Every transaction is tagged, so purging has links to remove
+	create := func(userID int64, deleted bool) int64 {
+		txn, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+			UserID:      userID,
+			AmountPence: -450,
+			TDate:       time.Date(2031, 5, 1, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+		require.NoError(t, repository.CreateTransactionTag(ctx, repo.CreateTransactionTagParams{TransactionID: txn.ID, TagID: tag.ID}))
+		if deleted {
+			require.NoError(t, repository.SoftDeleteTransaction(ctx, txn.ID))
+		}
+		return txn.ID
+	}
+	exists := func(id int64) bool {
+		var n int
+		require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM transactions WHERE id = ?", id).Scan(&n))
+		return n == 1
+	}
+
+	trashed := create(1, true)
+	otherTrashed := create(1, true)
+	live := create(1, false)
+	theirs := create(other.ID, true)
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.DELETE("/transactions/:id/purge", h.PurgeSoftDeletedTransaction)
+
+	purge := func(id string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("DELETE", "/transactions/"+id+"/purge", nil))
+		return w
+	}
+
+	t.Run("purges a soft deleted transaction", func(t *testing.T) {
+		w := purge(strconv.FormatInt(trashed, 10))
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.False(t, exists(trashed))
+		assert.True(t, exists(otherTrashed), "other trashed transactions are left alone")
+	})
+
+	t.Run("refuses to purge a live transaction", func(t *testing.T) {
+		w := purge(strconv.FormatInt(live, 10))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.True(t, exists(live))
+		tags, err := repository.GetTransactionTags(ctx, live)
+		require.NoError(t, err)
+		assert.Len(t, tags, 1, "a live transaction keeps its tags")
+	})
+
+	t.Run("refuses to purge another user's transaction", func(t *testing.T) {
+		w := purge(strconv.FormatInt(theirs, 10))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.True(t, exists(theirs))
+	})
+
+	t.Run("reports an already purged transaction as not found", func(t *testing.T) {
+		w := purge(strconv.FormatInt(trashed, 10))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("rejects an invalid ID", func(t *testing.T) {
+		w := purge("abc")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestDeleteTaggedRecordsIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	txn, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+		UserID:      1,
+		AmountPence: -1299,
+		TDate:       time.Date(2031, 6, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	rule, err := repository.CreateRecurring(ctx, repo.CreateRecurringParams{
+		UserID:       1,
+		AmountPence:  -1299,
+		Frequency:    "monthly",
+		IntervalN:    1,
+		FirstDueDate: time.Date(2031, 6, 1, 0, 0, 0, 0, time.UTC),
+		NextDueDate:  time.Date(2031, 7, 1, 0, 0, 0, 0, time.UTC),
+		Active:       true,
+	})
+	require.NoError(t, err)
+	doomed, err := repository.CreateTag(ctx, "doomed")
+	require.NoError(t, err)
+	kept, err := repository.CreateTag(ctx, "kept")
+	require.NoError(t, err)
+	for _, tagID := range []int64{doomed.ID, kept.ID} {
+		require.NoError(t, repository.CreateTransactionTag(ctx, repo.CreateTransactionTagParams{TransactionID: txn.ID, TagID: tagID}))
+		require.NoError(t, repository.CreateRecurringTag(ctx, repo.CreateRecurringTagParams{RecurringID: rule.ID, TagID: tagID}))
+	}
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.DELETE("/tags/:id", h.DeleteTag)
+	router.DELETE("/transactions/:id", h.HardDeleteTransaction)
+
+	send := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("DELETE", url, nil))
+		return w
+	}
+
+	t.Run("deleting a tag unlinks it first", func(t *testing.T) {
+		w := send("/tags/" + strconv.FormatInt(doomed.ID, 10))
+		require.Equal(t, http.StatusNoContent, w.Code)
+
+		_, err := repository.GetTagByID(ctx, doomed.ID)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		txnTags, err := repository.GetTransactionTags(ctx, txn.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []repo.Tag{kept}, txnTags)
+		ruleTags, err := repository.GetRecurringTags(ctx, rule.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []repo.Tag{kept}, ruleTags)
+	})
+
+	t.Run("hard deleting a tagged transaction", func(t *testing.T) {
+		w := send("/transactions/" + strconv.FormatInt(txn.ID, 10))
+		require.Equal(t, http.StatusNoContent, w.Code)
+
+		_, err := repository.GetTransactionByID(ctx, txn.ID)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		var links int
+		require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM transaction_tags WHERE transaction_id = ?", txn.ID).Scan(&links))
+		assert.Zero(t, links)
+	})
+}
+
+func TestPurgeSoftDeletedTransactionsIsScopedToUserIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	other, err := repository.CreateUser(ctx, repo.CreateUserParams{
+		Email:  "other@example.com",
+		PwHash: "hashedpassword",
+	})
+	require.NoError(t, err)
+
+	trash := func(userID int64) int64 {
+		txn, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+			UserID:      userID,
+			AmountPence: -300,
+			TDate:       time.Date(2031, 7, 1, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+		require.NoError(t, repository.SoftDeleteTransaction(ctx, txn.ID))
+		return txn.ID
+	}
+	exists := func(id int64) bool {
+		var n int
+		require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM transactions WHERE id = ?", id).Scan(&n))
+		return n == 1
+	}
+
+	mine := trash(1)
+	theirs := trash(other.ID)
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.POST("/transactions/purge", ValidateRequest[model.PurgeTransactionsRequest](), h.PurgeSoftDeletedTransactions)
+
+	cutoff := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	body, _ := json.Marshal(map[string]interface{}{"cutoff_date": cutoff})
+	req := httptest.NewRequest("POST", "/transactions/purge", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	assert.False(t, exists(mine), "the requesting user's trash should be purged")
+	assert.True(t, exists(theirs), "another user's trash must be left alone")
+
+	// Purging directly for the other user only touches their rows
+	purged, err := repository.PurgeSoftDeletedTransactions(ctx, repo.PurgeSoftDeletedTransactionsParams{
+		UserID:    other.ID,
+		DeletedAt: sql.NullTime{Time: time.Now().AddDate(0, 0, 1), Valid: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+	assert.False(t, exists(theirs))
+}
+
+func TestMakeTransactionRecurringIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	txn, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+		UserID:      1,
+		AmountPence: -899,
+		TDate:       time.Date(2031, 9, 12, 0, 0, 0, 0, time.UTC),
+		Note:        sql.NullString{String: "Cloud storage", Valid: true},
+	})
+	require.NoError(t, err)
+	tagA, err := repository.CreateTag(ctx, "promote-a")
+	require.NoError(t, err)
+	tagB, err := repository.CreateTag(ctx, "promote-b")
+	require.NoError(t, err)
+	for _, tagID := range []int64{tagA.ID, tagB.ID} {
+		require.NoError(t, repository.CreateTransactionTag(ctx, repo.CreateTransactionTagParams{TransactionID: txn.ID, TagID: tagID}))
+	}
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.POST("/transactions/:id/make-recurring", ValidateRequest[model.MakeRecurringRequest](), h.MakeTransactionRecurring)
+
+	post := func(id int64, body map[string]interface{}) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(body)
+		req := httptest.NewRequest("POST", "/transactions/"+strconv.FormatInt(id, 10)+"/make-recurring", bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	schedule := map[string]interface{}{
+		"frequency":      "monthly",
+		"interval_n":     1,
+		"first_due_date": "2031-10-12",
+	}
+
+	t.Run("rule inherits amount, note and tags", func(t *testing.T) {
+		w := post(txn.ID, schedule)
+		require.Equal(t, http.StatusCreated, w.Code)
+		var response struct {
+			Data struct {
+				ID int64 `json:"id"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+		rule, err := repository.GetRecurringByID(ctx, response.Data.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(-899), rule.AmountPence)
+		assert.Equal(t, "Cloud storage", rule.Description.String)
+		assert.Equal(t, "monthly", rule.Frequency)
+		assert.Equal(t, "2031-10-12", model.FormatDate(rule.NextDueDate))
+		assert.True(t, rule.Active)
+
+		tags, err := repository.GetRecurringTags(ctx, rule.ID)
+		require.NoError(t, err)
+		ids := make([]int64, len(tags))
+		for i, tag := range tags {
+			ids[i] = tag.ID
+		}
+		assert.ElementsMatch(t, []int64{tagA.ID, tagB.ID}, ids)
+	})
+
+	t.Run("unknown transaction", func(t *testing.T) {
+		w := post(999999, schedule)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("schedule is required", func(t *testing.T) {
+		w := post(txn.ID, map[string]interface{}{"interval_n": 1})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGetTransactionsCreatedAtFilterIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	create := func(tDate time.Time, createdAt string) int64 {
+		txn, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+			UserID:      1,
+			AmountPence: -100,
+			TDate:       tDate,
+		})
+		require.NoError(t, err)
+		_, err = db.Exec("UPDATE transactions SET created_at = ? WHERE id = ?", createdAt, txn.ID)
+		require.NoError(t, err)
+		return txn.ID
+	}
+
+	// Dated long ago but entered in the window, and the other way round
+	backfilled := create(time.Date(2030, 1, 5, 0, 0, 0, 0, time.UTC), "2031-06-15 18:30:00")
+	create(time.Date(2031, 6, 12, 0, 0, 0, 0, time.UTC), "2031-01-01 09:00:00")
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.GET("/transactions", h.GetTransactions)
+
+	t.Run("filters on created_at rather than t_date", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/transactions?created_from=2031-06-10&created_to=2031-06-15", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data  []model.TransactionResponse `json:"data"`
+			Total int64                       `json:"total"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data, 1)
+		assert.Equal(t, backfilled, response.Data[0].ID)
+		assert.Equal(t, "2030-01-05", response.Data[0].TDate)
+		assert.Equal(t, int64(1), response.Total)
+	})
+
+	t.Run("rejects an invalid date", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/transactions?created_from=last-week", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestTransactionTagEndpointsIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	txn, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+		UserID:      1,
+		AmountPence: -420,
+		TDate:       time.Date(2031, 4, 2, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	tagA, err := repository.CreateTag(ctx, "txn-tag-a")
+	require.NoError(t, err)
+	tagB, err := repository.CreateTag(ctx, "txn-tag-b")
+	require.NoError(t, err)
+	tagC, err := repository.CreateTag(ctx, "txn-tag-c")
+	require.NoError(t, err)
+	for _, tagID := range []int64{tagA.ID, tagB.ID} {
+		require.NoError(t, repository.CreateTransactionTag(ctx, repo.CreateTransactionTagParams{TransactionID: txn.ID, TagID: tagID}))
+	}
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.POST("/transactions/:id/tags/:tag_id", h.AddTransactionTag)
+	router.DELETE("/transactions/:id/tags/:tag_id", h.RemoveTransactionTag)
+
+	call := func(method string, id, tagID int64) *httptest.ResponseRecorder {
+		url := "/transactions/" + strconv.FormatInt(id, 10) + "/tags/" + strconv.FormatInt(tagID, 10)
+		req := httptest.NewRequest(method, url, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	tagIDsOf := func(transactionID int64) []int64 {
+		tags, err := repository.GetTransactionTags(ctx, transactionID)
+		require.NoError(t, err)
+		ids := make([]int64, len(tags))
+		for i, tag := range tags {
+			ids[i] = tag.ID
+		}
+		return ids
+	}
+
+	t.Run("adds a tag alongside the existing ones", func(t *testing.T) {
+		w := call("POST", txn.ID, tagC.ID)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data struct {
+				TagIDs []int64 `json:"tag_ids"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.ElementsMatch(t, []int64{tagA.ID, tagB.ID, tagC.ID}, response.Data.TagIDs)
+		assert.ElementsMatch(t, []int64{tagA.ID, tagB.ID, tagC.ID}, tagIDsOf(txn.ID))
+	})
+
+	t.Run("removes one tag and keeps the others", func(t *testing.T) {
+		w := call("DELETE", txn.ID, tagA.ID)
+		require.Equal(t, http.StatusNoContent, w.Code)
+		assert.Empty(t, w.Body.String())
+		assert.ElementsMatch(t, []int64{tagB.ID, tagC.ID}, tagIDsOf(txn.ID))
+	})
+
+	t.Run("removing a tag that isn't attached", func(t *testing.T) {
+		w := call("DELETE", txn.ID, tagA.ID)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "tag is not on this transaction")
+		assert.ElementsMatch(t, []int64{tagB.ID, tagC.ID}, tagIDsOf(txn.ID))
+	})
+
+	t.Run("unknown transaction or tag", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, call("POST", 999999, tagA.ID).Code)
+		assert.Equal(t, http.StatusNotFound, call("POST", txn.ID, 999999).Code)
+	})
+}
+
+func TestCreateTransactionExternalIDDedupeIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.POST("/transactions", ValidateRequest[model.CreateTransactionRequest](), h.CreateTransaction)
+
+	create := func(body string) (int64, bool) {
+		req := httptest.NewRequest("POST", "/transactions", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response struct {
+			Data struct {
+				ID      int64 `json:"id"`
+				Skipped bool  `json:"skipped"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		// A skipped repeat creates nothing, so it answers 200 rather than 201
+		if response.Data.Skipped {
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		} else {
+			require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		}
+		return response.Data.ID, response.Data.Skipped
+	}
+
+	firstID, skipped := create(`{"amount": "-12.34", "t_date": "2031-06-01", "external_id": "BANK-0001"}`)
+	assert.False(t, skipped)
+
+	// The same reference again is skipped and answers with the original
+	againID, skipped := create(`{"amount": "-99.99", "t_date": "2031-06-02", "external_id": " BANK-0001 "}`)
+	assert.True(t, skipped)
+	assert.Equal(t, firstID, againID)
+
+	stored, err := repository.GetTransactionByExternalID(ctx, repo.GetTransactionByExternalIDParams{
+		UserID:     1,
+		ExternalID: sql.NullString{String: "BANK-0001", Valid: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, firstID, stored.ID)
+	assert.Equal(t, int64(-1234), stored.AmountPence)
+
+	// Other references and transactions without one are created as usual
+	otherID, skipped := create(`{"amount": "-5.00", "t_date": "2031-06-02", "external_id": "BANK-0002"}`)
+	assert.False(t, skipped)
+	assert.NotEqual(t, firstID, otherID)
+	noRef1, _ := create(`{"amount": "-5.00", "t_date": "2031-06-03"}`)
+	noRef2, _ := create(`{"amount": "-5.00", "t_date": "2031-06-03", "external_id": ""}`)
+	assert.NotEqual(t, noRef1, noRef2)
+
+	// The reference is returned with the transaction
+	router.GET("/transactions/:id", h.GetTransactionByID)
+	req := httptest.NewRequest("GET", "/transactions/"+strconv.FormatInt(firstID, 10), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"external_id":"BANK-0001"`)
+}
+
+// racedExternalIDRepo wraps a repository and misses the first lookup by
+// external reference, as if a concurrent import inserted it just after
+type racedExternalIDRepo struct {
+	repo.Repository
+	missed bool
+}
+
+func (r *racedExternalIDRepo) GetTransactionByExternalID(ctx context.Context, arg repo.GetTransactionByExternalIDParams) (repo.Transaction, error) {
+	if !r.missed {
+		r.missed = true
+		return repo.Transaction{}, sql.ErrNoRows
+	}
+	return r.Repository.GetTransactionByExternalID(ctx, arg)
+}
+
+func TestCreateTransactionExternalIDRaceIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	existing, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+		UserID:      1,
+		AmountPence: -1234,
+		TDate:       time.Date(2031, 6, 1, 0, 0, 0, 0, time.UTC),
+		ExternalID:  sql.NullString{String: "BANK-0001", Valid: true},
+	})
+	require.NoError(t, err)
+	before := countLiveTransactions(t, repository, 1)
+
+	h := NewHandler(&racedExternalIDRepo{Repository: repository}, zap.NewNop())
+	router := gin.New()
+	router.POST("/transactions", ValidateRequest[model.CreateTransactionRequest](), h.CreateTransaction)
+
+	req := httptest.NewRequest("POST", "/transactions", bytes.NewBufferString(`{"amount": "-12.34", "t_date": "2031-06-01", "external_id": "BANK-0001"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// The insert losing the race is answered as a skip, not a failure
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response struct {
+		Data struct {
+			ID      int64 `json:"id"`
+			Skipped bool  `json:"skipped"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.True(t, response.Data.Skipped)
+	assert.Equal(t, existing.ID, response.Data.ID)
+	assert.Equal(t, before, countLiveTransactions(t, repository, 1))
+}
+
+func TestTransactionClearedIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	create := func() int64 {
+		txn, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+			UserID:      1,
+			AmountPence: -100,
+			TDate:       time.Date(2031, 6, 1, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+		return txn.ID
+	}
+	toggled := create()
+	patched := create()
+	untouched := create()
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.GET("/transactions", h.GetTransactions)
+	router.PATCH("/transactions/:id", ValidateRequest[model.UpdateTransactionRequest](), h.UpdateTransaction)
+	router.PATCH("/transactions/:id/toggle-cleared", h.ToggleTransactionCleared)
+
+	serve := func(method, url, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, url, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	listIDs := func(query string) []int64 {
+		w := serve("GET", "/transactions"+query, "")
+		require.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Data []model.TransactionResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		ids := make([]int64, 0, len(response.Data))
+		for _, txn := range response.Data {
+			ids = append(ids, txn.ID)
+		}
+		return ids
+	}
+
+	w := serve("PATCH", "/transactions/"+strconv.FormatInt(toggled, 10)+"/toggle-cleared", "")
+	require.Equal(t, http.StatusNoContent, w.Code)
+	w = serve("PATCH", "/transactions/"+strconv.FormatInt(patched, 10), `{"cleared": true}`)
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	t.Run("filters cleared transactions", func(t *testing.T) {
+		assert.ElementsMatch(t, []int64{toggled, patched}, listIDs("?cleared=true"))
+	})
+
+	t.Run("filters uncleared transactions", func(t *testing.T) {
+		ids := listIDs("?cleared=false")
+		assert.Contains(t, ids, untouched)
+		assert.NotContains(t, ids, toggled)
+		assert.NotContains(t, ids, patched)
+	})
+
+	t.Run("toggling again clears the flag", func(t *testing.T) {
+		w := serve("PATCH", "/transactions/"+strconv.FormatInt(toggled, 10)+"/toggle-cleared", "")
+		require.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, []int64{patched}, listIDs("?cleared=true"))
+	})
+
+	t.Run("rejects an invalid filter", func(t *testing.T) {
+		w := serve("GET", "/transactions?cleared=maybe", "")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("unknown transaction", func(t *testing.T) {
+		w := serve("PATCH", "/transactions/999999/toggle-cleared", "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestReconcileTransactionsIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	other, err := repository.CreateUser(ctx, repo.CreateUserParams{
+		Email:  "reconcile-other@example.com",
+		PwHash: "hash",
+	})
+	require.NoError(t, err)
+
+	create := func(userID int64) int64 {
+		txn, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+			UserID:      userID,
+			AmountPence: -100,
+			TDate:       time.Date(2031, 7, 1, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+		return txn.ID
+	}
+	ids := []int64{create(1), create(1), create(1)}
+	foreign := create(other.ID)
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.POST("/transactions/reconcile", ValidateRequest[model.ReconcileTransactionsRequest](), h.ReconcileTransactions)
+
+	reconcile := func(transactionIDs []int64, cleared bool) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]interface{}{"transaction_ids": transactionIDs, "cleared": cleared})
+		req := httptest.NewRequest("POST", "/transactions/reconcile", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	clearedState := func(id int64) bool {
+		txn, err := repository.GetTransactionByID(ctx, id)
+		require.NoError(t, err)
+		return txn.Cleared
+	}
+
+	t.Run("clears every transaction", func(t *testing.T) {
+		w := reconcile(ids, true)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data model.ReconcileTransactionsResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, int64(3), response.Data.Updated)
+		assert.True(t, response.Data.Cleared)
+
+		for _, id := range ids {
+			assert.True(t, clearedState(id), "transaction %d", id)
+		}
+	})
+
+	t.Run("rejects another user's transaction and changes nothing", func(t *testing.T) {
+		w := reconcile([]int64{ids[0], foreign}, false)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.True(t, clearedState(ids[0]))
+		assert.False(t, clearedState(foreign))
+	})
+
+	t.Run("rejects an unknown transaction", func(t *testing.T) {
+		w := reconcile([]int64{999999}, true)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("unclears transactions", func(t *testing.T) {
+		w := reconcile(ids[:2], false)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.False(t, clearedState(ids[0]))
+		assert.False(t, clearedState(ids[1]))
+		assert.True(t, clearedState(ids[2]))
+	})
+
+	t.Run("requires the cleared flag", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/transactions/reconcile", bytes.NewBufferString(`{"transaction_ids": [1]}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestBulkDeleteTransactionsIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	other, err := repository.CreateUser(ctx, repo.CreateUserParams{
+		Email:  "bulk-delete-other@example.com",
+		PwHash: "hash",
+	})
+	require.NoError(t, err)
+
+	create := func(userID int64) int64 {
+		txn, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+			UserID:      userID,
+			AmountPence: -100,
+			TDate:       time.Date(2031, 7, 1, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+		return txn.ID
+	}
+	ids := []int64{create(1), create(1), create(1)}
+	kept := create(1)
+	foreign := create(other.ID)
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.POST("/transactions/bulk-delete", ValidateRequest[model.BulkDeleteTransactionsRequest](), h.BulkDeleteTransactions)
+	router.GET("/transactions", h.GetTransactions)
+
+	bulkDelete := func(transactionIDs []int64) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]interface{}{"transaction_ids": transactionIDs})
+		req := httptest.NewRequest("POST", "/transactions/bulk-delete", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	listIDs := func() []int64 {
+		req := httptest.NewRequest("GET", "/transactions", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data []model.TransactionResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		listed := make([]int64, 0, len(response.Data))
+		for _, txn := range response.Data {
+			listed = append(listed, txn.ID)
+		}
+		return listed
+	}
+
+	t.Run("rejects another user's transaction and deletes nothing", func(t *testing.T) {
+		w := bulkDelete([]int64{ids[0], foreign})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, listIDs(), ids[0])
+
+		_, err := repository.GetTransactionByID(ctx, foreign)
+		assert.NoError(t, err)
+	})
+
+	t.Run("soft deletes every transaction", func(t *testing.T) {
+		w := bulkDelete(ids)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data model.BulkDeleteTransactionsResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, int64(3), response.Data.Deleted)
+
+		listed := listIDs()
+		for _, id := range ids {
+			assert.NotContains(t, listed, id)
+		}
+		assert.Contains(t, listed, kept)
+	})
+
+	t.Run("rejects an already deleted transaction", func(t *testing.T) {
+		w := bulkDelete([]int64{ids[0], kept})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, listIDs(), kept)
+	})
+
+	t.Run("requires at least one transaction", func(t *testing.T) {
+		w := bulkDelete([]int64{})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGetTransactionsTagFilterIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	food, err := repository.CreateTag(ctx, "filter-food")
+	require.NoError(t, err)
+	travel, err := repository.CreateTag(ctx, "filter-travel")
+	require.NoError(t, err)
+	other, err := repository.CreateTag(ctx, "filter-other")
+	require.NoError(t, err)
+
+	createTagged := func(tagIDs ...int64) int64 {
+		txn, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+			UserID:      1,
+			AmountPence: -400,
+			TDate:       time.Date(2031, 8, 1, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+		for _, tagID := range tagIDs {
+			require.NoError(t, repository.CreateTransactionTag(ctx, repo.CreateTransactionTagParams{
+				TransactionID: txn.ID,
+				TagID:         tagID,
+			}))
+		}
+		return txn.ID
+	}
+	onlyFood := createTagged(food.ID)
+	onlyTravel := createTagged(travel.ID)
+	both := createTagged(food.ID, travel.ID)
+	allThree := createTagged(food.ID, travel.ID, other.ID)
+	onlyOther := createTagged(other.ID)
+	untagged := createTagged()
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	router.GET("/transactions", h.GetTransactions)
+
+	list := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/transactions"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	listIDs := func(query string) []int64 {
+		w := list(query)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response struct {
+			Data  []model.TransactionResponse `json:"data"`
+			Total int64                       `json:"total"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, int64(len(response.Data)), response.Total)
+		ids := make([]int64, 0, len(response.Data))
+		for _, txn := range response.Data {
+			ids = append(ids, txn.ID)
+		}
+		return ids
+	}
+	tags := strconv.FormatInt(food.ID, 10) + "," + strconv.FormatInt(travel.ID, 10)
+
+	t.Run("no tag filter lists tagged and untagged transactions", func(t *testing.T) {
+		ids := listIDs("")
+		assert.Subset(t, ids, []int64{onlyFood, onlyTravel, both, allThree, onlyOther, untagged})
+	})
+
+	t.Run("any matches at least one tag", func(t *testing.T) {
+		assert.ElementsMatch(t, []int64{onlyFood, onlyTravel, both, allThree}, listIDs("?tags="+tags+"&tag_mode=any"))
+	})
+
+	t.Run("any is the default", func(t *testing.T) {
+		assert.ElementsMatch(t, []int64{onlyFood, onlyTravel, both, allThree}, listIDs("?tags="+tags))
+	})
+
+	t.Run("all matches every tag", func(t *testing.T) {
+		assert.ElementsMatch(t, []int64{both, allThree}, listIDs("?tags="+tags+"&tag_mode=all"))
+	})
+
+	t.Run("repeated tags count once", func(t *testing.T) {
+		assert.ElementsMatch(t, []int64{both, allThree}, listIDs("?tags="+tags+","+strconv.FormatInt(food.ID, 10)+"&tag_mode=all"))
+	})
+
+	t.Run("rejects invalid tag IDs", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, list("?tags=1,abc").Code)
+		assert.Equal(t, http.StatusBadRequest, list("?tags=0").Code)
+	})
+
+	t.Run("rejects an unknown mode", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, list("?tags="+tags+"&tag_mode=some").Code)
+	})
+}
+
+func TestGetTransactionActivityIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	create := func(day int, amount int64) repo.Transaction {
+		txn, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+			UserID:      1,
+			AmountPence: amount,
+			TDate:       time.Date(2031, 8, day, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+		return txn
+	}
+	create(3, -1250)
+	create(3, 5000)
+	create(17, -800)
+	deleted := create(20, -999)
+	require.NoError(t, repository.SoftDeleteTransaction(ctx, deleted.ID))
+	create(31, -100) // after the range
+
+	h := NewHandler(repository, zap.NewNop())
+	h.clock = fixedClock(time.Date(2031, 8, 25, 10, 0, 0, 0, time.UTC))
+	router := gin.New()
+	router.GET("/transactions/activity", h.GetTransactionActivity)
+
+	get := func(query string) (int, model.ActivityResponse) {
+		req := httptest.NewRequest("GET", "/transactions/activity"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var response struct {
+			Data model.ActivityResponse `json:"data"`
+		}
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w.Code, response.Data
+	}
+
+	t.Run("only days with transactions", func(t *testing.T) {
+		code, activity := get("?from=2031-08-01&to=2031-08-30")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "2031-08-01", activity.From)
+		assert.Equal(t, "2031-08-30", activity.To)
+		assert.Equal(t, []model.DailyActivity{
+			{Date: "2031-08-03", TransactionCount: 2, Net: "37.50", NetPence: 3750},
+			{Date: "2031-08-17", TransactionCount: 1, Net: "-8.00", NetPence: -800},
+		}, activity.Days)
+	})
+
+	t.Run("defaults to the current month so far", func(t *testing.T) {
+		code, activity := get("")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "2031-08-01", activity.From)
+		assert.Equal(t, "2031-08-25", activity.To)
+		assert.Len(t, activity.Days, 2)
+	})
+
+	t.Run("no activity", func(t *testing.T) {
+		code, activity := get("?from=2031-09-01&to=2031-09-30")
+		require.Equal(t, http.StatusOK, code)
+		assert.NotNil(t, activity.Days)
+		assert.Empty(t, activity.Days)
+	})
+
+	t.Run("invalid ranges", func(t *testing.T) {
+		for _, query := range []string{
+			"?from=2031-08-31&to=2031-08-01",
+			"?from=2031-01-01&to=2032-01-02",
+			"?from=08/01/2031",
+		} {
+			code, _ := get(query)
+			assert.Equal(t, http.StatusBadRequest, code, query)
+		}
+	})
+}
@@ -8,6 +8,8 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sort"
+	"strings"
 	"testing"
 
 	"go.uber.org/zap"
@@ -17,6 +19,7 @@ import (
 	"github.com/piotrzalecki/budget-api/internal/repo"
 	"github.com/piotrzalecki/budget-api/pkg/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // mockTransactionRepo implements repo.Repository with transaction methods for tests
@@ -61,7 +64,7 @@ func (m *mockTransactionRepo) GetTransactionByID(ctx context.Context, id int64)
 func (m *mockTransactionRepo) ListTransactions(ctx context.Context, arg repo.ListTransactionsParams) ([]repo.Transaction, error) {
 	var result []repo.Transaction
 	for _, t := range m.transactions {
-		if t.UserID == arg.UserID && !t.DeletedAt.Valid {
+		if t.UserID == arg.UserID && !t.DeletedAt.Valid && (!arg.Cleared.Valid || t.Cleared == arg.Cleared.Bool) {
 			if t.TDate.After(arg.TDate) || t.TDate.Equal(arg.TDate) {
 				if t.TDate.Before(arg.TDate_2) || t.TDate.Equal(arg.TDate_2) {
 					result = append(result, t)
@@ -72,6 +75,11 @@ func (m *mockTransactionRepo) ListTransactions(ctx context.Context, arg repo.Lis
 	return result, nil
 }
 
+func (m *mockTransactionRepo) CountTransactions(ctx context.Context, arg repo.CountTransactionsParams) (int64, error) {
+	transactions, err := m.ListTransactions(ctx, repo.ListTransactionsParams(arg))
+	return int64(len(transactions)), err
+}
+
 func (m *mockTransactionRepo) ListTransactionsByDateRange(ctx context.Context, userID int64) ([]repo.Transaction, error) {
 	var result []repo.Transaction
 	for _, t := range m.transactions {
@@ -85,9 +93,16 @@ func (m *mockTransactionRepo) ListTransactionsByDateRange(ctx context.Context, u
 func (m *mockTransactionRepo) UpdateTransaction(ctx context.Context, arg repo.UpdateTransactionParams) (repo.Transaction, error) {
 	for i, t := range m.transactions {
 		if t.ID == arg.ID && !t.DeletedAt.Valid {
+			if arg.ExpectedVersion.Valid && arg.ExpectedVersion.Int64 != t.Version {
+				return repo.Transaction{}, sql.ErrNoRows
+			}
 			m.transactions[i].AmountPence = arg.AmountPence
 			m.transactions[i].TDate = arg.TDate
 			m.transactions[i].Note = arg.Note
+			if arg.Cleared.Valid {
+				m.transactions[i].Cleared = arg.Cleared.Bool
+			}
+			m.transactions[i].Version++
 			return m.transactions[i], nil
 		}
 	}
@@ -104,6 +119,17 @@ func (m *mockTransactionRepo) SoftDeleteTransaction(ctx context.Context, id int6
 	return sql.ErrNoRows
 }
 
+func (m *mockTransactionRepo) SoftDeleteAllTransactionsByUser(ctx context.Context, userID int64) (int64, error) {
+	var count int64
+	for i, t := range m.transactions {
+		if t.UserID == userID && !t.DeletedAt.Valid {
+			m.transactions[i].DeletedAt = sql.NullTime{Time: time.Now(), Valid: true}
+			count++
+		}
+	}
+	return count, nil
+}
+
 func (m *mockTransactionRepo) GetTagByID(ctx context.Context, id int64) (repo.Tag, error) {
 	for _, tag := range m.tags {
 		if tag.ID == id {
@@ -154,17 +180,39 @@ func (m *mockTransactionRepo) DeleteUser(ctx context.Context, id int64) error {
 func (m *mockTransactionRepo) GetTransactionsByRecurringID(ctx context.Context, sourceRecurring sql.NullInt64) ([]repo.Transaction, error) { panic("not implemented") }
 func (m *mockTransactionRepo) GetTransactionsByTag(ctx context.Context, tagID int64) ([]repo.Transaction, error) { panic("not implemented") }
 func (m *mockTransactionRepo) HardDeleteTransaction(ctx context.Context, id int64) error { panic("not implemented") }
-func (m *mockTransactionRepo) PurgeSoftDeletedTransactions(ctx context.Context, deletedAt sql.NullTime) error { panic("not implemented") }
+func (m *mockTransactionRepo) PurgeSoftDeletedTransactions(ctx context.Context, arg repo.PurgeSoftDeletedTransactionsParams) (int64, error) { panic("not implemented") }
 func (m *mockTransactionRepo) CreateTag(ctx context.Context, name string) (repo.Tag, error) { panic("not implemented") }
 func (m *mockTransactionRepo) GetTagByName(ctx context.Context, name string) (repo.Tag, error) { panic("not implemented") }
 func (m *mockTransactionRepo) ListTags(ctx context.Context) ([]repo.Tag, error) { panic("not implemented") }
 func (m *mockTransactionRepo) UpdateTag(ctx context.Context, arg repo.UpdateTagParams) (repo.Tag, error) { panic("not implemented") }
 func (m *mockTransactionRepo) DeleteTag(ctx context.Context, id int64) error { panic("not implemented") }
 func (m *mockTransactionRepo) DeleteTransactionTag(ctx context.Context, arg repo.DeleteTransactionTagParams) error { panic("not implemented") }
+func (m *mockTransactionRepo) ListTransactionTagsByDateRange(ctx context.Context, arg repo.ListTransactionTagsByDateRangeParams) ([]repo.ListTransactionTagsByDateRangeRow, error) {
+	tagNames := make(map[int64]string)
+	for _, tag := range m.tags {
+		tagNames[tag.ID] = tag.Name
+	}
+	var result []repo.ListTransactionTagsByDateRangeRow
+	for _, t := range m.transactions {
+		if t.UserID != arg.UserID || t.DeletedAt.Valid || t.TDate.Before(arg.TDate) || t.TDate.After(arg.TDate_2) {
+			continue
+		}
+		for _, tag := range m.transactionTags[t.ID] {
+			result = append(result, repo.ListTransactionTagsByDateRangeRow{
+				TransactionID: t.ID,
+				ID:            tag.ID,
+				Name:          tagNames[tag.ID],
+			})
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
 func (m *mockTransactionRepo) CreateRecurring(ctx context.Context, arg repo.CreateRecurringParams) (repo.Recurring, error) { panic("not implemented") }
 func (m *mockTransactionRepo) GetRecurringByID(ctx context.Context, id int64) (repo.Recurring, error) { panic("not implemented") }
-func (m *mockTransactionRepo) ListRecurring(ctx context.Context, userID int64) ([]repo.Recurring, error) { panic("not implemented") }
-func (m *mockTransactionRepo) ListActiveRecurring(ctx context.Context, userID int64) ([]repo.Recurring, error) { panic("not implemented") }
+func (m *mockTransactionRepo) ListRecurring(ctx context.Context, arg repo.ListRecurringParams) ([]repo.Recurring, error) { panic("not implemented") }
+func (m *mockTransactionRepo) ListActiveRecurring(ctx context.Context, arg repo.ListActiveRecurringParams) ([]repo.Recurring, error) { panic("not implemented") }
 func (m *mockTransactionRepo) GetRecurringByTag(ctx context.Context, tagID int64) ([]repo.Recurring, error) { panic("not implemented") }
 func (m *mockTransactionRepo) GetRecurringDueOnDate(ctx context.Context, nextDueDate time.Time) ([]repo.Recurring, error) { panic("not implemented") }
 func (m *mockTransactionRepo) UpdateRecurring(ctx context.Context, arg repo.UpdateRecurringParams) (repo.Recurring, error) { panic("not implemented") }
@@ -186,6 +234,51 @@ func (m *mockTransactionRepo) CreateSession(ctx context.Context, arg repo.Create
 func (m *mockTransactionRepo) GetSessionByToken(ctx context.Context, token string) (repo.GetSessionByTokenRow, error) { panic("not implemented") }
 func (m *mockTransactionRepo) DeleteSession(ctx context.Context, token string) error { panic("not implemented") }
 func (m *mockTransactionRepo) DeleteAllSessionsByUserID(ctx context.Context, userID int64) error { panic("not implemented") }
+func (m *mockTransactionRepo) CreateRecurringHistory(ctx context.Context, arg repo.CreateRecurringHistoryParams) (repo.RecurringHistory, error) { panic("not implemented") }
+func (m *mockTransactionRepo) ListRecurringHistory(ctx context.Context, arg repo.ListRecurringHistoryParams) ([]repo.RecurringHistory, error) { panic("not implemented") }
+func (m *mockTransactionRepo) CountRecurringHistory(ctx context.Context, recurringID int64) (int64, error) { panic("not implemented") }
+func (m *mockTransactionRepo) DeleteRecurringHistory(ctx context.Context, recurringID int64) error { panic("not implemented") }
+func (m *mockTransactionRepo) CountRecurring(ctx context.Context, arg repo.CountRecurringParams) (int64, error) { panic("not implemented") }
+func (m *mockTransactionRepo) CountActiveRecurring(ctx context.Context, arg repo.CountActiveRecurringParams) (int64, error) { panic("not implemented") }
+func (m *mockTransactionRepo) PurgeAllSoftDeleted(ctx context.Context, userID int64) (int64, error) { panic("not implemented") }
+func (m *mockTransactionRepo) ReassignTransactionTags(ctx context.Context, arg repo.ReassignTransactionTagsParams) (int64, error) { panic("not implemented") }
+func (m *mockTransactionRepo) DeleteTransactionTagsByTag(ctx context.Context, tagID int64) (int64, error) { panic("not implemented") }
+func (m *mockTransactionRepo) ListTransactionAmountsByDateRange(ctx context.Context, arg repo.ListTransactionAmountsByDateRangeParams) ([]repo.ListTransactionAmountsByDateRangeRow, error) { panic("not implemented") }
+func (m *mockTransactionRepo) GetBalanceBefore(ctx context.Context, arg repo.GetBalanceBeforeParams) (sql.NullFloat64, error) { panic("not implemented") }
+func (m *mockTransactionRepo) DetachRecurringTransactions(ctx context.Context, sourceRecurring sql.NullInt64) error { panic("not implemented") }
+func (m *mockTransactionRepo) CreateTagAlert(ctx context.Context, arg repo.CreateTagAlertParams) (repo.TagAlert, error) { panic("not implemented") }
+func (m *mockTransactionRepo) GetTagAlertByID(ctx context.Context, id int64) (repo.TagAlert, error) { panic("not implemented") }
+func (m *mockTransactionRepo) ListTagAlerts(ctx context.Context, userID int64) ([]repo.TagAlert, error) { panic("not implemented") }
+func (m *mockTransactionRepo) UpdateTagAlertThreshold(ctx context.Context, arg repo.UpdateTagAlertThresholdParams) (repo.TagAlert, error) { panic("not implemented") }
+func (m *mockTransactionRepo) MarkTagAlertNotified(ctx context.Context, arg repo.MarkTagAlertNotifiedParams) error { panic("not implemented") }
+func (m *mockTransactionRepo) DeleteTagAlert(ctx context.Context, id int64) error { panic("not implemented") }
+func (m *mockTransactionRepo) GetTagMonthlySpend(ctx context.Context, arg repo.GetTagMonthlySpendParams) (sql.NullFloat64, error) { panic("not implemented") }
+func (m *mockTransactionRepo) GetTopSpendingTags(ctx context.Context, arg repo.GetTopSpendingTagsParams) ([]repo.GetTopSpendingTagsRow, error) { panic("not implemented") }
+func (m *mockTransactionRepo) SoftDeleteRecurring(ctx context.Context, id int64) error { panic("not implemented") }
+func (m *mockTransactionRepo) CreateReceipt(ctx context.Context, arg repo.CreateReceiptParams) (repo.Receipt, error) { panic("not implemented") }
+func (m *mockTransactionRepo) GetReceiptByID(ctx context.Context, id int64) (repo.Receipt, error) { panic("not implemented") }
+func (m *mockTransactionRepo) ListReceipts(ctx context.Context, transactionID int64) ([]repo.Receipt, error) { panic("not implemented") }
+func (m *mockTransactionRepo) DeleteReceipt(ctx context.Context, id int64) error { panic("not implemented") }
+func (m *mockTransactionRepo) GetTransactionByExternalID(ctx context.Context, arg repo.GetTransactionByExternalIDParams) (repo.Transaction, error) { panic("not implemented") }
+func (m *mockTransactionRepo) ToggleTransactionCleared(ctx context.Context, id int64) error { panic("not implemented") }
+func (m *mockTransactionRepo) SetTransactionCleared(ctx context.Context, arg repo.SetTransactionClearedParams) error { panic("not implemented") }
+func (m *mockTransactionRepo) GetNetByPeriod(ctx context.Context, arg repo.GetNetByPeriodParams) ([]repo.GetNetByPeriodRow, error) { panic("not implemented") }
+func (m *mockTransactionRepo) DeleteTransactionsByUser(ctx context.Context, userID int64) (int64, error) { panic("not implemented") }
+func (m *mockTransactionRepo) DeleteRecurringByUser(ctx context.Context, userID int64) (int64, error) { panic("not implemented") }
+func (m *mockTransactionRepo) ListStaleRecurring(ctx context.Context, arg repo.ListStaleRecurringParams) ([]repo.ListStaleRecurringRow, error) { panic("not implemented") }
+func (m *mockTransactionRepo) ListDailyActivity(ctx context.Context, arg repo.ListDailyActivityParams) ([]repo.ListDailyActivityRow, error) { panic("not implemented") }
+func (m *mockTransactionRepo) PurgeSoftDeletedTransaction(ctx context.Context, arg repo.PurgeSoftDeletedTransactionParams) (int64, error) { panic("not implemented") }
+func (m *mockTransactionRepo) AcquireSettingLock(ctx context.Context, arg repo.AcquireSettingLockParams) (int64, error) { panic("not implemented") }
+func (m *mockTransactionRepo) ReleaseSettingLock(ctx context.Context, arg repo.ReleaseSettingLockParams) error { panic("not implemented") }
+func (m *mockTransactionRepo) SearchTags(ctx context.Context, arg repo.SearchTagsParams) ([]repo.Tag, error) { panic("not implemented") }
+func (m *mockTransactionRepo) GetOrCreateTag(ctx context.Context, name string) (repo.Tag, bool, error) { panic("not implemented") }
+func (m *mockTransactionRepo) DeleteOtherSessionsByUserID(ctx context.Context, arg repo.DeleteOtherSessionsByUserIDParams) error { panic("not implemented") }
+func (m *mockTransactionRepo) DeleteTransactionTagsByUser(ctx context.Context, userID int64) error { panic("not implemented") }
+func (m *mockTransactionRepo) PurgeSoftDeletedTransactionTags(ctx context.Context, arg repo.PurgeSoftDeletedTransactionTagsParams) error { panic("not implemented") }
+func (m *mockTransactionRepo) PurgeAllSoftDeletedTags(ctx context.Context, userID int64) error { panic("not implemented") }
+func (m *mockTransactionRepo) PurgeSoftDeletedTransactionTagsByID(ctx context.Context, arg repo.PurgeSoftDeletedTransactionTagsByIDParams) error { panic("not implemented") }
+func (m *mockTransactionRepo) DeleteRecurringTagsByTag(ctx context.Context, tagID int64) error { panic("not implemented") }
+func (m *mockTransactionRepo) DeleteRecurringTagsByUser(ctx context.Context, userID int64) error { panic("not implemented") }
 
 func TestCreateTransaction(t *testing.T) {
 	gin.SetMode(gin.TestMode)
@@ -214,7 +307,7 @@ func TestCreateTransaction(t *testing.T) {
 				"note":    "Test transaction",
 				"tag_ids": []int64{1, 2},
 			},
-			expectedStatus: http.StatusOK,
+			expectedStatus: http.StatusCreated,
 			expectedError:  false,
 		},
 		{
@@ -223,7 +316,7 @@ func TestCreateTransaction(t *testing.T) {
 				"amount": "123.45",
 				"t_date": "2025-06-17",
 			},
-			expectedStatus: http.StatusOK,
+			expectedStatus: http.StatusCreated,
 			expectedError:  false,
 		},
 		{
@@ -321,6 +414,38 @@ func TestGetTransactions(t *testing.T) {
 	assert.Contains(t, firstTransaction, "t_date")
 }
 
+func TestGetTransactionsTotalCount(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mock := &mockTransactionRepo{
+		transactions: []repo.Transaction{
+			{ID: 1, UserID: 1, AmountPence: -100, TDate: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+			{ID: 2, UserID: 1, AmountPence: -200, TDate: time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)},
+			{ID: 3, UserID: 1, AmountPence: -300, TDate: time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC),
+				DeletedAt: sql.NullTime{Time: time.Now(), Valid: true}},
+		},
+		transactionTags: make(map[int64][]repo.Tag),
+	}
+	h := NewHandler(mock, zap.NewNop())
+	router := gin.New()
+	router.GET("/transactions", h.GetTransactions)
+
+	req := httptest.NewRequest("GET", "/transactions", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data  []model.TransactionResponse `json:"data"`
+		Total int64                       `json:"total"`
+		Meta  model.ListMeta              `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response.Data, 2)
+	assert.Equal(t, int64(2), response.Total)
+	assert.Equal(t, len(response.Data), response.Meta.Count)
+	assert.Equal(t, "2", w.Header().Get("X-Total-Count"))
+}
+
 func TestUpdateTransaction(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -417,4 +542,197 @@ func TestUpdateTransaction(t *testing.T) {
 			}
 		})
 	}
-} 
\ No newline at end of file
+} 
+func TestTransactionNoteValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("over-length note is rejected", func(t *testing.T) {
+		mock := &mockTransactionRepo{transactionTags: make(map[int64][]repo.Tag)}
+		h := NewHandler(mock, zap.NewNop())
+		router := gin.New()
+		router.POST("/transactions", ValidateRequest[model.CreateTransactionRequest](), h.CreateTransaction)
+
+		body, _ := json.Marshal(map[string]interface{}{
+			"amount": "-12.34",
+			"t_date": "2025-06-17",
+			"note":   strings.Repeat("a", 501),
+		})
+		req := httptest.NewRequest("POST", "/transactions", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var response map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		validationErrors := response["data"].(map[string]interface{})
+		assert.Equal(t, "must be at most 500 characters", validationErrors["note"])
+		assert.Empty(t, mock.transactions)
+	})
+
+	t.Run("note with control characters is rejected", func(t *testing.T) {
+		mock := &mockTransactionRepo{transactionTags: make(map[int64][]repo.Tag)}
+		h := NewHandler(mock, zap.NewNop())
+		router := gin.New()
+		router.POST("/transactions", ValidateRequest[model.CreateTransactionRequest](), h.CreateTransaction)
+
+		body, _ := json.Marshal(map[string]interface{}{
+			"amount": "-12.34",
+			"t_date": "2025-06-17",
+			"note":   "bad\x00note",
+		})
+		req := httptest.NewRequest("POST", "/transactions", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "must not contain control characters")
+		assert.Empty(t, mock.transactions)
+	})
+
+	t.Run("surrounding whitespace is trimmed on create", func(t *testing.T) {
+		mock := &mockTransactionRepo{transactionTags: make(map[int64][]repo.Tag)}
+		h := NewHandler(mock, zap.NewNop())
+		router := gin.New()
+		router.POST("/transactions", ValidateRequest[model.CreateTransactionRequest](), h.CreateTransaction)
+
+		body, _ := json.Marshal(map[string]interface{}{
+			"amount": "-12.34",
+			"t_date": "2025-06-17",
+			"note":   "  weekly shop \n",
+		})
+		req := httptest.NewRequest("POST", "/transactions", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		if assert.Len(t, mock.transactions, 1) {
+			assert.Equal(t, "weekly shop", mock.transactions[0].Note.String)
+		}
+	})
+
+	t.Run("surrounding whitespace is trimmed on update", func(t *testing.T) {
+		mock := &mockTransactionRepo{
+			transactions: []repo.Transaction{
+				{
+					ID:          1,
+					UserID:      1,
+					AmountPence: -1234,
+					TDate:       time.Date(2025, 6, 17, 0, 0, 0, 0, time.UTC),
+					Note:        sql.NullString{String: "Original note", Valid: true},
+				},
+			},
+			transactionTags: make(map[int64][]repo.Tag),
+		}
+		h := NewHandler(mock, zap.NewNop())
+		router := gin.New()
+		router.PATCH("/transactions/:id", ValidateRequest[model.UpdateTransactionRequest](), h.UpdateTransaction)
+
+		body, _ := json.Marshal(map[string]interface{}{"note": "\tUpdated note  "})
+		req := httptest.NewRequest("PATCH", "/transactions/1", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "Updated note", mock.transactions[0].Note.String)
+	})
+}
+
+func TestTransactionWithoutTagsHasEmptyTagIDs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mock := &mockTransactionRepo{
+		transactions: []repo.Transaction{
+			{
+				ID:          1,
+				UserID:      1,
+				AmountPence: -1234,
+				TDate:       time.Date(2025, 6, 17, 0, 0, 0, 0, time.UTC),
+				CreatedAt:   sql.NullTime{Time: time.Now(), Valid: true},
+			},
+		},
+		transactionTags: map[int64][]repo.Tag{1: nil},
+	}
+	h := NewHandler(mock, zap.NewNop())
+	router := gin.New()
+	router.GET("/transactions", h.GetTransactions)
+	router.GET("/transactions/:id", h.GetTransactionByID)
+
+	for _, url := range []string{"/transactions", "/transactions/1"} {
+		t.Run(url, func(t *testing.T) {
+			req := httptest.NewRequest("GET", url, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Contains(t, w.Body.String(), `"tag_ids":[]`)
+		})
+	}
+}
+
+func TestGetTransactionsGroupedByTag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mock := &mockTransactionRepo{
+		transactions: []repo.Transaction{
+			{ID: 1, UserID: 1, AmountPence: -1000, TDate: time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC)},
+			{ID: 2, UserID: 1, AmountPence: -2000, TDate: time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)},
+			{ID: 3, UserID: 1, AmountPence: 5000, TDate: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+			{ID: 4, UserID: 1, AmountPence: -300, TDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		tags: []repo.Tag{
+			{ID: 1, Name: "groceries"},
+			{ID: 2, Name: "entertainment"},
+		},
+		transactionTags: map[int64][]repo.Tag{
+			1: {{ID: 1}, {ID: 2}},
+			2: {{ID: 1}},
+			4: {{ID: 1}},
+		},
+	}
+	h := NewHandler(mock, zap.NewNop())
+	router := gin.New()
+	router.GET("/transactions/by-tag/:tag_id", h.GetTransactionsByTag)
+	router.GET("/transactions/by-tag-grouped", h.GetTransactionsGroupedByTag)
+
+	t.Run("groups transactions in range by tag", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/transactions/by-tag-grouped?from=2025-06-01&to=2025-06-30", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data []model.TagTransactionsGroup `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+		ids := func(group model.TagTransactionsGroup) []int64 {
+			result := make([]int64, len(group.Transactions))
+			for i, txn := range group.Transactions {
+				result[i] = txn.ID
+			}
+			return result
+		}
+
+		if assert.Len(t, response.Data, 3) {
+			assert.Equal(t, "entertainment", response.Data[0].TagName)
+			assert.Equal(t, int64(2), *response.Data[0].TagID)
+			assert.Equal(t, []int64{1}, ids(response.Data[0]))
+
+			assert.Equal(t, "groceries", response.Data[1].TagName)
+			assert.Equal(t, int64(1), *response.Data[1].TagID)
+			assert.Equal(t, []int64{1, 2}, ids(response.Data[1]))
+
+			assert.Equal(t, "untagged", response.Data[2].TagName)
+			assert.Nil(t, response.Data[2].TagID)
+			assert.Equal(t, []int64{3}, ids(response.Data[2]))
+		}
+	})
+
+	t.Run("invalid date", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/transactions/by-tag-grouped?from=bad", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
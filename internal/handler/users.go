@@ -5,10 +5,10 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
-	"golang.org/x/crypto/bcrypt"
 
 	"github.com/piotrzalecki/budget-api/internal/repo"
 	"github.com/piotrzalecki/budget-api/pkg/model"
@@ -51,6 +51,7 @@ func (h *Handler) ListUsers(c *gin.Context) {
 // @Success 201 {object} model.UserResponse
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
+// @Failure 422 {object} model.ErrorResponse "Password does not meet policy"
 // @Router /users [post]
 func (h *Handler) CreateUser(c *gin.Context) {
 	req, ok := GetValidatedRequest[model.CreateUserRequest](c)
@@ -59,7 +60,12 @@ func (h *Handler) CreateUser(c *gin.Context) {
 		return
 	}
 
-	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err := PasswordPolicyFromEnv().Validate(req.Password); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	hash, err := model.HashPassword(req.Password)
 	if err != nil {
 		h.logger.Error("failed to hash password", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
@@ -68,7 +74,7 @@ func (h *Handler) CreateUser(c *gin.Context) {
 
 	user, err := h.repo.CreateUser(c.Request.Context(), repo.CreateUserParams{
 		Email:     req.Email,
-		PwHash:    string(hash),
+		PwHash:    hash,
 		IsService: req.IsService,
 	})
 	if err != nil {
@@ -113,20 +119,21 @@ func (h *Handler) GetUserByID(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"data": userToResponse(user), "error": nil})
 }
 
-// UpdateUser updates a user's email and/or password.
+// UpdateUser updates a user's email, password and/or timezone.
 //
 // @Summary Update user
-// @Description Update a user's email and/or password
+// @Description Update a user's email, password and/or timezone (an IANA name such as Europe/London)
 // @Tags users
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param id path int true "User ID"
 // @Param request body model.UpdateUserRequest true "Fields to update"
-// @Success 200 {object} model.UserResponse
+// @Success 204
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 404 {object} model.ErrorResponse
+// @Failure 422 {object} model.ErrorResponse "Password does not meet policy"
 // @Router /users/{id} [patch]
 func (h *Handler) UpdateUser(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -141,6 +148,13 @@ func (h *Handler) UpdateUser(c *gin.Context) {
 		return
 	}
 
+	if req.Password != nil {
+		if err := PasswordPolicyFromEnv().Validate(*req.Password); err != nil {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
 	existing, err := h.repo.GetUserByID(c.Request.Context(), id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -153,33 +167,261 @@ func (h *Handler) UpdateUser(c *gin.Context) {
 	}
 
 	params := repo.UpdateUserParams{
-		ID:     id,
-		Email:  existing.Email,
-		PwHash: existing.PwHash,
+		ID:       id,
+		Email:    existing.Email,
+		PwHash:   existing.PwHash,
+		Timezone: existing.Timezone,
 	}
 	if req.Email != nil {
 		params.Email = *req.Email
 	}
+	if req.Timezone != nil {
+		params.Timezone = *req.Timezone
+	}
 	if req.Password != nil {
-		hash, err := bcrypt.GenerateFromPassword([]byte(*req.Password), bcrypt.DefaultCost)
+		hash, err := model.HashPassword(*req.Password)
 		if err != nil {
 			h.logger.Error("failed to hash password", zap.Error(err))
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 			return
 		}
-		params.PwHash = string(hash)
+		params.PwHash = hash
 	}
 
-	user, err := h.repo.UpdateUser(c.Request.Context(), params)
+	_, err = h.repo.UpdateUser(c.Request.Context(), params)
 	if err != nil {
 		h.logger.Error("failed to update user", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		return
 	}
 
+	c.Status(http.StatusNoContent)
+}
+
+// profileUserID returns the user the request is authenticated as. When no
+// user is on the context it writes a 401 response and returns false.
+func profileUserID(c *gin.Context) (int64, bool) {
+	userID := GetUserID(c)
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return 0, false
+	}
+	return userID, true
+}
+
+// GetProfile returns the authenticated user.
+//
+// @Summary Get profile
+// @Description Get the authenticated user's account
+// @Tags users
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} model.UserResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Router /me [get]
+func (h *Handler) GetProfile(c *gin.Context) {
+	userID, ok := profileUserID(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.repo.GetUserByID(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+			return
+		}
+		h.logger.Error("failed to get user", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"data": userToResponse(user), "error": nil})
 }
 
+// UpdateProfile changes the authenticated user's email and/or password.
+//
+// @Summary Update profile
+// @Description Change the authenticated user's email and/or password; current_password must match the existing password. A password change signs out every other session.
+// @Tags users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body model.UpdateProfileRequest true "Fields to update"
+// @Success 204
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse "Current password is incorrect"
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse "Email already registered"
+// @Failure 422 {object} model.ErrorResponse "Password does not meet policy"
+// @Router /me [patch]
+func (h *Handler) UpdateProfile(c *gin.Context) {
+	req, ok := GetValidatedRequest[model.UpdateProfileRequest](c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	if req.Password != nil {
+		if err := PasswordPolicyFromEnv().Validate(*req.Password); err != nil {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	userID, ok := profileUserID(c)
+	if !ok {
+		return
+	}
+
+	existing, err := h.repo.GetUserByID(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+			return
+		}
+		h.logger.Error("failed to get user", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if !model.CheckPassword(existing.PwHash, req.CurrentPassword) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "current password is incorrect"})
+		return
+	}
+
+	params := repo.UpdateUserParams{
+		ID:       existing.ID,
+		Email:    existing.Email,
+		PwHash:   existing.PwHash,
+		Timezone: existing.Timezone,
+	}
+	if req.Email != nil && *req.Email != existing.Email {
+		_, err := h.repo.GetUserByEmail(c.Request.Context(), *req.Email)
+		if err == nil {
+			c.JSON(http.StatusConflict, gin.H{"error": "email already registered"})
+			return
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			h.logger.Error("failed to look up user", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+		params.Email = *req.Email
+	}
+	if req.Password != nil {
+		hash, err := model.HashPassword(*req.Password)
+		if err != nil {
+			h.logger.Error("failed to hash password", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+		params.PwHash = hash
+	}
+
+	// A new password signs out every other session, so whoever held the
+	// old one loses access; the session making the change stays valid
+	err = h.repo.WithTx(c.Request.Context(), func(r repo.Repository) error {
+		if _, err := r.UpdateUser(c.Request.Context(), params); err != nil {
+			return err
+		}
+		if req.Password == nil {
+			return nil
+		}
+		var currentToken string
+		if authHeader := c.GetHeader("Authorization"); strings.HasPrefix(authHeader, "Bearer ") {
+			currentToken = authHeader[len("Bearer "):]
+		}
+		return r.DeleteOtherSessionsByUserID(c.Request.Context(), repo.DeleteOtherSessionsByUserIDParams{
+			UserID: existing.ID,
+			Token:  currentToken,
+		})
+	})
+	if err != nil {
+		h.logger.Error("failed to update user", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteProfile deletes the authenticated user together with their
+// transactions, recurring rules and everything attached to them.
+//
+// @Summary Delete account
+// @Description Permanently delete the authenticated user and all their data; password must match the existing password
+// @Tags users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body model.DeleteAccountRequest true "Password confirmation"
+// @Success 204
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse "Password is incorrect"
+// @Failure 404 {object} model.ErrorResponse
+// @Router /me [delete]
+func (h *Handler) DeleteProfile(c *gin.Context) {
+	req, ok := GetValidatedRequest[model.DeleteAccountRequest](c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	userID, ok := profileUserID(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.repo.GetUserByID(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+			return
+		}
+		h.logger.Error("failed to get user", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if !model.CheckPassword(user.PwHash, req.Password) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "password is incorrect"})
+		return
+	}
+
+	// Transactions go first because they may point at the user's recurring
+	// rules, and tag links go before what they link. Receipts, history,
+	// alerts and sessions cascade.
+	err = h.repo.WithTx(c.Request.Context(), func(r repo.Repository) error {
+		if err := r.DeleteTransactionTagsByUser(c.Request.Context(), user.ID); err != nil {
+			return err
+		}
+		if _, err := r.DeleteTransactionsByUser(c.Request.Context(), user.ID); err != nil {
+			return err
+		}
+		if err := r.DeleteRecurringTagsByUser(c.Request.Context(), user.ID); err != nil {
+			return err
+		}
+		if _, err := r.DeleteRecurringByUser(c.Request.Context(), user.ID); err != nil {
+			return err
+		}
+		if err := r.DeleteAllSessionsByUserID(c.Request.Context(), user.ID); err != nil {
+			return err
+		}
+		return r.DeleteUser(c.Request.Context(), user.ID)
+	})
+	if err != nil {
+		h.logger.Error("failed to delete account", zap.Error(err), zap.Int64("user_id", user.ID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 // DeleteUser deletes a user and all their sessions.
 //
 // @Summary Delete user
@@ -219,6 +461,7 @@ func userToResponse(u repo.User) model.UserResponse {
 		ID:        u.ID,
 		Email:     u.Email,
 		IsService: u.IsService,
+		Timezone:  u.Timezone,
 	}
 	if u.CreatedAt.Valid {
 		t := u.CreatedAt.Time
@@ -0,0 +1,229 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+	"github.com/piotrzalecki/budget-api/pkg/model"
+)
+
+func TestProfileIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("BUDGET_API_KEY", "integration-test-key")
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ctx := context.Background()
+
+	hash, err := model.HashPassword("correct horse")
+	require.NoError(t, err)
+	user, err := repository.CreateUser(ctx, repo.CreateUserParams{Email: "me@example.com", PwHash: hash})
+	require.NoError(t, err)
+	_, err = repository.CreateUser(ctx, repo.CreateUserParams{Email: "taken@example.com", PwHash: hash})
+	require.NoError(t, err)
+	_, err = repository.CreateSession(ctx, repo.CreateSessionParams{
+		UserID:    user.ID,
+		Token:     "profile-token",
+		ExpiresAt: sql.NullTime{Time: time.Now().UTC().Add(time.Hour), Valid: true},
+	})
+	require.NoError(t, err)
+	_, err = repository.CreateSession(ctx, repo.CreateSessionParams{
+		UserID:    user.ID,
+		Token:     "other-device-token",
+		ExpiresAt: sql.NullTime{Time: time.Now().UTC().Add(time.Hour), Valid: true},
+	})
+	require.NoError(t, err)
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	v1 := router.Group("/", APIKeyOrSessionAuth(repository))
+	v1.GET("/me", h.GetProfile)
+	v1.PATCH("/me", ValidateRequest[model.UpdateProfileRequest](), h.UpdateProfile)
+
+	do := func(method, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/me", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer profile-token")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	profile := func() model.UserResponse {
+		w := do("GET", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Data model.UserResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data
+	}
+
+	t.Run("fetches the authenticated user", func(t *testing.T) {
+		me := profile()
+		assert.Equal(t, user.ID, me.ID)
+		assert.Equal(t, "me@example.com", me.Email)
+	})
+
+	t.Run("rejects a wrong current password", func(t *testing.T) {
+		w := do("PATCH", `{"email": "new@example.com", "current_password": "wrong horse"}`)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Equal(t, "me@example.com", profile().Email)
+	})
+
+	t.Run("rejects an email in use", func(t *testing.T) {
+		w := do("PATCH", `{"email": "taken@example.com", "current_password": "correct horse"}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("changes the email", func(t *testing.T) {
+		w := do("PATCH", `{"email": "new@example.com", "current_password": "correct horse"}`)
+		require.Equal(t, http.StatusNoContent, w.Code)
+		assert.Empty(t, w.Body.String())
+		assert.Equal(t, "new@example.com", profile().Email)
+
+		// Other sessions survive a change that leaves the password alone
+		_, err := repository.GetSessionByToken(ctx, "other-device-token")
+		assert.NoError(t, err)
+	})
+
+	t.Run("changes the password", func(t *testing.T) {
+		w := do("PATCH", `{"password": "battery staple", "current_password": "correct horse"}`)
+		require.Equal(t, http.StatusNoContent, w.Code)
+
+		updated, err := repository.GetUserByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.True(t, model.CheckPassword(updated.PwHash, "battery staple"))
+
+		// Every other session is signed out; the one making the change isn't
+		_, err = repository.GetSessionByToken(ctx, "other-device-token")
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.Equal(t, user.ID, profile().ID)
+	})
+}
+
+func TestDeleteProfileIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("BUDGET_API_KEY", "integration-test-key")
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repository := repo.NewRepository(db)
+	ensureDefaultUser(t, repository)
+	ctx := context.Background()
+
+	hash, err := model.HashPassword("correct horse")
+	require.NoError(t, err)
+	user, err := repository.CreateUser(ctx, repo.CreateUserParams{Email: "leaving@example.com", PwHash: hash})
+	require.NoError(t, err)
+	_, err = repository.CreateSession(ctx, repo.CreateSessionParams{
+		UserID:    user.ID,
+		Token:     "leaving-token",
+		ExpiresAt: sql.NullTime{Time: time.Now().UTC().Add(time.Hour), Valid: true},
+	})
+	require.NoError(t, err)
+
+	tag, err := repository.CreateTag(ctx, "delete-account-tag")
+	require.NoError(t, err)
+
+	rule, err := repository.CreateRecurring(ctx, repo.CreateRecurringParams{
+		UserID:       user.ID,
+		AmountPence:  -1500,
+		Frequency:    "monthly",
+		IntervalN:    1,
+		FirstDueDate: time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC),
+		NextDueDate:  time.Date(2031, 2, 1, 0, 0, 0, 0, time.UTC),
+		Active:       true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, repository.CreateRecurringTag(ctx, repo.CreateRecurringTagParams{RecurringID: rule.ID, TagID: tag.ID}))
+	_, err = repository.CreateRecurringHistory(ctx, repo.CreateRecurringHistoryParams{RecurringID: rule.ID, Action: "paused"})
+	require.NoError(t, err)
+
+	txn, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+		UserID:          user.ID,
+		AmountPence:     -1500,
+		TDate:           time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC),
+		SourceRecurring: sql.NullInt64{Int64: rule.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.NoError(t, repository.CreateTransactionTag(ctx, repo.CreateTransactionTagParams{TransactionID: txn.ID, TagID: tag.ID}))
+	_, err = repository.CreateReceipt(ctx, repo.CreateReceiptParams{TransactionID: txn.ID, Url: "https://example.com/receipt.pdf"})
+	require.NoError(t, err)
+	_, err = repository.CreateTagAlert(ctx, repo.CreateTagAlertParams{UserID: user.ID, TagID: tag.ID, ThresholdPence: 10000})
+	require.NoError(t, err)
+
+	// Another user's data must survive
+	otherTxn, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+		UserID:      1,
+		AmountPence: -300,
+		TDate:       time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.NoError(t, repository.CreateTransactionTag(ctx, repo.CreateTransactionTagParams{TransactionID: otherTxn.ID, TagID: tag.ID}))
+
+	h := NewHandler(repository, zap.NewNop())
+	router := gin.New()
+	v1 := router.Group("/", APIKeyOrSessionAuth(repository))
+	v1.DELETE("/me", ValidateRequest[model.DeleteAccountRequest](), h.DeleteProfile)
+
+	deleteAccount := func(password string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]string{"password": password})
+		req := httptest.NewRequest("DELETE", "/me", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer leaving-token")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	count := func(query string, args ...interface{}) int {
+		var n int
+		require.NoError(t, db.QueryRow(query, args...).Scan(&n))
+		return n
+	}
+
+	t.Run("rejects a wrong password", func(t *testing.T) {
+		w := deleteAccount("wrong horse")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		_, err := repository.GetUserByID(ctx, user.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("removes the user and all their data", func(t *testing.T) {
+		w := deleteAccount("correct horse")
+		require.Equal(t, http.StatusNoContent, w.Code)
+		assert.Empty(t, w.Body.String())
+
+		_, err := repository.GetUserByID(ctx, user.ID)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.Zero(t, count("SELECT COUNT(*) FROM transactions WHERE user_id = ?", user.ID))
+		assert.Zero(t, count("SELECT COUNT(*) FROM recurring WHERE user_id = ?", user.ID))
+		assert.Zero(t, count("SELECT COUNT(*) FROM transaction_tags WHERE transaction_id = ?", txn.ID))
+		assert.Zero(t, count("SELECT COUNT(*) FROM recurring_tags WHERE recurring_id = ?", rule.ID))
+		assert.Zero(t, count("SELECT COUNT(*) FROM recurring_history WHERE recurring_id = ?", rule.ID))
+		assert.Zero(t, count("SELECT COUNT(*) FROM receipts WHERE transaction_id = ?", txn.ID))
+		assert.Zero(t, count("SELECT COUNT(*) FROM tag_alerts WHERE user_id = ?", user.ID))
+		assert.Zero(t, count("SELECT COUNT(*) FROM sessions WHERE user_id = ?", user.ID))
+
+		assert.Equal(t, 1, count("SELECT COUNT(*) FROM transaction_tags WHERE transaction_id = ?", otherTxn.ID))
+		_, err = repository.GetTagByID(ctx, tag.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("the token no longer authenticates", func(t *testing.T) {
+		w := deleteAccount("correct horse")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+	"github.com/piotrzalecki/budget-api/pkg/model"
+)
+
+func TestUpdateUserReturnsNoContent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockRepo := new(MockRepository)
+	existing := repo.User{ID: 1, Email: "old@example.com", PwHash: "hash", Timezone: "UTC"}
+	mockRepo.On("GetUserByID", mock.Anything, int64(1)).Return(existing, nil)
+	mockRepo.On("UpdateUser", mock.Anything, repo.UpdateUserParams{
+		ID:       1,
+		Email:    "new@example.com",
+		PwHash:   "hash",
+		Timezone: "UTC",
+	}).Return(repo.User{ID: 1, Email: "new@example.com", PwHash: "hash", Timezone: "UTC"}, nil)
+
+	h := NewHandler(mockRepo, zap.NewNop())
+	router := gin.New()
+	router.PATCH("/users/:id", ValidateRequest[model.UpdateUserRequest](), h.UpdateUser)
+
+	req := httptest.NewRequest("PATCH", "/users/1", bytes.NewBufferString(`{"email": "new@example.com"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Body.String())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestProfileRequiresAuthenticatedUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockRepo := new(MockRepository)
+	h := NewHandler(mockRepo, zap.NewNop())
+	router := gin.New()
+	router.GET("/me", h.GetProfile)
+	router.PATCH("/me", ValidateRequest[model.UpdateProfileRequest](), h.UpdateProfile)
+	router.DELETE("/me", ValidateRequest[model.DeleteAccountRequest](), h.DeleteProfile)
+
+	for _, tt := range []struct {
+		method string
+		body   string
+	}{
+		{"GET", ""},
+		{"PATCH", `{"current_password": "correct horse", "email": "new@example.com"}`},
+		{"DELETE", `{"password": "correct horse"}`},
+	} {
+		req := httptest.NewRequest(tt.method, "/me", bytes.NewBufferString(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code, tt.method)
+		assert.JSONEq(t, `{"error": "not authenticated"}`, w.Body.String(), tt.method)
+	}
+	mockRepo.AssertExpectations(t)
+}
@@ -27,24 +27,39 @@ type Repository interface {
 	GetSessionByToken(ctx context.Context, token string) (GetSessionByTokenRow, error)
 	DeleteSession(ctx context.Context, token string) error
 	DeleteAllSessionsByUserID(ctx context.Context, userID int64) error
+	DeleteOtherSessionsByUserID(ctx context.Context, arg DeleteOtherSessionsByUserIDParams) error
 
 	// Transaction operations
 	CreateTransaction(ctx context.Context, arg CreateTransactionParams) (Transaction, error)
 	GetTransactionByID(ctx context.Context, id int64) (Transaction, error)
+	GetTransactionByExternalID(ctx context.Context, arg GetTransactionByExternalIDParams) (Transaction, error)
 	ListTransactions(ctx context.Context, arg ListTransactionsParams) ([]Transaction, error)
+	CountTransactions(ctx context.Context, arg CountTransactionsParams) (int64, error)
 	ListTransactionsByDateRange(ctx context.Context, userID int64) ([]Transaction, error)
 	GetTransactionsByRecurringID(ctx context.Context, sourceRecurring sql.NullInt64) ([]Transaction, error)
 	GetTransactionsByTag(ctx context.Context, tagID int64) ([]Transaction, error)
 	UpdateTransaction(ctx context.Context, arg UpdateTransactionParams) (Transaction, error)
+	ToggleTransactionCleared(ctx context.Context, id int64) error
+	SetTransactionCleared(ctx context.Context, arg SetTransactionClearedParams) error
 	SoftDeleteTransaction(ctx context.Context, id int64) error
+	SoftDeleteAllTransactionsByUser(ctx context.Context, userID int64) (int64, error)
 	HardDeleteTransaction(ctx context.Context, id int64) error
-	PurgeSoftDeletedTransactions(ctx context.Context, deletedAt sql.NullTime) error
+	DeleteTransactionsByUser(ctx context.Context, userID int64) (int64, error)
+	DeleteTransactionTagsByUser(ctx context.Context, userID int64) error
+	PurgeSoftDeletedTransactions(ctx context.Context, arg PurgeSoftDeletedTransactionsParams) (int64, error)
+	PurgeAllSoftDeleted(ctx context.Context, userID int64) (int64, error)
+	PurgeSoftDeletedTransaction(ctx context.Context, arg PurgeSoftDeletedTransactionParams) (int64, error)
+	PurgeSoftDeletedTransactionTags(ctx context.Context, arg PurgeSoftDeletedTransactionTagsParams) error
+	PurgeAllSoftDeletedTags(ctx context.Context, userID int64) error
+	PurgeSoftDeletedTransactionTagsByID(ctx context.Context, arg PurgeSoftDeletedTransactionTagsByIDParams) error
 
 	// Tag operations
 	CreateTag(ctx context.Context, name string) (Tag, error)
+	GetOrCreateTag(ctx context.Context, name string) (Tag, bool, error)
 	GetTagByID(ctx context.Context, id int64) (Tag, error)
 	GetTagByName(ctx context.Context, name string) (Tag, error)
 	ListTags(ctx context.Context) ([]Tag, error)
+	SearchTags(ctx context.Context, arg SearchTagsParams) ([]Tag, error)
 	UpdateTag(ctx context.Context, arg UpdateTagParams) (Tag, error)
 	DeleteTag(ctx context.Context, id int64) error
 
@@ -53,18 +68,29 @@ type Repository interface {
 	GetTransactionTags(ctx context.Context, transactionID int64) ([]Tag, error)
 	DeleteTransactionTag(ctx context.Context, arg DeleteTransactionTagParams) error
 	DeleteAllTransactionTags(ctx context.Context, transactionID int64) error
+	ReassignTransactionTags(ctx context.Context, arg ReassignTransactionTagsParams) (int64, error)
+	DeleteTransactionTagsByTag(ctx context.Context, tagID int64) (int64, error)
+	DeleteRecurringTagsByTag(ctx context.Context, tagID int64) error
+	ListTransactionTagsByDateRange(ctx context.Context, arg ListTransactionTagsByDateRangeParams) ([]ListTransactionTagsByDateRangeRow, error)
 
 	// Recurring operations
 	CreateRecurring(ctx context.Context, arg CreateRecurringParams) (Recurring, error)
 	GetRecurringByID(ctx context.Context, id int64) (Recurring, error)
-	ListRecurring(ctx context.Context, userID int64) ([]Recurring, error)
-	ListActiveRecurring(ctx context.Context, userID int64) ([]Recurring, error)
+	ListRecurring(ctx context.Context, arg ListRecurringParams) ([]Recurring, error)
+	CountRecurring(ctx context.Context, arg CountRecurringParams) (int64, error)
+	ListActiveRecurring(ctx context.Context, arg ListActiveRecurringParams) ([]Recurring, error)
+	CountActiveRecurring(ctx context.Context, arg CountActiveRecurringParams) (int64, error)
+	ListStaleRecurring(ctx context.Context, arg ListStaleRecurringParams) ([]ListStaleRecurringRow, error)
 	GetRecurringByTag(ctx context.Context, tagID int64) ([]Recurring, error)
 	GetRecurringDueOnDate(ctx context.Context, nextDueDate time.Time) ([]Recurring, error)
 	UpdateRecurring(ctx context.Context, arg UpdateRecurringParams) (Recurring, error)
 	UpdateRecurringNextDue(ctx context.Context, arg UpdateRecurringNextDueParams) error
 	ToggleRecurringActive(ctx context.Context, id int64) error
 	DeleteRecurring(ctx context.Context, id int64) error
+	DeleteRecurringByUser(ctx context.Context, userID int64) (int64, error)
+	DeleteRecurringTagsByUser(ctx context.Context, userID int64) error
+	SoftDeleteRecurring(ctx context.Context, id int64) error
+	DetachRecurringTransactions(ctx context.Context, sourceRecurring sql.NullInt64) error
 
 	// Recurring tag operations
 	CreateRecurringTag(ctx context.Context, arg CreateRecurringTagParams) error
@@ -72,14 +98,42 @@ type Repository interface {
 	DeleteRecurringTag(ctx context.Context, arg DeleteRecurringTagParams) error
 	DeleteAllRecurringTags(ctx context.Context, recurringID int64) error
 
+	// Recurring history operations
+	CreateRecurringHistory(ctx context.Context, arg CreateRecurringHistoryParams) (RecurringHistory, error)
+	ListRecurringHistory(ctx context.Context, arg ListRecurringHistoryParams) ([]RecurringHistory, error)
+	CountRecurringHistory(ctx context.Context, recurringID int64) (int64, error)
+	DeleteRecurringHistory(ctx context.Context, recurringID int64) error
+
 	// Settings operations
 	CreateSetting(ctx context.Context, arg CreateSettingParams) (Setting, error)
 	GetSetting(ctx context.Context, key string) (Setting, error)
 	ListSettings(ctx context.Context) ([]Setting, error)
 	UpdateSetting(ctx context.Context, arg UpdateSettingParams) (Setting, error)
 	DeleteSetting(ctx context.Context, key string) error
+	AcquireSettingLock(ctx context.Context, arg AcquireSettingLockParams) (int64, error)
+	ReleaseSettingLock(ctx context.Context, arg ReleaseSettingLockParams) error
+
+	// Receipt operations
+	CreateReceipt(ctx context.Context, arg CreateReceiptParams) (Receipt, error)
+	GetReceiptByID(ctx context.Context, id int64) (Receipt, error)
+	ListReceipts(ctx context.Context, transactionID int64) ([]Receipt, error)
+	DeleteReceipt(ctx context.Context, id int64) error
+
+	// Tag alert operations
+	CreateTagAlert(ctx context.Context, arg CreateTagAlertParams) (TagAlert, error)
+	GetTagAlertByID(ctx context.Context, id int64) (TagAlert, error)
+	ListTagAlerts(ctx context.Context, userID int64) ([]TagAlert, error)
+	UpdateTagAlertThreshold(ctx context.Context, arg UpdateTagAlertThresholdParams) (TagAlert, error)
+	MarkTagAlertNotified(ctx context.Context, arg MarkTagAlertNotifiedParams) error
+	DeleteTagAlert(ctx context.Context, id int64) error
+	GetTagMonthlySpend(ctx context.Context, arg GetTagMonthlySpendParams) (sql.NullFloat64, error)
 
 	// Report operations
 	GetMonthlyReport(ctx context.Context, arg GetMonthlyReportParams) ([]GetMonthlyReportRow, error)
 	GetMonthlyTotals(ctx context.Context, arg GetMonthlyTotalsParams) (GetMonthlyTotalsRow, error)
+	GetTopSpendingTags(ctx context.Context, arg GetTopSpendingTagsParams) ([]GetTopSpendingTagsRow, error)
+	ListTransactionAmountsByDateRange(ctx context.Context, arg ListTransactionAmountsByDateRangeParams) ([]ListTransactionAmountsByDateRangeRow, error)
+	ListDailyActivity(ctx context.Context, arg ListDailyActivityParams) ([]ListDailyActivityRow, error)
+	GetBalanceBefore(ctx context.Context, arg GetBalanceBeforeParams) (sql.NullFloat64, error)
+	GetNetByPeriod(ctx context.Context, arg GetNetByPeriodParams) ([]GetNetByPeriodRow, error)
 } 
\ No newline at end of file
@@ -9,6 +9,14 @@ import (
 	"time"
 )
 
+type Receipt struct {
+	ID            int64
+	TransactionID int64
+	Url           string
+	ContentType   sql.NullString
+	UploadedAt    sql.NullTime
+}
+
 type Recurring struct {
 	ID           int64
 	UserID       int64
@@ -21,6 +29,15 @@ type Recurring struct {
 	EndDate      sql.NullTime
 	Active       bool
 	CreatedAt    sql.NullTime
+	GroupName    sql.NullString
+	DeletedAt    sql.NullTime
+}
+
+type RecurringHistory struct {
+	ID          int64
+	RecurringID int64
+	Action      string
+	CreatedAt   sql.NullTime
 }
 
 type RecurringTag struct {
@@ -42,8 +59,18 @@ type Setting struct {
 }
 
 type Tag struct {
-	ID   int64
-	Name string
+	ID        int64
+	Name      string
+	CreatedAt sql.NullTime
+}
+
+type TagAlert struct {
+	ID             int64
+	UserID         int64
+	TagID          int64
+	ThresholdPence int64
+	LastNotifiedYm sql.NullString
+	CreatedAt      sql.NullTime
 }
 
 type Transaction struct {
@@ -55,6 +82,9 @@ type Transaction struct {
 	CreatedAt       sql.NullTime
 	SourceRecurring sql.NullInt64
 	DeletedAt       sql.NullTime
+	Version         int64
+	ExternalID      sql.NullString
+	Cleared         bool
 }
 
 type TransactionTag struct {
@@ -68,4 +98,5 @@ type User struct {
 	PwHash    string
 	CreatedAt sql.NullTime
 	IsService bool
+	Timezone  string
 }
@@ -24,6 +24,10 @@ WHERE token = ?;
 DELETE FROM sessions
 WHERE user_id = ?;
 
+-- name: DeleteOtherSessionsByUserID :exec
+DELETE FROM sessions
+WHERE user_id = ? AND token != ?;
+
 -- name: GetUserByID :one
 SELECT * FROM users
 WHERE id = ?;
@@ -38,7 +42,7 @@ ORDER BY created_at DESC;
 
 -- name: UpdateUser :one
 UPDATE users
-SET email = ?, pw_hash = ?
+SET email = ?, pw_hash = ?, timezone = ?
 WHERE id = ?
 RETURNING *;
 
@@ -70,11 +74,26 @@ RETURNING *;
 DELETE FROM settings
 WHERE key = ?;
 
+-- name: AcquireSettingLock :execrows
+INSERT INTO settings (key, value)
+VALUES (sqlc.arg(key), sqlc.arg(value))
+ON CONFLICT(key) DO UPDATE SET value = excluded.value
+WHERE settings.value < sqlc.arg(stale_before);
+
+-- name: ReleaseSettingLock :exec
+DELETE FROM settings
+WHERE key = ? AND value = ?;
+
 -- name: CreateTag :one
-INSERT INTO tags (name)
-VALUES (?)
+INSERT INTO tags (name, created_at)
+VALUES (?, CURRENT_TIMESTAMP)
 RETURNING *;
 
+-- name: CreateTagIfNotExists :execrows
+INSERT INTO tags (name, created_at)
+VALUES (?, CURRENT_TIMESTAMP)
+ON CONFLICT(name) DO NOTHING;
+
 -- name: GetTagByID :one
 SELECT * FROM tags
 WHERE id = ?;
@@ -87,6 +106,12 @@ WHERE name = ?;
 SELECT * FROM tags
 ORDER BY name;
 
+-- name: SearchTags :many
+SELECT * FROM tags
+WHERE name LIKE '%' || sqlc.arg(query) || '%' ESCAPE '\'
+ORDER BY CASE WHEN name LIKE sqlc.arg(query) || '%' ESCAPE '\' THEN 0 ELSE 1 END, name
+LIMIT sqlc.arg(limit);
+
 -- name: UpdateTag :one
 UPDATE tags
 SET name = ?
@@ -98,33 +123,104 @@ DELETE FROM tags
 WHERE id = ?;
 
 -- name: CreateTransaction :one
-INSERT INTO transactions (user_id, amount_pence, t_date, note, source_recurring)
-VALUES (?, ?, ?, ?, ?)
+INSERT INTO transactions (user_id, amount_pence, t_date, note, source_recurring, external_id)
+VALUES (?, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: GetTransactionByID :one
 SELECT * FROM transactions
 WHERE id = ? AND deleted_at IS NULL;
 
+-- name: GetTransactionByExternalID :one
+SELECT * FROM transactions
+WHERE user_id = ? AND external_id = ?;
+
 -- name: ListTransactions :many
 SELECT * FROM transactions
 WHERE user_id = ? AND deleted_at IS NULL
   AND (t_date >= ? OR ? IS NULL)
   AND (t_date <= ? OR ? IS NULL)
+  AND created_at >= COALESCE(sqlc.narg(created_from), created_at)
+  AND created_at <= COALESCE(sqlc.narg(created_to), created_at)
+  AND cleared = COALESCE(sqlc.narg(cleared), cleared)
+  AND (sqlc.arg(min_tag_matches) = 0 OR id IN (
+    SELECT transaction_id FROM transaction_tags
+    WHERE tag_id IN (SELECT value FROM json_each(COALESCE(sqlc.narg(tag_ids), '[]')))
+    GROUP BY transaction_id
+    HAVING COUNT(DISTINCT tag_id) >= sqlc.arg(min_tag_matches)
+  ))
 ORDER BY t_date DESC, created_at DESC;
 
+-- name: CountTransactions :one
+SELECT COUNT(*) FROM transactions
+WHERE user_id = ? AND deleted_at IS NULL
+  AND (t_date >= ? OR ? IS NULL)
+  AND (t_date <= ? OR ? IS NULL)
+  AND created_at >= COALESCE(sqlc.narg(created_from), created_at)
+  AND created_at <= COALESCE(sqlc.narg(created_to), created_at)
+  AND cleared = COALESCE(sqlc.narg(cleared), cleared)
+  AND (sqlc.arg(min_tag_matches) = 0 OR id IN (
+    SELECT transaction_id FROM transaction_tags
+    WHERE tag_id IN (SELECT value FROM json_each(COALESCE(sqlc.narg(tag_ids), '[]')))
+    GROUP BY transaction_id
+    HAVING COUNT(DISTINCT tag_id) >= sqlc.arg(min_tag_matches)
+  ));
+
 -- name: ListTransactionsByDateRange :many
 SELECT * FROM transactions
 WHERE user_id = ? AND deleted_at IS NULL
   AND t_date BETWEEN ? AND ?
 ORDER BY t_date DESC, created_at DESC;
 
+-- name: ListTransactionTagsByDateRange :many
+SELECT tt.transaction_id, t.id, t.name FROM transaction_tags tt
+JOIN tags t ON tt.tag_id = t.id
+JOIN transactions tx ON tt.transaction_id = tx.id
+WHERE tx.user_id = ? AND tx.deleted_at IS NULL
+  AND tx.t_date >= ? AND tx.t_date <= ?
+ORDER BY t.name;
+
+-- name: ListTransactionAmountsByDateRange :many
+SELECT t_date, amount_pence FROM transactions
+WHERE user_id = ? AND deleted_at IS NULL
+  AND t_date >= ? AND t_date <= ?
+ORDER BY t_date ASC, id ASC;
+
+-- name: ListDailyActivity :many
+SELECT
+    CAST(date(t_date) AS TEXT) as day,
+    COUNT(*) as transaction_count,
+    CAST(SUM(amount_pence) AS INTEGER) as net_pence
+FROM transactions
+WHERE user_id = sqlc.arg(user_id)
+  AND deleted_at IS NULL
+  AND date(t_date) >= CAST(sqlc.arg(from_date) AS TEXT)
+  AND date(t_date) <= CAST(sqlc.arg(to_date) AS TEXT)
+GROUP BY day
+ORDER BY day;
+
+-- name: GetBalanceBefore :one
+SELECT SUM(amount_pence) AS balance_pence FROM transactions
+WHERE user_id = ? AND deleted_at IS NULL
+  AND t_date < ?;
+
 -- name: UpdateTransaction :one
 UPDATE transactions
-SET amount_pence = ?, t_date = ?, note = ?
-WHERE id = ? AND deleted_at IS NULL
+SET amount_pence = sqlc.arg(amount_pence), t_date = sqlc.arg(t_date), note = sqlc.arg(note), cleared = COALESCE(sqlc.narg(cleared), cleared), version = version + 1
+WHERE id = sqlc.arg(id) AND deleted_at IS NULL
+  AND version = COALESCE(CAST(sqlc.narg(expected_version) AS INTEGER), version)
 RETURNING *;
 
+-- name: ToggleTransactionCleared :exec
+UPDATE transactions
+SET cleared = CASE WHEN cleared = 1 THEN 0 ELSE 1 END, version = version + 1
+WHERE id = ? AND deleted_at IS NULL;
+
+-- name: SetTransactionCleared :exec
+UPDATE transactions
+SET cleared = ?, version = version + 1
+WHERE id = ? AND deleted_at IS NULL;
+
 -- name: SoftDeleteTransaction :exec
 UPDATE transactions
 SET deleted_at = CURRENT_TIMESTAMP
@@ -134,6 +230,19 @@ WHERE id = ? AND deleted_at IS NULL;
 DELETE FROM transactions
 WHERE id = ?;
 
+-- name: DeleteTransactionsByUser :execrows
+DELETE FROM transactions
+WHERE user_id = ?;
+
+-- name: DeleteTransactionTagsByUser :exec
+DELETE FROM transaction_tags
+WHERE transaction_id IN (SELECT id FROM transactions WHERE user_id = ?);
+
+-- name: SoftDeleteAllTransactionsByUser :execrows
+UPDATE transactions
+SET deleted_at = CURRENT_TIMESTAMP
+WHERE user_id = ? AND deleted_at IS NULL;
+
 -- name: GetTransactionsByRecurringID :many
 SELECT * FROM transactions
 WHERE source_recurring = ? AND deleted_at IS NULL
@@ -158,9 +267,40 @@ WHERE transaction_id = ? AND tag_id = ?;
 DELETE FROM transaction_tags
 WHERE transaction_id = ?;
 
+-- name: ReassignTransactionTags :execrows
+UPDATE OR IGNORE transaction_tags
+SET tag_id = sqlc.arg(to_tag_id)
+WHERE tag_id = sqlc.arg(from_tag_id);
+
+-- name: DeleteTransactionTagsByTag :execrows
+DELETE FROM transaction_tags
+WHERE tag_id = ?;
+
+-- name: DeleteRecurringTagsByTag :exec
+DELETE FROM recurring_tags
+WHERE tag_id = ?;
+
+-- name: CreateReceipt :one
+INSERT INTO receipts (transaction_id, url, content_type)
+VALUES (?, ?, ?)
+RETURNING *;
+
+-- name: GetReceiptByID :one
+SELECT * FROM receipts
+WHERE id = ?;
+
+-- name: ListReceipts :many
+SELECT * FROM receipts
+WHERE transaction_id = ?
+ORDER BY uploaded_at ASC, id ASC;
+
+-- name: DeleteReceipt :exec
+DELETE FROM receipts
+WHERE id = ?;
+
 -- name: CreateRecurring :one
-INSERT INTO recurring (user_id, amount_pence, description, frequency, interval_n, first_due_date, next_due_date, end_date, active)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+INSERT INTO recurring (user_id, amount_pence, description, frequency, interval_n, first_due_date, next_due_date, end_date, active, group_name)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: GetRecurringByID :one
@@ -169,23 +309,47 @@ WHERE id = ?;
 
 -- name: ListRecurring :many
 SELECT * FROM recurring
-WHERE user_id = ?
-ORDER BY next_due_date ASC;
+WHERE user_id = sqlc.arg(user_id) AND deleted_at IS NULL
+  AND frequency = COALESCE(CAST(sqlc.narg(frequency) AS TEXT), frequency)
+ORDER BY next_due_date ASC, id ASC
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
+
+-- name: CountRecurring :one
+SELECT COUNT(*) FROM recurring
+WHERE user_id = sqlc.arg(user_id) AND deleted_at IS NULL
+  AND frequency = COALESCE(CAST(sqlc.narg(frequency) AS TEXT), frequency);
 
 -- name: ListActiveRecurring :many
 SELECT * FROM recurring
-WHERE user_id = ? AND active = 1
-ORDER BY next_due_date ASC;
+WHERE user_id = sqlc.arg(user_id) AND active = 1 AND deleted_at IS NULL
+  AND frequency = COALESCE(CAST(sqlc.narg(frequency) AS TEXT), frequency)
+ORDER BY next_due_date ASC, id ASC
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
+
+-- name: ListStaleRecurring :many
+SELECT r.*, CAST(MAX(date(t.t_date)) AS TEXT) as last_generated_date
+FROM recurring r
+LEFT JOIN transactions t ON t.source_recurring = r.id AND t.deleted_at IS NULL
+WHERE r.user_id = sqlc.arg(user_id) AND r.active = 1 AND r.deleted_at IS NULL
+GROUP BY r.id
+HAVING MAX(date(t.t_date)) < CAST(sqlc.arg(cutoff) AS TEXT)
+    OR (MAX(date(t.t_date)) IS NULL AND date(r.first_due_date) < CAST(sqlc.arg(cutoff) AS TEXT))
+ORDER BY last_generated_date ASC, r.id ASC;
+
+-- name: CountActiveRecurring :one
+SELECT COUNT(*) FROM recurring
+WHERE user_id = sqlc.arg(user_id) AND active = 1 AND deleted_at IS NULL
+  AND frequency = COALESCE(CAST(sqlc.narg(frequency) AS TEXT), frequency);
 
 -- name: GetRecurringDueOnDate :many
 SELECT * FROM recurring
-WHERE active = 1 AND next_due_date <= ?
+WHERE active = 1 AND deleted_at IS NULL AND next_due_date <= ?
 ORDER BY next_due_date ASC;
 
 -- name: UpdateRecurring :one
 UPDATE recurring
 SET amount_pence = ?, description = ?, frequency = ?, interval_n = ?, 
-    first_due_date = ?, next_due_date = ?, end_date = ?, active = ?
+    first_due_date = ?, next_due_date = ?, end_date = ?, active = ?, group_name = ?
 WHERE id = ?
 RETURNING *;
 
@@ -203,6 +367,24 @@ WHERE id = ?;
 DELETE FROM recurring
 WHERE id = ?;
 
+-- name: DeleteRecurringByUser :execrows
+DELETE FROM recurring
+WHERE user_id = ?;
+
+-- name: DeleteRecurringTagsByUser :exec
+DELETE FROM recurring_tags
+WHERE recurring_id IN (SELECT id FROM recurring WHERE user_id = ?);
+
+-- name: SoftDeleteRecurring :exec
+UPDATE recurring
+SET deleted_at = CURRENT_TIMESTAMP, active = 0
+WHERE id = ? AND deleted_at IS NULL;
+
+-- name: DetachRecurringTransactions :exec
+UPDATE transactions
+SET source_recurring = NULL
+WHERE source_recurring = ?;
+
 -- name: CreateRecurringTag :exec
 INSERT INTO recurring_tags (recurring_id, tag_id)
 VALUES (?, ?)
@@ -222,9 +404,94 @@ WHERE recurring_id = ? AND tag_id = ?;
 DELETE FROM recurring_tags
 WHERE recurring_id = ?;
 
--- name: PurgeSoftDeletedTransactions :exec
+-- name: CreateRecurringHistory :one
+INSERT INTO recurring_history (recurring_id, action)
+VALUES (?, ?)
+RETURNING *;
+
+-- name: ListRecurringHistory :many
+SELECT * FROM recurring_history
+WHERE recurring_id = ?
+ORDER BY created_at ASC, id ASC
+LIMIT ? OFFSET ?;
+
+-- name: CountRecurringHistory :one
+SELECT COUNT(*) FROM recurring_history
+WHERE recurring_id = ?;
+
+-- name: DeleteRecurringHistory :exec
+DELETE FROM recurring_history
+WHERE recurring_id = ?;
+
+-- name: PurgeSoftDeletedTransactions :execrows
+DELETE FROM transactions
+WHERE user_id = ? AND deleted_at IS NOT NULL AND deleted_at < ?;
+
+-- name: PurgeAllSoftDeleted :execrows
 DELETE FROM transactions
-WHERE deleted_at IS NOT NULL AND deleted_at < ?;
+WHERE user_id = ? AND deleted_at IS NOT NULL;
+
+-- name: PurgeSoftDeletedTransaction :execrows
+DELETE FROM transactions
+WHERE id = ? AND user_id = ? AND deleted_at IS NOT NULL;
+
+-- name: PurgeSoftDeletedTransactionTags :exec
+DELETE FROM transaction_tags
+WHERE transaction_id IN (
+    SELECT id FROM transactions
+    WHERE user_id = ? AND deleted_at IS NOT NULL AND deleted_at < ?
+);
+
+-- name: PurgeAllSoftDeletedTags :exec
+DELETE FROM transaction_tags
+WHERE transaction_id IN (
+    SELECT id FROM transactions
+    WHERE user_id = ? AND deleted_at IS NOT NULL
+);
+
+-- name: PurgeSoftDeletedTransactionTagsByID :exec
+DELETE FROM transaction_tags
+WHERE transaction_id IN (
+    SELECT id FROM transactions
+    WHERE id = ? AND user_id = ? AND deleted_at IS NOT NULL
+);
+
+-- name: CreateTagAlert :one
+INSERT INTO tag_alerts (user_id, tag_id, threshold_pence)
+VALUES (?, ?, ?)
+RETURNING *;
+
+-- name: GetTagAlertByID :one
+SELECT * FROM tag_alerts
+WHERE id = ?;
+
+-- name: ListTagAlerts :many
+SELECT * FROM tag_alerts
+WHERE user_id = ?
+ORDER BY id ASC;
+
+-- name: UpdateTagAlertThreshold :one
+UPDATE tag_alerts
+SET threshold_pence = ?
+WHERE id = ?
+RETURNING *;
+
+-- name: MarkTagAlertNotified :exec
+UPDATE tag_alerts
+SET last_notified_ym = ?
+WHERE id = ?;
+
+-- name: DeleteTagAlert :exec
+DELETE FROM tag_alerts
+WHERE id = ?;
+
+-- name: GetTagMonthlySpend :one
+SELECT SUM(CASE WHEN tx.amount_pence < 0 THEN ABS(tx.amount_pence) ELSE 0 END) AS spend_pence
+FROM transactions tx
+JOIN transaction_tags tt ON tx.id = tt.transaction_id
+WHERE tx.user_id = ? AND tt.tag_id = ?
+  AND tx.deleted_at IS NULL
+  AND strftime('%Y-%m', tx.t_date) = CAST(sqlc.arg(ym) AS TEXT);
 
 -- name: GetMonthlyReport :many
 SELECT 
@@ -237,19 +504,67 @@ LEFT JOIN transaction_tags tt ON tx.id = tt.transaction_id
 LEFT JOIN tags t ON tt.tag_id = t.id
 WHERE tx.user_id = ? 
   AND tx.deleted_at IS NULL
-  AND strftime('%Y-%m', tx.t_date) = ?
+  AND strftime('%Y-%m', tx.t_date) = CAST(sqlc.arg(ym) AS TEXT)
+  AND CASE CAST(sqlc.arg(direction) AS TEXT) WHEN 'in' THEN tx.amount_pence > 0 WHEN 'out' THEN tx.amount_pence < 0 ELSE 1 END
+  AND (sqlc.narg(tag_id) IS NULL OR EXISTS (
+    SELECT 1 FROM transaction_tags ft
+    WHERE ft.transaction_id = tx.id AND ft.tag_id = sqlc.narg(tag_id)
+  ))
 GROUP BY t.id, t.name
 ORDER BY total_out_pence DESC;
 
+-- name: GetTopSpendingTags :many
+SELECT 
+    t.id as tag_id,
+    t.name as tag_name,
+    SUM(ABS(tx.amount_pence)) as spend_pence,
+    COUNT(*) as transaction_count
+FROM transactions tx
+JOIN transaction_tags tt ON tx.id = tt.transaction_id
+JOIN tags t ON tt.tag_id = t.id
+WHERE tx.user_id = ? 
+  AND tx.deleted_at IS NULL
+  AND tx.amount_pence < 0
+  AND strftime('%Y-%m', tx.t_date) = CAST(sqlc.arg(ym) AS TEXT)
+GROUP BY t.id, t.name
+ORDER BY spend_pence DESC, t.name ASC
+LIMIT sqlc.arg(limit);
+
 -- name: GetMonthlyTotals :one
 SELECT 
     SUM(CASE WHEN amount_pence > 0 THEN amount_pence ELSE 0 END) as total_in_pence,
     SUM(CASE WHEN amount_pence < 0 THEN ABS(amount_pence) ELSE 0 END) as total_out_pence,
-    COUNT(*) as transaction_count
+    COUNT(*) as transaction_count,
+    MIN(CASE WHEN amount_pence < 0 THEN ABS(amount_pence) END) as min_expense_pence,
+    MAX(CASE WHEN amount_pence < 0 THEN ABS(amount_pence) END) as max_expense_pence,
+    ROUND(AVG(CASE WHEN amount_pence < 0 THEN ABS(amount_pence) END)) as avg_expense_pence
 FROM transactions
 WHERE user_id = ? 
   AND deleted_at IS NULL
-  AND strftime('%Y-%m', t_date) = ?;
+  AND strftime('%Y-%m', t_date) = CAST(sqlc.arg(ym) AS TEXT)
+  AND CASE CAST(sqlc.arg(direction) AS TEXT) WHEN 'in' THEN amount_pence > 0 WHEN 'out' THEN amount_pence < 0 ELSE 1 END
+  AND (sqlc.narg(tag_id) IS NULL OR EXISTS (
+    SELECT 1 FROM transaction_tags ft
+    WHERE ft.transaction_id = transactions.id AND ft.tag_id = sqlc.narg(tag_id)
+  ));
+
+-- name: GetNetByPeriod :many
+SELECT
+    CAST(CASE CAST(sqlc.arg(group_by) AS TEXT)
+        WHEN 'day' THEN date(t_date)
+        WHEN 'week' THEN date(t_date, '-6 days', 'weekday 1')
+        ELSE strftime('%Y-%m', t_date)
+    END AS TEXT) as period,
+    SUM(CASE WHEN amount_pence > 0 THEN amount_pence ELSE 0 END) as total_in_pence,
+    SUM(CASE WHEN amount_pence < 0 THEN ABS(amount_pence) ELSE 0 END) as total_out_pence
+FROM transactions
+WHERE user_id = sqlc.arg(user_id)
+  AND deleted_at IS NULL
+  AND date(t_date) >= CAST(sqlc.arg(from_date) AS TEXT)
+  AND date(t_date) <= CAST(sqlc.arg(to_date) AS TEXT)
+  AND CASE CAST(sqlc.arg(direction) AS TEXT) WHEN 'in' THEN amount_pence > 0 WHEN 'out' THEN amount_pence < 0 ELSE 1 END
+GROUP BY period
+ORDER BY period;
 
 -- name: GetTransactionsByTag :many
 SELECT tx.* FROM transactions tx
@@ -260,5 +575,5 @@ ORDER BY tx.t_date DESC;
 -- name: GetRecurringByTag :many
 SELECT r.* FROM recurring r
 JOIN recurring_tags rt ON r.id = rt.recurring_id
-WHERE rt.tag_id = ?
+WHERE rt.tag_id = ? AND r.deleted_at IS NULL
 ORDER BY r.next_due_date ASC;
@@ -11,10 +11,152 @@ import (
 	"time"
 )
 
+const acquireSettingLock = `-- name: AcquireSettingLock :execrows
+INSERT INTO settings (key, value)
+VALUES (?, ?)
+ON CONFLICT(key) DO UPDATE SET value = excluded.value
+WHERE settings.value < ?
+`
+
+type AcquireSettingLockParams struct {
+	Key         string
+	Value       string
+	StaleBefore string
+}
+
+func (q *Queries) AcquireSettingLock(ctx context.Context, arg AcquireSettingLockParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, acquireSettingLock, arg.Key, arg.Value, arg.StaleBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const countActiveRecurring = `-- name: CountActiveRecurring :one
+SELECT COUNT(*) FROM recurring
+WHERE user_id = ? AND active = 1 AND deleted_at IS NULL
+  AND frequency = COALESCE(CAST(? AS TEXT), frequency)
+`
+
+type CountActiveRecurringParams struct {
+	UserID    int64
+	Frequency sql.NullString
+}
+
+func (q *Queries) CountActiveRecurring(ctx context.Context, arg CountActiveRecurringParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countActiveRecurring, arg.UserID, arg.Frequency)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countRecurring = `-- name: CountRecurring :one
+SELECT COUNT(*) FROM recurring
+WHERE user_id = ? AND deleted_at IS NULL
+  AND frequency = COALESCE(CAST(? AS TEXT), frequency)
+`
+
+type CountRecurringParams struct {
+	UserID    int64
+	Frequency sql.NullString
+}
+
+func (q *Queries) CountRecurring(ctx context.Context, arg CountRecurringParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countRecurring, arg.UserID, arg.Frequency)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countRecurringHistory = `-- name: CountRecurringHistory :one
+SELECT COUNT(*) FROM recurring_history
+WHERE recurring_id = ?
+`
+
+func (q *Queries) CountRecurringHistory(ctx context.Context, recurringID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countRecurringHistory, recurringID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countTransactions = `-- name: CountTransactions :one
+SELECT COUNT(*) FROM transactions
+WHERE user_id = ? AND deleted_at IS NULL
+  AND (t_date >= ? OR ? IS NULL)
+  AND (t_date <= ? OR ? IS NULL)
+  AND created_at >= COALESCE(?, created_at)
+  AND created_at <= COALESCE(?, created_at)
+  AND cleared = COALESCE(?, cleared)
+  AND (? = 0 OR id IN (
+    SELECT transaction_id FROM transaction_tags
+    WHERE tag_id IN (SELECT value FROM json_each(COALESCE(?, '[]')))
+    GROUP BY transaction_id
+    HAVING COUNT(DISTINCT tag_id) >= ?
+  ))
+`
+
+type CountTransactionsParams struct {
+	UserID        int64
+	TDate         time.Time
+	Column3       interface{}
+	TDate_2       time.Time
+	Column5       interface{}
+	CreatedFrom   sql.NullTime
+	CreatedTo     sql.NullTime
+	Cleared       sql.NullBool
+	TagIds        sql.NullString
+	MinTagMatches int64
+}
+
+func (q *Queries) CountTransactions(ctx context.Context, arg CountTransactionsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countTransactions,
+		arg.UserID,
+		arg.TDate,
+		arg.Column3,
+		arg.TDate_2,
+		arg.Column5,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.Cleared,
+		arg.MinTagMatches,
+		arg.TagIds,
+		arg.MinTagMatches,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createReceipt = `-- name: CreateReceipt :one
+INSERT INTO receipts (transaction_id, url, content_type)
+VALUES (?, ?, ?)
+RETURNING id, transaction_id, url, content_type, uploaded_at
+`
+
+type CreateReceiptParams struct {
+	TransactionID int64
+	Url           string
+	ContentType   sql.NullString
+}
+
+func (q *Queries) CreateReceipt(ctx context.Context, arg CreateReceiptParams) (Receipt, error) {
+	row := q.db.QueryRowContext(ctx, createReceipt, arg.TransactionID, arg.Url, arg.ContentType)
+	var i Receipt
+	err := row.Scan(
+		&i.ID,
+		&i.TransactionID,
+		&i.Url,
+		&i.ContentType,
+		&i.UploadedAt,
+	)
+	return i, err
+}
+
 const createRecurring = `-- name: CreateRecurring :one
-INSERT INTO recurring (user_id, amount_pence, description, frequency, interval_n, first_due_date, next_due_date, end_date, active)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
-RETURNING id, user_id, amount_pence, description, frequency, interval_n, first_due_date, next_due_date, end_date, active, created_at
+INSERT INTO recurring (user_id, amount_pence, description, frequency, interval_n, first_due_date, next_due_date, end_date, active, group_name)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, user_id, amount_pence, description, frequency, interval_n, first_due_date, next_due_date, end_date, active, created_at, group_name, deleted_at
 `
 
 type CreateRecurringParams struct {
@@ -27,6 +169,7 @@ type CreateRecurringParams struct {
 	NextDueDate  time.Time
 	EndDate      sql.NullTime
 	Active       bool
+	GroupName    sql.NullString
 }
 
 func (q *Queries) CreateRecurring(ctx context.Context, arg CreateRecurringParams) (Recurring, error) {
@@ -40,6 +183,7 @@ func (q *Queries) CreateRecurring(ctx context.Context, arg CreateRecurringParams
 		arg.NextDueDate,
 		arg.EndDate,
 		arg.Active,
+		arg.GroupName,
 	)
 	var i Recurring
 	err := row.Scan(
@@ -54,6 +198,31 @@ func (q *Queries) CreateRecurring(ctx context.Context, arg CreateRecurringParams
 		&i.EndDate,
 		&i.Active,
 		&i.CreatedAt,
+		&i.GroupName,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const createRecurringHistory = `-- name: CreateRecurringHistory :one
+INSERT INTO recurring_history (recurring_id, action)
+VALUES (?, ?)
+RETURNING id, recurring_id, action, created_at
+`
+
+type CreateRecurringHistoryParams struct {
+	RecurringID int64
+	Action      string
+}
+
+func (q *Queries) CreateRecurringHistory(ctx context.Context, arg CreateRecurringHistoryParams) (RecurringHistory, error) {
+	row := q.db.QueryRowContext(ctx, createRecurringHistory, arg.RecurringID, arg.Action)
+	var i RecurringHistory
+	err := row.Scan(
+		&i.ID,
+		&i.RecurringID,
+		&i.Action,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -119,22 +288,62 @@ func (q *Queries) CreateSetting(ctx context.Context, arg CreateSettingParams) (S
 }
 
 const createTag = `-- name: CreateTag :one
-INSERT INTO tags (name)
-VALUES (?)
-RETURNING id, name
+INSERT INTO tags (name, created_at)
+VALUES (?, CURRENT_TIMESTAMP)
+RETURNING id, name, created_at
 `
 
 func (q *Queries) CreateTag(ctx context.Context, name string) (Tag, error) {
 	row := q.db.QueryRowContext(ctx, createTag, name)
 	var i Tag
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
+	return i, err
+}
+
+const createTagAlert = `-- name: CreateTagAlert :one
+INSERT INTO tag_alerts (user_id, tag_id, threshold_pence)
+VALUES (?, ?, ?)
+RETURNING id, user_id, tag_id, threshold_pence, last_notified_ym, created_at
+`
+
+type CreateTagAlertParams struct {
+	UserID         int64
+	TagID          int64
+	ThresholdPence int64
+}
+
+func (q *Queries) CreateTagAlert(ctx context.Context, arg CreateTagAlertParams) (TagAlert, error) {
+	row := q.db.QueryRowContext(ctx, createTagAlert, arg.UserID, arg.TagID, arg.ThresholdPence)
+	var i TagAlert
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TagID,
+		&i.ThresholdPence,
+		&i.LastNotifiedYm,
+		&i.CreatedAt,
+	)
 	return i, err
 }
 
+const createTagIfNotExists = `-- name: CreateTagIfNotExists :execrows
+INSERT INTO tags (name, created_at)
+VALUES (?, CURRENT_TIMESTAMP)
+ON CONFLICT(name) DO NOTHING
+`
+
+func (q *Queries) CreateTagIfNotExists(ctx context.Context, name string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createTagIfNotExists, name)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const createTransaction = `-- name: CreateTransaction :one
-INSERT INTO transactions (user_id, amount_pence, t_date, note, source_recurring)
-VALUES (?, ?, ?, ?, ?)
-RETURNING id, user_id, amount_pence, t_date, note, created_at, source_recurring, deleted_at
+INSERT INTO transactions (user_id, amount_pence, t_date, note, source_recurring, external_id)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING id, user_id, amount_pence, t_date, note, created_at, source_recurring, deleted_at, version, external_id, cleared
 `
 
 type CreateTransactionParams struct {
@@ -143,6 +352,7 @@ type CreateTransactionParams struct {
 	TDate           time.Time
 	Note            sql.NullString
 	SourceRecurring sql.NullInt64
+	ExternalID      sql.NullString
 }
 
 func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionParams) (Transaction, error) {
@@ -152,6 +362,7 @@ func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionPa
 		arg.TDate,
 		arg.Note,
 		arg.SourceRecurring,
+		arg.ExternalID,
 	)
 	var i Transaction
 	err := row.Scan(
@@ -163,6 +374,9 @@ func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionPa
 		&i.CreatedAt,
 		&i.SourceRecurring,
 		&i.DeletedAt,
+		&i.Version,
+		&i.ExternalID,
+		&i.Cleared,
 	)
 	return i, err
 }
@@ -186,7 +400,7 @@ func (q *Queries) CreateTransactionTag(ctx context.Context, arg CreateTransactio
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (email, pw_hash, is_service)
 VALUES (?, ?, ?)
-RETURNING id, email, pw_hash, created_at, is_service
+RETURNING id, email, pw_hash, created_at, is_service, timezone
 `
 
 type CreateUserParams struct {
@@ -204,6 +418,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.PwHash,
 		&i.CreatedAt,
 		&i.IsService,
+		&i.Timezone,
 	)
 	return i, err
 }
@@ -238,6 +453,31 @@ func (q *Queries) DeleteAllTransactionTags(ctx context.Context, transactionID in
 	return err
 }
 
+const deleteOtherSessionsByUserID = `-- name: DeleteOtherSessionsByUserID :exec
+DELETE FROM sessions
+WHERE user_id = ? AND token != ?
+`
+
+type DeleteOtherSessionsByUserIDParams struct {
+	UserID int64
+	Token  string
+}
+
+func (q *Queries) DeleteOtherSessionsByUserID(ctx context.Context, arg DeleteOtherSessionsByUserIDParams) error {
+	_, err := q.db.ExecContext(ctx, deleteOtherSessionsByUserID, arg.UserID, arg.Token)
+	return err
+}
+
+const deleteReceipt = `-- name: DeleteReceipt :exec
+DELETE FROM receipts
+WHERE id = ?
+`
+
+func (q *Queries) DeleteReceipt(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, deleteReceipt, id)
+	return err
+}
+
 const deleteRecurring = `-- name: DeleteRecurring :exec
 DELETE FROM recurring
 WHERE id = ?
@@ -248,6 +488,29 @@ func (q *Queries) DeleteRecurring(ctx context.Context, id int64) error {
 	return err
 }
 
+const deleteRecurringByUser = `-- name: DeleteRecurringByUser :execrows
+DELETE FROM recurring
+WHERE user_id = ?
+`
+
+func (q *Queries) DeleteRecurringByUser(ctx context.Context, userID int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteRecurringByUser, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteRecurringHistory = `-- name: DeleteRecurringHistory :exec
+DELETE FROM recurring_history
+WHERE recurring_id = ?
+`
+
+func (q *Queries) DeleteRecurringHistory(ctx context.Context, recurringID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteRecurringHistory, recurringID)
+	return err
+}
+
 const deleteRecurringTag = `-- name: DeleteRecurringTag :exec
 DELETE FROM recurring_tags
 WHERE recurring_id = ? AND tag_id = ?
@@ -263,6 +526,26 @@ func (q *Queries) DeleteRecurringTag(ctx context.Context, arg DeleteRecurringTag
 	return err
 }
 
+const deleteRecurringTagsByTag = `-- name: DeleteRecurringTagsByTag :exec
+DELETE FROM recurring_tags
+WHERE tag_id = ?
+`
+
+func (q *Queries) DeleteRecurringTagsByTag(ctx context.Context, tagID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteRecurringTagsByTag, tagID)
+	return err
+}
+
+const deleteRecurringTagsByUser = `-- name: DeleteRecurringTagsByUser :exec
+DELETE FROM recurring_tags
+WHERE recurring_id IN (SELECT id FROM recurring WHERE user_id = ?)
+`
+
+func (q *Queries) DeleteRecurringTagsByUser(ctx context.Context, userID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteRecurringTagsByUser, userID)
+	return err
+}
+
 const deleteSession = `-- name: DeleteSession :exec
 DELETE FROM sessions
 WHERE token = ?
@@ -293,6 +576,16 @@ func (q *Queries) DeleteTag(ctx context.Context, id int64) error {
 	return err
 }
 
+const deleteTagAlert = `-- name: DeleteTagAlert :exec
+DELETE FROM tag_alerts
+WHERE id = ?
+`
+
+func (q *Queries) DeleteTagAlert(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, deleteTagAlert, id)
+	return err
+}
+
 const deleteTransactionTag = `-- name: DeleteTransactionTag :exec
 DELETE FROM transaction_tags
 WHERE transaction_id = ? AND tag_id = ?
@@ -308,6 +601,42 @@ func (q *Queries) DeleteTransactionTag(ctx context.Context, arg DeleteTransactio
 	return err
 }
 
+const deleteTransactionTagsByTag = `-- name: DeleteTransactionTagsByTag :execrows
+DELETE FROM transaction_tags
+WHERE tag_id = ?
+`
+
+func (q *Queries) DeleteTransactionTagsByTag(ctx context.Context, tagID int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteTransactionTagsByTag, tagID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteTransactionTagsByUser = `-- name: DeleteTransactionTagsByUser :exec
+DELETE FROM transaction_tags
+WHERE transaction_id IN (SELECT id FROM transactions WHERE user_id = ?)
+`
+
+func (q *Queries) DeleteTransactionTagsByUser(ctx context.Context, userID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteTransactionTagsByUser, userID)
+	return err
+}
+
+const deleteTransactionsByUser = `-- name: DeleteTransactionsByUser :execrows
+DELETE FROM transactions
+WHERE user_id = ?
+`
+
+func (q *Queries) DeleteTransactionsByUser(ctx context.Context, userID int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteTransactionsByUser, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const deleteUser = `-- name: DeleteUser :exec
 DELETE FROM users
 WHERE id = ?
@@ -318,6 +647,35 @@ func (q *Queries) DeleteUser(ctx context.Context, id int64) error {
 	return err
 }
 
+const detachRecurringTransactions = `-- name: DetachRecurringTransactions :exec
+UPDATE transactions
+SET source_recurring = NULL
+WHERE source_recurring = ?
+`
+
+func (q *Queries) DetachRecurringTransactions(ctx context.Context, sourceRecurring sql.NullInt64) error {
+	_, err := q.db.ExecContext(ctx, detachRecurringTransactions, sourceRecurring)
+	return err
+}
+
+const getBalanceBefore = `-- name: GetBalanceBefore :one
+SELECT SUM(amount_pence) AS balance_pence FROM transactions
+WHERE user_id = ? AND deleted_at IS NULL
+  AND t_date < ?
+`
+
+type GetBalanceBeforeParams struct {
+	UserID int64
+	TDate  time.Time
+}
+
+func (q *Queries) GetBalanceBefore(ctx context.Context, arg GetBalanceBeforeParams) (sql.NullFloat64, error) {
+	row := q.db.QueryRowContext(ctx, getBalanceBefore, arg.UserID, arg.TDate)
+	var balance_pence sql.NullFloat64
+	err := row.Scan(&balance_pence)
+	return balance_pence, err
+}
+
 const getMonthlyReport = `-- name: GetMonthlyReport :many
 SELECT 
     t.name as tag_name,
@@ -329,14 +687,21 @@ LEFT JOIN transaction_tags tt ON tx.id = tt.transaction_id
 LEFT JOIN tags t ON tt.tag_id = t.id
 WHERE tx.user_id = ? 
   AND tx.deleted_at IS NULL
-  AND strftime('%Y-%m', tx.t_date) = ?
+  AND strftime('%Y-%m', tx.t_date) = CAST(? AS TEXT)
+  AND CASE CAST(? AS TEXT) WHEN 'in' THEN tx.amount_pence > 0 WHEN 'out' THEN tx.amount_pence < 0 ELSE 1 END
+  AND (? IS NULL OR EXISTS (
+    SELECT 1 FROM transaction_tags ft
+    WHERE ft.transaction_id = tx.id AND ft.tag_id = ?
+  ))
 GROUP BY t.id, t.name
 ORDER BY total_out_pence DESC
 `
 
 type GetMonthlyReportParams struct {
-	UserID int64
-	TDate  time.Time
+	UserID    int64
+	Ym        string
+	Direction string
+	TagID     sql.NullInt64
 }
 
 type GetMonthlyReportRow struct {
@@ -347,7 +712,13 @@ type GetMonthlyReportRow struct {
 }
 
 func (q *Queries) GetMonthlyReport(ctx context.Context, arg GetMonthlyReportParams) ([]GetMonthlyReportRow, error) {
-	rows, err := q.db.QueryContext(ctx, getMonthlyReport, arg.UserID, arg.TDate)
+	rows, err := q.db.QueryContext(ctx, getMonthlyReport,
+		arg.UserID,
+		arg.Ym,
+		arg.Direction,
+		arg.TagID,
+		arg.TagID,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -378,33 +749,139 @@ const getMonthlyTotals = `-- name: GetMonthlyTotals :one
 SELECT 
     SUM(CASE WHEN amount_pence > 0 THEN amount_pence ELSE 0 END) as total_in_pence,
     SUM(CASE WHEN amount_pence < 0 THEN ABS(amount_pence) ELSE 0 END) as total_out_pence,
-    COUNT(*) as transaction_count
+    COUNT(*) as transaction_count,
+    MIN(CASE WHEN amount_pence < 0 THEN ABS(amount_pence) END) as min_expense_pence,
+    MAX(CASE WHEN amount_pence < 0 THEN ABS(amount_pence) END) as max_expense_pence,
+    ROUND(AVG(CASE WHEN amount_pence < 0 THEN ABS(amount_pence) END)) as avg_expense_pence
 FROM transactions
 WHERE user_id = ? 
   AND deleted_at IS NULL
-  AND strftime('%Y-%m', t_date) = ?
+  AND strftime('%Y-%m', t_date) = CAST(? AS TEXT)
+  AND CASE CAST(? AS TEXT) WHEN 'in' THEN amount_pence > 0 WHEN 'out' THEN amount_pence < 0 ELSE 1 END
+  AND (? IS NULL OR EXISTS (
+    SELECT 1 FROM transaction_tags ft
+    WHERE ft.transaction_id = transactions.id AND ft.tag_id = ?
+  ))
 `
 
 type GetMonthlyTotalsParams struct {
-	UserID int64
-	TDate  time.Time
+	UserID    int64
+	Ym        string
+	Direction string
+	TagID     sql.NullInt64
 }
 
 type GetMonthlyTotalsRow struct {
 	TotalInPence     sql.NullFloat64
 	TotalOutPence    sql.NullFloat64
 	TransactionCount int64
+	MinExpensePence  sql.NullFloat64
+	MaxExpensePence  sql.NullFloat64
+	AvgExpensePence  sql.NullFloat64
 }
 
 func (q *Queries) GetMonthlyTotals(ctx context.Context, arg GetMonthlyTotalsParams) (GetMonthlyTotalsRow, error) {
-	row := q.db.QueryRowContext(ctx, getMonthlyTotals, arg.UserID, arg.TDate)
+	row := q.db.QueryRowContext(ctx, getMonthlyTotals,
+		arg.UserID,
+		arg.Ym,
+		arg.Direction,
+		arg.TagID,
+		arg.TagID,
+	)
 	var i GetMonthlyTotalsRow
-	err := row.Scan(&i.TotalInPence, &i.TotalOutPence, &i.TransactionCount)
+	err := row.Scan(
+		&i.TotalInPence,
+		&i.TotalOutPence,
+		&i.TransactionCount,
+		&i.MinExpensePence,
+		&i.MaxExpensePence,
+		&i.AvgExpensePence,
+	)
+	return i, err
+}
+
+const getNetByPeriod = `-- name: GetNetByPeriod :many
+SELECT
+    CAST(CASE CAST(? AS TEXT)
+        WHEN 'day' THEN date(t_date)
+        WHEN 'week' THEN date(t_date, '-6 days', 'weekday 1')
+        ELSE strftime('%Y-%m', t_date)
+    END AS TEXT) as period,
+    SUM(CASE WHEN amount_pence > 0 THEN amount_pence ELSE 0 END) as total_in_pence,
+    SUM(CASE WHEN amount_pence < 0 THEN ABS(amount_pence) ELSE 0 END) as total_out_pence
+FROM transactions
+WHERE user_id = ?
+  AND deleted_at IS NULL
+  AND date(t_date) >= CAST(? AS TEXT)
+  AND date(t_date) <= CAST(? AS TEXT)
+  AND CASE CAST(? AS TEXT) WHEN 'in' THEN amount_pence > 0 WHEN 'out' THEN amount_pence < 0 ELSE 1 END
+GROUP BY period
+ORDER BY period
+`
+
+type GetNetByPeriodParams struct {
+	GroupBy   string
+	UserID    int64
+	FromDate  string
+	ToDate    string
+	Direction string
+}
+
+type GetNetByPeriodRow struct {
+	Period        string
+	TotalInPence  sql.NullFloat64
+	TotalOutPence sql.NullFloat64
+}
+
+func (q *Queries) GetNetByPeriod(ctx context.Context, arg GetNetByPeriodParams) ([]GetNetByPeriodRow, error) {
+	rows, err := q.db.QueryContext(ctx, getNetByPeriod,
+		arg.GroupBy,
+		arg.UserID,
+		arg.FromDate,
+		arg.ToDate,
+		arg.Direction,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetNetByPeriodRow
+	for rows.Next() {
+		var i GetNetByPeriodRow
+		if err := rows.Scan(&i.Period, &i.TotalInPence, &i.TotalOutPence); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReceiptByID = `-- name: GetReceiptByID :one
+SELECT id, transaction_id, url, content_type, uploaded_at FROM receipts
+WHERE id = ?
+`
+
+func (q *Queries) GetReceiptByID(ctx context.Context, id int64) (Receipt, error) {
+	row := q.db.QueryRowContext(ctx, getReceiptByID, id)
+	var i Receipt
+	err := row.Scan(
+		&i.ID,
+		&i.TransactionID,
+		&i.Url,
+		&i.ContentType,
+		&i.UploadedAt,
+	)
 	return i, err
 }
 
 const getRecurringByID = `-- name: GetRecurringByID :one
-SELECT id, user_id, amount_pence, description, frequency, interval_n, first_due_date, next_due_date, end_date, active, created_at FROM recurring
+SELECT id, user_id, amount_pence, description, frequency, interval_n, first_due_date, next_due_date, end_date, active, created_at, group_name, deleted_at FROM recurring
 WHERE id = ?
 `
 
@@ -423,14 +900,16 @@ func (q *Queries) GetRecurringByID(ctx context.Context, id int64) (Recurring, er
 		&i.EndDate,
 		&i.Active,
 		&i.CreatedAt,
+		&i.GroupName,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const getRecurringByTag = `-- name: GetRecurringByTag :many
-SELECT r.id, r.user_id, r.amount_pence, r.description, r.frequency, r.interval_n, r.first_due_date, r.next_due_date, r.end_date, r.active, r.created_at FROM recurring r
+SELECT r.id, r.user_id, r.amount_pence, r.description, r.frequency, r.interval_n, r.first_due_date, r.next_due_date, r.end_date, r.active, r.created_at, r.group_name, r.deleted_at FROM recurring r
 JOIN recurring_tags rt ON r.id = rt.recurring_id
-WHERE rt.tag_id = ?
+WHERE rt.tag_id = ? AND r.deleted_at IS NULL
 ORDER BY r.next_due_date ASC
 `
 
@@ -455,6 +934,8 @@ func (q *Queries) GetRecurringByTag(ctx context.Context, tagID int64) ([]Recurri
 			&i.EndDate,
 			&i.Active,
 			&i.CreatedAt,
+			&i.GroupName,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -470,8 +951,8 @@ func (q *Queries) GetRecurringByTag(ctx context.Context, tagID int64) ([]Recurri
 }
 
 const getRecurringDueOnDate = `-- name: GetRecurringDueOnDate :many
-SELECT id, user_id, amount_pence, description, frequency, interval_n, first_due_date, next_due_date, end_date, active, created_at FROM recurring
-WHERE active = 1 AND next_due_date <= ?
+SELECT id, user_id, amount_pence, description, frequency, interval_n, first_due_date, next_due_date, end_date, active, created_at, group_name, deleted_at FROM recurring
+WHERE active = 1 AND deleted_at IS NULL AND next_due_date <= ?
 ORDER BY next_due_date ASC
 `
 
@@ -496,6 +977,8 @@ func (q *Queries) GetRecurringDueOnDate(ctx context.Context, nextDueDate time.Ti
 			&i.EndDate,
 			&i.Active,
 			&i.CreatedAt,
+			&i.GroupName,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -511,7 +994,7 @@ func (q *Queries) GetRecurringDueOnDate(ctx context.Context, nextDueDate time.Ti
 }
 
 const getRecurringTags = `-- name: GetRecurringTags :many
-SELECT t.id, t.name FROM tags t
+SELECT t.id, t.name, t.created_at FROM tags t
 JOIN recurring_tags rt ON t.id = rt.tag_id
 WHERE rt.recurring_id = ?
 ORDER BY t.name
@@ -526,7 +1009,7 @@ func (q *Queries) GetRecurringTags(ctx context.Context, recurringID int64) ([]Ta
 	var items []Tag
 	for rows.Next() {
 		var i Tag
-		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+		if err := rows.Scan(&i.ID, &i.Name, &i.CreatedAt); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -588,53 +1071,185 @@ func (q *Queries) GetSetting(ctx context.Context, key string) (Setting, error) {
 	return i, err
 }
 
+const getTagAlertByID = `-- name: GetTagAlertByID :one
+SELECT id, user_id, tag_id, threshold_pence, last_notified_ym, created_at FROM tag_alerts
+WHERE id = ?
+`
+
+func (q *Queries) GetTagAlertByID(ctx context.Context, id int64) (TagAlert, error) {
+	row := q.db.QueryRowContext(ctx, getTagAlertByID, id)
+	var i TagAlert
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TagID,
+		&i.ThresholdPence,
+		&i.LastNotifiedYm,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getTagByID = `-- name: GetTagByID :one
-SELECT id, name FROM tags
+SELECT id, name, created_at FROM tags
 WHERE id = ?
 `
 
 func (q *Queries) GetTagByID(ctx context.Context, id int64) (Tag, error) {
 	row := q.db.QueryRowContext(ctx, getTagByID, id)
 	var i Tag
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
 	return i, err
 }
 
 const getTagByName = `-- name: GetTagByName :one
-SELECT id, name FROM tags
+SELECT id, name, created_at FROM tags
 WHERE name = ?
 `
 
 func (q *Queries) GetTagByName(ctx context.Context, name string) (Tag, error) {
 	row := q.db.QueryRowContext(ctx, getTagByName, name)
 	var i Tag
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
 	return i, err
 }
 
-const getTransactionByID = `-- name: GetTransactionByID :one
-SELECT id, user_id, amount_pence, t_date, note, created_at, source_recurring, deleted_at FROM transactions
-WHERE id = ? AND deleted_at IS NULL
+const getTagMonthlySpend = `-- name: GetTagMonthlySpend :one
+SELECT SUM(CASE WHEN tx.amount_pence < 0 THEN ABS(tx.amount_pence) ELSE 0 END) AS spend_pence
+FROM transactions tx
+JOIN transaction_tags tt ON tx.id = tt.transaction_id
+WHERE tx.user_id = ? AND tt.tag_id = ?
+  AND tx.deleted_at IS NULL
+  AND strftime('%Y-%m', tx.t_date) = CAST(? AS TEXT)
 `
 
-func (q *Queries) GetTransactionByID(ctx context.Context, id int64) (Transaction, error) {
-	row := q.db.QueryRowContext(ctx, getTransactionByID, id)
-	var i Transaction
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.AmountPence,
-		&i.TDate,
-		&i.Note,
-		&i.CreatedAt,
-		&i.SourceRecurring,
-		&i.DeletedAt,
+type GetTagMonthlySpendParams struct {
+	UserID int64
+	TagID  int64
+	Ym     string
+}
+
+func (q *Queries) GetTagMonthlySpend(ctx context.Context, arg GetTagMonthlySpendParams) (sql.NullFloat64, error) {
+	row := q.db.QueryRowContext(ctx, getTagMonthlySpend, arg.UserID, arg.TagID, arg.Ym)
+	var spend_pence sql.NullFloat64
+	err := row.Scan(&spend_pence)
+	return spend_pence, err
+}
+
+const getTopSpendingTags = `-- name: GetTopSpendingTags :many
+SELECT 
+    t.id as tag_id,
+    t.name as tag_name,
+    SUM(ABS(tx.amount_pence)) as spend_pence,
+    COUNT(*) as transaction_count
+FROM transactions tx
+JOIN transaction_tags tt ON tx.id = tt.transaction_id
+JOIN tags t ON tt.tag_id = t.id
+WHERE tx.user_id = ? 
+  AND tx.deleted_at IS NULL
+  AND tx.amount_pence < 0
+  AND strftime('%Y-%m', tx.t_date) = CAST(? AS TEXT)
+GROUP BY t.id, t.name
+ORDER BY spend_pence DESC, t.name ASC
+LIMIT ?
+`
+
+type GetTopSpendingTagsParams struct {
+	UserID int64
+	Ym     string
+	Limit  int64
+}
+
+type GetTopSpendingTagsRow struct {
+	TagID            int64
+	TagName          string
+	SpendPence       sql.NullFloat64
+	TransactionCount int64
+}
+
+func (q *Queries) GetTopSpendingTags(ctx context.Context, arg GetTopSpendingTagsParams) ([]GetTopSpendingTagsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getTopSpendingTags, arg.UserID, arg.Ym, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTopSpendingTagsRow
+	for rows.Next() {
+		var i GetTopSpendingTagsRow
+		if err := rows.Scan(
+			&i.TagID,
+			&i.TagName,
+			&i.SpendPence,
+			&i.TransactionCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTransactionByExternalID = `-- name: GetTransactionByExternalID :one
+SELECT id, user_id, amount_pence, t_date, note, created_at, source_recurring, deleted_at, version, external_id, cleared FROM transactions
+WHERE user_id = ? AND external_id = ?
+`
+
+type GetTransactionByExternalIDParams struct {
+	UserID     int64
+	ExternalID sql.NullString
+}
+
+func (q *Queries) GetTransactionByExternalID(ctx context.Context, arg GetTransactionByExternalIDParams) (Transaction, error) {
+	row := q.db.QueryRowContext(ctx, getTransactionByExternalID, arg.UserID, arg.ExternalID)
+	var i Transaction
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.AmountPence,
+		&i.TDate,
+		&i.Note,
+		&i.CreatedAt,
+		&i.SourceRecurring,
+		&i.DeletedAt,
+		&i.Version,
+		&i.ExternalID,
+		&i.Cleared,
+	)
+	return i, err
+}
+
+const getTransactionByID = `-- name: GetTransactionByID :one
+SELECT id, user_id, amount_pence, t_date, note, created_at, source_recurring, deleted_at, version, external_id, cleared FROM transactions
+WHERE id = ? AND deleted_at IS NULL
+`
+
+func (q *Queries) GetTransactionByID(ctx context.Context, id int64) (Transaction, error) {
+	row := q.db.QueryRowContext(ctx, getTransactionByID, id)
+	var i Transaction
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.AmountPence,
+		&i.TDate,
+		&i.Note,
+		&i.CreatedAt,
+		&i.SourceRecurring,
+		&i.DeletedAt,
+		&i.Version,
+		&i.ExternalID,
+		&i.Cleared,
 	)
 	return i, err
 }
 
 const getTransactionTags = `-- name: GetTransactionTags :many
-SELECT t.id, t.name FROM tags t
+SELECT t.id, t.name, t.created_at FROM tags t
 JOIN transaction_tags tt ON t.id = tt.tag_id
 WHERE tt.transaction_id = ?
 ORDER BY t.name
@@ -649,7 +1264,7 @@ func (q *Queries) GetTransactionTags(ctx context.Context, transactionID int64) (
 	var items []Tag
 	for rows.Next() {
 		var i Tag
-		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+		if err := rows.Scan(&i.ID, &i.Name, &i.CreatedAt); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -664,7 +1279,7 @@ func (q *Queries) GetTransactionTags(ctx context.Context, transactionID int64) (
 }
 
 const getTransactionsByRecurringID = `-- name: GetTransactionsByRecurringID :many
-SELECT id, user_id, amount_pence, t_date, note, created_at, source_recurring, deleted_at FROM transactions
+SELECT id, user_id, amount_pence, t_date, note, created_at, source_recurring, deleted_at, version, external_id, cleared FROM transactions
 WHERE source_recurring = ? AND deleted_at IS NULL
 ORDER BY t_date DESC
 `
@@ -687,6 +1302,9 @@ func (q *Queries) GetTransactionsByRecurringID(ctx context.Context, sourceRecurr
 			&i.CreatedAt,
 			&i.SourceRecurring,
 			&i.DeletedAt,
+			&i.Version,
+			&i.ExternalID,
+			&i.Cleared,
 		); err != nil {
 			return nil, err
 		}
@@ -702,7 +1320,7 @@ func (q *Queries) GetTransactionsByRecurringID(ctx context.Context, sourceRecurr
 }
 
 const getTransactionsByTag = `-- name: GetTransactionsByTag :many
-SELECT tx.id, tx.user_id, tx.amount_pence, tx.t_date, tx.note, tx.created_at, tx.source_recurring, tx.deleted_at FROM transactions tx
+SELECT tx.id, tx.user_id, tx.amount_pence, tx.t_date, tx.note, tx.created_at, tx.source_recurring, tx.deleted_at, tx.version, tx.external_id, tx.cleared FROM transactions tx
 JOIN transaction_tags tt ON tx.id = tt.transaction_id
 WHERE tt.tag_id = ? AND tx.deleted_at IS NULL
 ORDER BY tx.t_date DESC
@@ -726,6 +1344,9 @@ func (q *Queries) GetTransactionsByTag(ctx context.Context, tagID int64) ([]Tran
 			&i.CreatedAt,
 			&i.SourceRecurring,
 			&i.DeletedAt,
+			&i.Version,
+			&i.ExternalID,
+			&i.Cleared,
 		); err != nil {
 			return nil, err
 		}
@@ -741,7 +1362,7 @@ func (q *Queries) GetTransactionsByTag(ctx context.Context, tagID int64) ([]Tran
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, pw_hash, created_at, is_service FROM users
+SELECT id, email, pw_hash, created_at, is_service, timezone FROM users
 WHERE email = ?
 `
 
@@ -754,12 +1375,13 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.PwHash,
 		&i.CreatedAt,
 		&i.IsService,
+		&i.Timezone,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, pw_hash, created_at, is_service FROM users
+SELECT id, email, pw_hash, created_at, is_service, timezone FROM users
 WHERE id = ?
 `
 
@@ -772,6 +1394,7 @@ func (q *Queries) GetUserByID(ctx context.Context, id int64) (User, error) {
 		&i.PwHash,
 		&i.CreatedAt,
 		&i.IsService,
+		&i.Timezone,
 	)
 	return i, err
 }
@@ -787,13 +1410,27 @@ func (q *Queries) HardDeleteTransaction(ctx context.Context, id int64) error {
 }
 
 const listActiveRecurring = `-- name: ListActiveRecurring :many
-SELECT id, user_id, amount_pence, description, frequency, interval_n, first_due_date, next_due_date, end_date, active, created_at FROM recurring
-WHERE user_id = ? AND active = 1
-ORDER BY next_due_date ASC
+SELECT id, user_id, amount_pence, description, frequency, interval_n, first_due_date, next_due_date, end_date, active, created_at, group_name, deleted_at FROM recurring
+WHERE user_id = ? AND active = 1 AND deleted_at IS NULL
+  AND frequency = COALESCE(CAST(? AS TEXT), frequency)
+ORDER BY next_due_date ASC, id ASC
+LIMIT ? OFFSET ?
 `
 
-func (q *Queries) ListActiveRecurring(ctx context.Context, userID int64) ([]Recurring, error) {
-	rows, err := q.db.QueryContext(ctx, listActiveRecurring, userID)
+type ListActiveRecurringParams struct {
+	UserID    int64
+	Frequency sql.NullString
+	Limit     int64
+	Offset    int64
+}
+
+func (q *Queries) ListActiveRecurring(ctx context.Context, arg ListActiveRecurringParams) ([]Recurring, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveRecurring,
+		arg.UserID,
+		arg.Frequency,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -813,6 +1450,8 @@ func (q *Queries) ListActiveRecurring(ctx context.Context, userID int64) ([]Recu
 			&i.EndDate,
 			&i.Active,
 			&i.CreatedAt,
+			&i.GroupName,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -827,14 +1466,112 @@ func (q *Queries) ListActiveRecurring(ctx context.Context, userID int64) ([]Recu
 	return items, nil
 }
 
-const listRecurring = `-- name: ListRecurring :many
-SELECT id, user_id, amount_pence, description, frequency, interval_n, first_due_date, next_due_date, end_date, active, created_at FROM recurring
+const listDailyActivity = `-- name: ListDailyActivity :many
+SELECT
+    CAST(date(t_date) AS TEXT) as day,
+    COUNT(*) as transaction_count,
+    CAST(SUM(amount_pence) AS INTEGER) as net_pence
+FROM transactions
 WHERE user_id = ?
-ORDER BY next_due_date ASC
+  AND deleted_at IS NULL
+  AND date(t_date) >= CAST(? AS TEXT)
+  AND date(t_date) <= CAST(? AS TEXT)
+GROUP BY day
+ORDER BY day
 `
 
-func (q *Queries) ListRecurring(ctx context.Context, userID int64) ([]Recurring, error) {
-	rows, err := q.db.QueryContext(ctx, listRecurring, userID)
+type ListDailyActivityParams struct {
+	UserID   int64
+	FromDate string
+	ToDate   string
+}
+
+type ListDailyActivityRow struct {
+	Day              string
+	TransactionCount int64
+	NetPence         int64
+}
+
+func (q *Queries) ListDailyActivity(ctx context.Context, arg ListDailyActivityParams) ([]ListDailyActivityRow, error) {
+	rows, err := q.db.QueryContext(ctx, listDailyActivity, arg.UserID, arg.FromDate, arg.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDailyActivityRow
+	for rows.Next() {
+		var i ListDailyActivityRow
+		if err := rows.Scan(&i.Day, &i.TransactionCount, &i.NetPence); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReceipts = `-- name: ListReceipts :many
+SELECT id, transaction_id, url, content_type, uploaded_at FROM receipts
+WHERE transaction_id = ?
+ORDER BY uploaded_at ASC, id ASC
+`
+
+func (q *Queries) ListReceipts(ctx context.Context, transactionID int64) ([]Receipt, error) {
+	rows, err := q.db.QueryContext(ctx, listReceipts, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Receipt
+	for rows.Next() {
+		var i Receipt
+		if err := rows.Scan(
+			&i.ID,
+			&i.TransactionID,
+			&i.Url,
+			&i.ContentType,
+			&i.UploadedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecurring = `-- name: ListRecurring :many
+SELECT id, user_id, amount_pence, description, frequency, interval_n, first_due_date, next_due_date, end_date, active, created_at, group_name, deleted_at FROM recurring
+WHERE user_id = ? AND deleted_at IS NULL
+  AND frequency = COALESCE(CAST(? AS TEXT), frequency)
+ORDER BY next_due_date ASC, id ASC
+LIMIT ? OFFSET ?
+`
+
+type ListRecurringParams struct {
+	UserID    int64
+	Frequency sql.NullString
+	Limit     int64
+	Offset    int64
+}
+
+func (q *Queries) ListRecurring(ctx context.Context, arg ListRecurringParams) ([]Recurring, error) {
+	rows, err := q.db.QueryContext(ctx, listRecurring,
+		arg.UserID,
+		arg.Frequency,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -854,6 +1591,49 @@ func (q *Queries) ListRecurring(ctx context.Context, userID int64) ([]Recurring,
 			&i.EndDate,
 			&i.Active,
 			&i.CreatedAt,
+			&i.GroupName,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecurringHistory = `-- name: ListRecurringHistory :many
+SELECT id, recurring_id, action, created_at FROM recurring_history
+WHERE recurring_id = ?
+ORDER BY created_at ASC, id ASC
+LIMIT ? OFFSET ?
+`
+
+type ListRecurringHistoryParams struct {
+	RecurringID int64
+	Limit       int64
+	Offset      int64
+}
+
+func (q *Queries) ListRecurringHistory(ctx context.Context, arg ListRecurringHistoryParams) ([]RecurringHistory, error) {
+	rows, err := q.db.QueryContext(ctx, listRecurringHistory, arg.RecurringID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RecurringHistory
+	for rows.Next() {
+		var i RecurringHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.RecurringID,
+			&i.Action,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -896,8 +1676,115 @@ func (q *Queries) ListSettings(ctx context.Context) ([]Setting, error) {
 	return items, nil
 }
 
+const listStaleRecurring = `-- name: ListStaleRecurring :many
+SELECT r.id, r.user_id, r.amount_pence, r.description, r.frequency, r.interval_n, r.first_due_date, r.next_due_date, r.end_date, r.active, r.created_at, r.group_name, r.deleted_at, CAST(MAX(date(t.t_date)) AS TEXT) as last_generated_date
+FROM recurring r
+LEFT JOIN transactions t ON t.source_recurring = r.id AND t.deleted_at IS NULL
+WHERE r.user_id = ? AND r.active = 1 AND r.deleted_at IS NULL
+GROUP BY r.id
+HAVING MAX(date(t.t_date)) < CAST(? AS TEXT)
+    OR (MAX(date(t.t_date)) IS NULL AND date(r.first_due_date) < CAST(? AS TEXT))
+ORDER BY last_generated_date ASC, r.id ASC
+`
+
+type ListStaleRecurringParams struct {
+	UserID int64
+	Cutoff string
+}
+
+type ListStaleRecurringRow struct {
+	ID                int64
+	UserID            int64
+	AmountPence       int64
+	Description       sql.NullString
+	Frequency         string
+	IntervalN         int64
+	FirstDueDate      time.Time
+	NextDueDate       time.Time
+	EndDate           sql.NullTime
+	Active            bool
+	CreatedAt         sql.NullTime
+	GroupName         sql.NullString
+	DeletedAt         sql.NullTime
+	LastGeneratedDate sql.NullString
+}
+
+func (q *Queries) ListStaleRecurring(ctx context.Context, arg ListStaleRecurringParams) ([]ListStaleRecurringRow, error) {
+	rows, err := q.db.QueryContext(ctx, listStaleRecurring, arg.UserID, arg.Cutoff, arg.Cutoff)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListStaleRecurringRow
+	for rows.Next() {
+		var i ListStaleRecurringRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.AmountPence,
+			&i.Description,
+			&i.Frequency,
+			&i.IntervalN,
+			&i.FirstDueDate,
+			&i.NextDueDate,
+			&i.EndDate,
+			&i.Active,
+			&i.CreatedAt,
+			&i.GroupName,
+			&i.DeletedAt,
+			&i.LastGeneratedDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTagAlerts = `-- name: ListTagAlerts :many
+SELECT id, user_id, tag_id, threshold_pence, last_notified_ym, created_at FROM tag_alerts
+WHERE user_id = ?
+ORDER BY id ASC
+`
+
+func (q *Queries) ListTagAlerts(ctx context.Context, userID int64) ([]TagAlert, error) {
+	rows, err := q.db.QueryContext(ctx, listTagAlerts, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TagAlert
+	for rows.Next() {
+		var i TagAlert
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.TagID,
+			&i.ThresholdPence,
+			&i.LastNotifiedYm,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listTags = `-- name: ListTags :many
-SELECT id, name FROM tags
+SELECT id, name, created_at FROM tags
 ORDER BY name
 `
 
@@ -910,7 +1797,92 @@ func (q *Queries) ListTags(ctx context.Context) ([]Tag, error) {
 	var items []Tag
 	for rows.Next() {
 		var i Tag
-		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+		if err := rows.Scan(&i.ID, &i.Name, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransactionAmountsByDateRange = `-- name: ListTransactionAmountsByDateRange :many
+SELECT t_date, amount_pence FROM transactions
+WHERE user_id = ? AND deleted_at IS NULL
+  AND t_date >= ? AND t_date <= ?
+ORDER BY t_date ASC, id ASC
+`
+
+type ListTransactionAmountsByDateRangeParams struct {
+	UserID  int64
+	TDate   time.Time
+	TDate_2 time.Time
+}
+
+type ListTransactionAmountsByDateRangeRow struct {
+	TDate       time.Time
+	AmountPence int64
+}
+
+func (q *Queries) ListTransactionAmountsByDateRange(ctx context.Context, arg ListTransactionAmountsByDateRangeParams) ([]ListTransactionAmountsByDateRangeRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTransactionAmountsByDateRange, arg.UserID, arg.TDate, arg.TDate_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTransactionAmountsByDateRangeRow
+	for rows.Next() {
+		var i ListTransactionAmountsByDateRangeRow
+		if err := rows.Scan(&i.TDate, &i.AmountPence); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransactionTagsByDateRange = `-- name: ListTransactionTagsByDateRange :many
+SELECT tt.transaction_id, t.id, t.name FROM transaction_tags tt
+JOIN tags t ON tt.tag_id = t.id
+JOIN transactions tx ON tt.transaction_id = tx.id
+WHERE tx.user_id = ? AND tx.deleted_at IS NULL
+  AND tx.t_date >= ? AND tx.t_date <= ?
+ORDER BY t.name
+`
+
+type ListTransactionTagsByDateRangeParams struct {
+	UserID  int64
+	TDate   time.Time
+	TDate_2 time.Time
+}
+
+type ListTransactionTagsByDateRangeRow struct {
+	TransactionID int64
+	ID            int64
+	Name          string
+}
+
+func (q *Queries) ListTransactionTagsByDateRange(ctx context.Context, arg ListTransactionTagsByDateRangeParams) ([]ListTransactionTagsByDateRangeRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTransactionTagsByDateRange, arg.UserID, arg.TDate, arg.TDate_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTransactionTagsByDateRangeRow
+	for rows.Next() {
+		var i ListTransactionTagsByDateRangeRow
+		if err := rows.Scan(&i.TransactionID, &i.ID, &i.Name); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -925,19 +1897,33 @@ func (q *Queries) ListTags(ctx context.Context) ([]Tag, error) {
 }
 
 const listTransactions = `-- name: ListTransactions :many
-SELECT id, user_id, amount_pence, t_date, note, created_at, source_recurring, deleted_at FROM transactions
+SELECT id, user_id, amount_pence, t_date, note, created_at, source_recurring, deleted_at, version, external_id, cleared FROM transactions
 WHERE user_id = ? AND deleted_at IS NULL
   AND (t_date >= ? OR ? IS NULL)
   AND (t_date <= ? OR ? IS NULL)
+  AND created_at >= COALESCE(?, created_at)
+  AND created_at <= COALESCE(?, created_at)
+  AND cleared = COALESCE(?, cleared)
+  AND (? = 0 OR id IN (
+    SELECT transaction_id FROM transaction_tags
+    WHERE tag_id IN (SELECT value FROM json_each(COALESCE(?, '[]')))
+    GROUP BY transaction_id
+    HAVING COUNT(DISTINCT tag_id) >= ?
+  ))
 ORDER BY t_date DESC, created_at DESC
 `
 
 type ListTransactionsParams struct {
-	UserID  int64
-	TDate   time.Time
-	Column3 interface{}
-	TDate_2 time.Time
-	Column5 interface{}
+	UserID        int64
+	TDate         time.Time
+	Column3       interface{}
+	TDate_2       time.Time
+	Column5       interface{}
+	CreatedFrom   sql.NullTime
+	CreatedTo     sql.NullTime
+	Cleared       sql.NullBool
+	TagIds        sql.NullString
+	MinTagMatches int64
 }
 
 func (q *Queries) ListTransactions(ctx context.Context, arg ListTransactionsParams) ([]Transaction, error) {
@@ -947,6 +1933,12 @@ func (q *Queries) ListTransactions(ctx context.Context, arg ListTransactionsPara
 		arg.Column3,
 		arg.TDate_2,
 		arg.Column5,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.Cleared,
+		arg.MinTagMatches,
+		arg.TagIds,
+		arg.MinTagMatches,
 	)
 	if err != nil {
 		return nil, err
@@ -964,6 +1956,9 @@ func (q *Queries) ListTransactions(ctx context.Context, arg ListTransactionsPara
 			&i.CreatedAt,
 			&i.SourceRecurring,
 			&i.DeletedAt,
+			&i.Version,
+			&i.ExternalID,
+			&i.Cleared,
 		); err != nil {
 			return nil, err
 		}
@@ -979,7 +1974,7 @@ func (q *Queries) ListTransactions(ctx context.Context, arg ListTransactionsPara
 }
 
 const listTransactionsByDateRange = `-- name: ListTransactionsByDateRange :many
-SELECT id, user_id, amount_pence, t_date, note, created_at, source_recurring, deleted_at FROM transactions
+SELECT id, user_id, amount_pence, t_date, note, created_at, source_recurring, deleted_at, version, external_id, cleared FROM transactions
 WHERE user_id = ? AND deleted_at IS NULL
   AND t_date BETWEEN ? AND ?
 ORDER BY t_date DESC, created_at DESC
@@ -1003,6 +1998,9 @@ func (q *Queries) ListTransactionsByDateRange(ctx context.Context, userID int64)
 			&i.CreatedAt,
 			&i.SourceRecurring,
 			&i.DeletedAt,
+			&i.Version,
+			&i.ExternalID,
+			&i.Cleared,
 		); err != nil {
 			return nil, err
 		}
@@ -1018,7 +2016,7 @@ func (q *Queries) ListTransactionsByDateRange(ctx context.Context, userID int64)
 }
 
 const listUsers = `-- name: ListUsers :many
-SELECT id, email, pw_hash, created_at, is_service FROM users
+SELECT id, email, pw_hash, created_at, is_service, timezone FROM users
 ORDER BY created_at DESC
 `
 
@@ -1037,6 +2035,7 @@ func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
 			&i.PwHash,
 			&i.CreatedAt,
 			&i.IsService,
+			&i.Timezone,
 		); err != nil {
 			return nil, err
 		}
@@ -1051,13 +2050,227 @@ func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
 	return items, nil
 }
 
-const purgeSoftDeletedTransactions = `-- name: PurgeSoftDeletedTransactions :exec
+const markTagAlertNotified = `-- name: MarkTagAlertNotified :exec
+UPDATE tag_alerts
+SET last_notified_ym = ?
+WHERE id = ?
+`
+
+type MarkTagAlertNotifiedParams struct {
+	LastNotifiedYm sql.NullString
+	ID             int64
+}
+
+func (q *Queries) MarkTagAlertNotified(ctx context.Context, arg MarkTagAlertNotifiedParams) error {
+	_, err := q.db.ExecContext(ctx, markTagAlertNotified, arg.LastNotifiedYm, arg.ID)
+	return err
+}
+
+const purgeAllSoftDeleted = `-- name: PurgeAllSoftDeleted :execrows
 DELETE FROM transactions
-WHERE deleted_at IS NOT NULL AND deleted_at < ?
+WHERE user_id = ? AND deleted_at IS NOT NULL
 `
 
-func (q *Queries) PurgeSoftDeletedTransactions(ctx context.Context, deletedAt sql.NullTime) error {
-	_, err := q.db.ExecContext(ctx, purgeSoftDeletedTransactions, deletedAt)
+func (q *Queries) PurgeAllSoftDeleted(ctx context.Context, userID int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeAllSoftDeleted, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeAllSoftDeletedTags = `-- name: PurgeAllSoftDeletedTags :exec
+DELETE FROM transaction_tags
+WHERE transaction_id IN (
+    SELECT id FROM transactions
+    WHERE user_id = ? AND deleted_at IS NOT NULL
+)
+`
+
+func (q *Queries) PurgeAllSoftDeletedTags(ctx context.Context, userID int64) error {
+	_, err := q.db.ExecContext(ctx, purgeAllSoftDeletedTags, userID)
+	return err
+}
+
+const purgeSoftDeletedTransaction = `-- name: PurgeSoftDeletedTransaction :execrows
+DELETE FROM transactions
+WHERE id = ? AND user_id = ? AND deleted_at IS NOT NULL
+`
+
+type PurgeSoftDeletedTransactionParams struct {
+	ID     int64
+	UserID int64
+}
+
+func (q *Queries) PurgeSoftDeletedTransaction(ctx context.Context, arg PurgeSoftDeletedTransactionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeSoftDeletedTransaction, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeSoftDeletedTransactionTags = `-- name: PurgeSoftDeletedTransactionTags :exec
+DELETE FROM transaction_tags
+WHERE transaction_id IN (
+    SELECT id FROM transactions
+    WHERE user_id = ? AND deleted_at IS NOT NULL AND deleted_at < ?
+)
+`
+
+type PurgeSoftDeletedTransactionTagsParams struct {
+	UserID    int64
+	DeletedAt sql.NullTime
+}
+
+func (q *Queries) PurgeSoftDeletedTransactionTags(ctx context.Context, arg PurgeSoftDeletedTransactionTagsParams) error {
+	_, err := q.db.ExecContext(ctx, purgeSoftDeletedTransactionTags, arg.UserID, arg.DeletedAt)
+	return err
+}
+
+const purgeSoftDeletedTransactionTagsByID = `-- name: PurgeSoftDeletedTransactionTagsByID :exec
+DELETE FROM transaction_tags
+WHERE transaction_id IN (
+    SELECT id FROM transactions
+    WHERE id = ? AND user_id = ? AND deleted_at IS NOT NULL
+)
+`
+
+type PurgeSoftDeletedTransactionTagsByIDParams struct {
+	ID     int64
+	UserID int64
+}
+
+func (q *Queries) PurgeSoftDeletedTransactionTagsByID(ctx context.Context, arg PurgeSoftDeletedTransactionTagsByIDParams) error {
+	_, err := q.db.ExecContext(ctx, purgeSoftDeletedTransactionTagsByID, arg.ID, arg.UserID)
+	return err
+}
+
+const purgeSoftDeletedTransactions = `-- name: PurgeSoftDeletedTransactions :execrows
+DELETE FROM transactions
+WHERE user_id = ? AND deleted_at IS NOT NULL AND deleted_at < ?
+`
+
+type PurgeSoftDeletedTransactionsParams struct {
+	UserID    int64
+	DeletedAt sql.NullTime
+}
+
+func (q *Queries) PurgeSoftDeletedTransactions(ctx context.Context, arg PurgeSoftDeletedTransactionsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeSoftDeletedTransactions, arg.UserID, arg.DeletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const reassignTransactionTags = `-- name: ReassignTransactionTags :execrows
+UPDATE OR IGNORE transaction_tags
+SET tag_id = ?
+WHERE tag_id = ?
+`
+
+type ReassignTransactionTagsParams struct {
+	ToTagID   int64
+	FromTagID int64
+}
+
+func (q *Queries) ReassignTransactionTags(ctx context.Context, arg ReassignTransactionTagsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, reassignTransactionTags, arg.ToTagID, arg.FromTagID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const releaseSettingLock = `-- name: ReleaseSettingLock :exec
+DELETE FROM settings
+WHERE key = ? AND value = ?
+`
+
+type ReleaseSettingLockParams struct {
+	Key   string
+	Value string
+}
+
+func (q *Queries) ReleaseSettingLock(ctx context.Context, arg ReleaseSettingLockParams) error {
+	_, err := q.db.ExecContext(ctx, releaseSettingLock, arg.Key, arg.Value)
+	return err
+}
+
+const searchTags = `-- name: SearchTags :many
+SELECT id, name, created_at FROM tags
+WHERE name LIKE '%' || ? || '%' ESCAPE '\'
+ORDER BY CASE WHEN name LIKE ? || '%' ESCAPE '\' THEN 0 ELSE 1 END, name
+LIMIT ?
+`
+
+type SearchTagsParams struct {
+	Query string
+	Limit int64
+}
+
+func (q *Queries) SearchTags(ctx context.Context, arg SearchTagsParams) ([]Tag, error) {
+	rows, err := q.db.QueryContext(ctx, searchTags, arg.Query, arg.Query, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Tag
+	for rows.Next() {
+		var i Tag
+		if err := rows.Scan(&i.ID, &i.Name, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setTransactionCleared = `-- name: SetTransactionCleared :exec
+UPDATE transactions
+SET cleared = ?, version = version + 1
+WHERE id = ? AND deleted_at IS NULL
+`
+
+type SetTransactionClearedParams struct {
+	Cleared bool
+	ID      int64
+}
+
+func (q *Queries) SetTransactionCleared(ctx context.Context, arg SetTransactionClearedParams) error {
+	_, err := q.db.ExecContext(ctx, setTransactionCleared, arg.Cleared, arg.ID)
+	return err
+}
+
+const softDeleteAllTransactionsByUser = `-- name: SoftDeleteAllTransactionsByUser :execrows
+UPDATE transactions
+SET deleted_at = CURRENT_TIMESTAMP
+WHERE user_id = ? AND deleted_at IS NULL
+`
+
+func (q *Queries) SoftDeleteAllTransactionsByUser(ctx context.Context, userID int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, softDeleteAllTransactionsByUser, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const softDeleteRecurring = `-- name: SoftDeleteRecurring :exec
+UPDATE recurring
+SET deleted_at = CURRENT_TIMESTAMP, active = 0
+WHERE id = ? AND deleted_at IS NULL
+`
+
+func (q *Queries) SoftDeleteRecurring(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, softDeleteRecurring, id)
 	return err
 }
 
@@ -1083,12 +2296,23 @@ func (q *Queries) ToggleRecurringActive(ctx context.Context, id int64) error {
 	return err
 }
 
+const toggleTransactionCleared = `-- name: ToggleTransactionCleared :exec
+UPDATE transactions
+SET cleared = CASE WHEN cleared = 1 THEN 0 ELSE 1 END, version = version + 1
+WHERE id = ? AND deleted_at IS NULL
+`
+
+func (q *Queries) ToggleTransactionCleared(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, toggleTransactionCleared, id)
+	return err
+}
+
 const updateRecurring = `-- name: UpdateRecurring :one
 UPDATE recurring
 SET amount_pence = ?, description = ?, frequency = ?, interval_n = ?, 
-    first_due_date = ?, next_due_date = ?, end_date = ?, active = ?
+    first_due_date = ?, next_due_date = ?, end_date = ?, active = ?, group_name = ?
 WHERE id = ?
-RETURNING id, user_id, amount_pence, description, frequency, interval_n, first_due_date, next_due_date, end_date, active, created_at
+RETURNING id, user_id, amount_pence, description, frequency, interval_n, first_due_date, next_due_date, end_date, active, created_at, group_name, deleted_at
 `
 
 type UpdateRecurringParams struct {
@@ -1100,6 +2324,7 @@ type UpdateRecurringParams struct {
 	NextDueDate  time.Time
 	EndDate      sql.NullTime
 	Active       bool
+	GroupName    sql.NullString
 	ID           int64
 }
 
@@ -1113,6 +2338,7 @@ func (q *Queries) UpdateRecurring(ctx context.Context, arg UpdateRecurringParams
 		arg.NextDueDate,
 		arg.EndDate,
 		arg.Active,
+		arg.GroupName,
 		arg.ID,
 	)
 	var i Recurring
@@ -1128,6 +2354,8 @@ func (q *Queries) UpdateRecurring(ctx context.Context, arg UpdateRecurringParams
 		&i.EndDate,
 		&i.Active,
 		&i.CreatedAt,
+		&i.GroupName,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -1171,7 +2399,7 @@ const updateTag = `-- name: UpdateTag :one
 UPDATE tags
 SET name = ?
 WHERE id = ?
-RETURNING id, name
+RETURNING id, name, created_at
 `
 
 type UpdateTagParams struct {
@@ -1182,22 +2410,51 @@ type UpdateTagParams struct {
 func (q *Queries) UpdateTag(ctx context.Context, arg UpdateTagParams) (Tag, error) {
 	row := q.db.QueryRowContext(ctx, updateTag, arg.Name, arg.ID)
 	var i Tag
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
+	return i, err
+}
+
+const updateTagAlertThreshold = `-- name: UpdateTagAlertThreshold :one
+UPDATE tag_alerts
+SET threshold_pence = ?
+WHERE id = ?
+RETURNING id, user_id, tag_id, threshold_pence, last_notified_ym, created_at
+`
+
+type UpdateTagAlertThresholdParams struct {
+	ThresholdPence int64
+	ID             int64
+}
+
+func (q *Queries) UpdateTagAlertThreshold(ctx context.Context, arg UpdateTagAlertThresholdParams) (TagAlert, error) {
+	row := q.db.QueryRowContext(ctx, updateTagAlertThreshold, arg.ThresholdPence, arg.ID)
+	var i TagAlert
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TagID,
+		&i.ThresholdPence,
+		&i.LastNotifiedYm,
+		&i.CreatedAt,
+	)
 	return i, err
 }
 
 const updateTransaction = `-- name: UpdateTransaction :one
 UPDATE transactions
-SET amount_pence = ?, t_date = ?, note = ?
+SET amount_pence = ?, t_date = ?, note = ?, cleared = COALESCE(?, cleared), version = version + 1
 WHERE id = ? AND deleted_at IS NULL
-RETURNING id, user_id, amount_pence, t_date, note, created_at, source_recurring, deleted_at
+  AND version = COALESCE(CAST(? AS INTEGER), version)
+RETURNING id, user_id, amount_pence, t_date, note, created_at, source_recurring, deleted_at, version, external_id, cleared
 `
 
 type UpdateTransactionParams struct {
-	AmountPence int64
-	TDate       time.Time
-	Note        sql.NullString
-	ID          int64
+	AmountPence     int64
+	TDate           time.Time
+	Note            sql.NullString
+	Cleared         sql.NullBool
+	ID              int64
+	ExpectedVersion sql.NullInt64
 }
 
 func (q *Queries) UpdateTransaction(ctx context.Context, arg UpdateTransactionParams) (Transaction, error) {
@@ -1205,7 +2462,9 @@ func (q *Queries) UpdateTransaction(ctx context.Context, arg UpdateTransactionPa
 		arg.AmountPence,
 		arg.TDate,
 		arg.Note,
+		arg.Cleared,
 		arg.ID,
+		arg.ExpectedVersion,
 	)
 	var i Transaction
 	err := row.Scan(
@@ -1217,25 +2476,34 @@ func (q *Queries) UpdateTransaction(ctx context.Context, arg UpdateTransactionPa
 		&i.CreatedAt,
 		&i.SourceRecurring,
 		&i.DeletedAt,
+		&i.Version,
+		&i.ExternalID,
+		&i.Cleared,
 	)
 	return i, err
 }
 
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
-SET email = ?, pw_hash = ?
+SET email = ?, pw_hash = ?, timezone = ?
 WHERE id = ?
-RETURNING id, email, pw_hash, created_at, is_service
+RETURNING id, email, pw_hash, created_at, is_service, timezone
 `
 
 type UpdateUserParams struct {
-	Email  string
-	PwHash string
-	ID     int64
+	Email    string
+	PwHash   string
+	Timezone string
+	ID       int64
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
-	row := q.db.QueryRowContext(ctx, updateUser, arg.Email, arg.PwHash, arg.ID)
+	row := q.db.QueryRowContext(ctx, updateUser,
+		arg.Email,
+		arg.PwHash,
+		arg.Timezone,
+		arg.ID,
+	)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -1243,6 +2511,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.PwHash,
 		&i.CreatedAt,
 		&i.IsService,
+		&i.Timezone,
 	)
 	return i, err
 }
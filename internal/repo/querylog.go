@@ -0,0 +1,82 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewRepositoryWithQueryLog creates a repository that logs every statement it
+// runs, with its duration, at debug level. Logging wraps each call, so it is
+// only enabled when the logger has debug enabled; otherwise this returns the
+// same repository as NewRepository.
+func NewRepositoryWithQueryLog(db *sql.DB, logger *zap.Logger) Repository {
+	if !logger.Core().Enabled(zapcore.DebugLevel) {
+		return NewRepository(db)
+	}
+	return &RepositoryImpl{
+		Queries:      New(&loggingDBTX{db: db, logger: logger}),
+		db:           db,
+		queryTimeout: QueryTimeoutFromEnv(),
+		queryLogger:  logger,
+	}
+}
+
+// loggingDBTX logs the statements run through db. Only the number of
+// arguments is logged, as they can hold password hashes and session tokens.
+type loggingDBTX struct {
+	db     DBTX
+	logger *zap.Logger
+}
+
+func (l *loggingDBTX) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	start := time.Now()
+	result, err := l.db.ExecContext(ctx, query, args...)
+	l.log(query, len(args), start, err)
+	return result, err
+}
+
+func (l *loggingDBTX) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	start := time.Now()
+	stmt, err := l.db.PrepareContext(ctx, query)
+	l.log(query, 0, start, err)
+	return stmt, err
+}
+
+func (l *loggingDBTX) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := l.db.QueryContext(ctx, query, args...)
+	l.log(query, len(args), start, err)
+	return rows, err
+}
+
+func (l *loggingDBTX) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	start := time.Now()
+	row := l.db.QueryRowContext(ctx, query, args...)
+	// A row's error only surfaces on Scan, after this returns
+	l.log(query, len(args), start, nil)
+	return row
+}
+
+// queryNamePattern matches the sqlc comment naming a generated query
+var queryNamePattern = regexp.MustCompile(`^-- name: (\w+) :\w+`)
+
+func (l *loggingDBTX) log(query string, args int, start time.Time, err error) {
+	fields := []zap.Field{
+		zap.String("statement", strings.Join(strings.Fields(queryNamePattern.ReplaceAllString(query, "")), " ")),
+		zap.Duration("duration", time.Since(start)),
+		zap.Int("args", args),
+	}
+	if m := queryNamePattern.FindStringSubmatch(query); m != nil {
+		fields = append([]zap.Field{zap.String("query", m[1])}, fields...)
+	}
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+	l.logger.Debug("sql query", fields...)
+}
@@ -3,23 +3,50 @@ package repo
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
 )
 
+// defaultQueryTimeout is used when DB_QUERY_TIMEOUT is unset or invalid. It
+// sits below the server's 15s WriteTimeout so a stuck query fails the request
+// rather than hanging it.
+const defaultQueryTimeout = 5 * time.Second
+
+// QueryTimeoutFromEnv returns the database query timeout from env variable
+// DB_QUERY_TIMEOUT (a Go duration such as "5s", default 5s)
+func QueryTimeoutFromEnv() time.Duration {
+	if v, err := time.ParseDuration(os.Getenv("DB_QUERY_TIMEOUT")); err == nil && v > 0 {
+		return v
+	}
+	return defaultQueryTimeout
+}
+
 // RepositoryImpl implements the Repository interface
 type RepositoryImpl struct {
 	*Queries
-	db *sql.DB
+	db           *sql.DB
+	queryTimeout time.Duration
+	// queryLogger logs each statement when set, see NewRepositoryWithQueryLog
+	queryLogger *zap.Logger
 }
 
 // NewRepository creates a new repository instance
 func NewRepository(db *sql.DB) Repository {
 	return &RepositoryImpl{
-		Queries: New(db),
-		db:      db,
+		Queries:      New(db),
+		db:           db,
+		queryTimeout: QueryTimeoutFromEnv(),
 	}
 }
 
-// WithTx executes a function within a database transaction
+// WithTx executes a function within a database transaction. Each statement
+// issued through the transaction repository is bounded by the query timeout,
+// rather than the transaction as a whole, so long runs of quick statements
+// such as a scheduler catch-up still commit.
 func (r *RepositoryImpl) WithTx(ctx context.Context, fn func(Repository) error) error {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -27,17 +54,26 @@ func (r *RepositoryImpl) WithTx(ctx context.Context, fn func(Repository) error)
 	}
 
 	// Create a new repository instance with the transaction
+	timeouts := &timeoutTx{tx: tx, timeout: r.queryTimeout}
+	defer timeouts.cancelAll()
+	var txDB DBTX = timeouts
+	if r.queryLogger != nil {
+		txDB = &loggingDBTX{db: txDB, logger: r.queryLogger}
+	}
 	txRepo := &RepositoryImpl{
-		Queries: New(tx),
-		db:      r.db, // Keep reference to original db for potential future use
+		Queries:      New(txDB),
+		db:           r.db, // Keep reference to original db for potential future use
+		queryTimeout: r.queryTimeout,
+		queryLogger:  r.queryLogger,
 	}
 
 	// Execute the function with the transaction repository
 	if err := fn(txRepo); err != nil {
-		// Rollback on error
-		if rbErr := tx.Rollback(); rbErr != nil {
-			// Return both errors, but prioritize the original error
-			return err
+		// Rollback on error, surfacing a failed rollback alongside the
+		// original error so a half-applied transaction is not hidden. A
+		// transaction already rolled back by its context timing out is fine.
+		if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+			return errors.Join(err, fmt.Errorf("rollback transaction: %w", rbErr))
 		}
 		return err
 	}
@@ -49,4 +85,62 @@ func (r *RepositoryImpl) WithTx(ctx context.Context, fn func(Repository) error)
 // GetDB returns the underlying database connection
 func (r *RepositoryImpl) GetDB() *sql.DB {
 	return r.db
-} 
\ No newline at end of file
+}
+
+// GetOrCreateTag returns the tag called name, creating it when there is none.
+// created reports whether this call created it. The insert is a no-op when
+// the name exists, so concurrent calls agree on a single tag.
+func (r *RepositoryImpl) GetOrCreateTag(ctx context.Context, name string) (tag Tag, created bool, err error) {
+	inserted, err := r.CreateTagIfNotExists(ctx, name)
+	if err != nil {
+		return Tag{}, false, err
+	}
+	tag, err = r.GetTagByName(ctx, name)
+	if err != nil {
+		return Tag{}, false, err
+	}
+	return tag, inserted > 0, nil
+}
+
+// timeoutTx runs each statement on a transaction under its own query
+// timeout, so a slow query inside WithTx is interrupted at the deadline
+type timeoutTx struct {
+	tx      *sql.Tx
+	timeout time.Duration
+	// cancels holds the cancel funcs of statements whose results are read
+	// after they return; WithTx calls them once the transaction is done
+	cancels []context.CancelFunc
+}
+
+// statementContext returns ctx bounded by the query timeout
+func (t *timeoutTx) statementContext(ctx context.Context) context.Context {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	t.cancels = append(t.cancels, cancel)
+	return ctx
+}
+
+func (t *timeoutTx) cancelAll() {
+	for _, cancel := range t.cancels {
+		cancel()
+	}
+}
+
+func (t *timeoutTx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.tx.ExecContext(ctx, query, args...)
+}
+
+func (t *timeoutTx) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.tx.PrepareContext(ctx, query)
+}
+
+func (t *timeoutTx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return t.tx.QueryContext(t.statementContext(ctx), query, args...)
+}
+
+func (t *timeoutTx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return t.tx.QueryRowContext(t.statementContext(ctx), query, args...)
+}
@@ -3,6 +3,11 @@ package repo
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -10,6 +15,9 @@ import (
 	"github.com/pressly/goose/v3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func setupTestDB(t *testing.T) *sql.DB {
@@ -233,6 +241,23 @@ func TestRepository_ListTags(t *testing.T) {
 	assert.True(t, tagNames["tag2"])
 }
 
+func TestRepository_GetOrCreateTag(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewRepository(db)
+
+	first, created, err := repo.GetOrCreateTag(context.Background(), "get-or-create")
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.True(t, first.CreatedAt.Valid)
+
+	second, created, err := repo.GetOrCreateTag(context.Background(), "get-or-create")
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, first, second)
+}
+
 func TestRepository_CreateTransaction(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
@@ -266,4 +291,347 @@ func TestRepository_CreateTransaction(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, txn.ID, retrievedTxn.ID)
 	assert.Equal(t, txn.AmountPence, retrievedTxn.AmountPence)
-} 
\ No newline at end of file
+} 
+
+var errRollbackFailed = errors.New("rollback failed")
+
+// rollbackFailConnector opens connections whose transactions cannot be rolled back
+type rollbackFailConnector struct{}
+
+func (rollbackFailConnector) Connect(context.Context) (driver.Conn, error) {
+	return rollbackFailConn{}, nil
+}
+
+func (c rollbackFailConnector) Driver() driver.Driver { return c }
+
+func (rollbackFailConnector) Open(string) (driver.Conn, error) { return rollbackFailConn{}, nil }
+
+type rollbackFailConn struct{}
+
+func (rollbackFailConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (rollbackFailConn) Close() error { return nil }
+
+func (rollbackFailConn) Begin() (driver.Tx, error) { return rollbackFailTx{}, nil }
+
+type rollbackFailTx struct{}
+
+func (rollbackFailTx) Commit() error { return nil }
+
+func (rollbackFailTx) Rollback() error { return errRollbackFailed }
+
+func TestWithTx_RollbackFailure(t *testing.T) {
+	db := sql.OpenDB(rollbackFailConnector{})
+	defer db.Close()
+
+	repo := NewRepository(db)
+	opErr := errors.New("operation failed")
+
+	err := repo.WithTx(context.Background(), func(txRepo Repository) error {
+		return opErr
+	})
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, opErr)
+	assert.ErrorIs(t, err, errRollbackFailed)
+	assert.Contains(t, err.Error(), "rollback transaction: rollback failed")
+}
+
+func TestQueryTimeoutFromEnv(t *testing.T) {
+	t.Setenv("DB_QUERY_TIMEOUT", "")
+	assert.Equal(t, defaultQueryTimeout, QueryTimeoutFromEnv())
+
+	t.Setenv("DB_QUERY_TIMEOUT", "250ms")
+	assert.Equal(t, 250*time.Millisecond, QueryTimeoutFromEnv())
+
+	t.Setenv("DB_QUERY_TIMEOUT", "soon")
+	assert.Equal(t, defaultQueryTimeout, QueryTimeoutFromEnv())
+}
+
+func TestWithTx_QueryTimeout(t *testing.T) {
+	t.Setenv("DB_QUERY_TIMEOUT", "100ms")
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewRepository(db)
+
+	// An unbounded recursive CTE never finishes on its own
+	const slowQuery = `WITH RECURSIVE n(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM n) SELECT COUNT(*) FROM n`
+
+	start := time.Now()
+	err := repo.WithTx(context.Background(), func(txRepo Repository) error {
+		var count int64
+		return txRepo.(*RepositoryImpl).Queries.db.QueryRowContext(context.Background(), slowQuery).Scan(&count)
+	})
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestWithTx_QueryTimeoutIsPerStatement(t *testing.T) {
+	t.Setenv("DB_QUERY_TIMEOUT", "100ms")
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewRepository(db)
+
+	// Together the statements outlast the timeout, but none does alone
+	err := repo.WithTx(context.Background(), func(txRepo Repository) error {
+		for i := 0; i < 4; i++ {
+			if _, err := txRepo.ListTags(context.Background()); err != nil {
+				return err
+			}
+			time.Sleep(40 * time.Millisecond)
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+}
+
+// setupFileTestDB opens a migrated on-disk database the way the server does,
+// since WAL and locking only matter for file databases
+func setupFileTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open(SQLiteDriver, SQLiteDSN(filepath.Join(t.TempDir(), "budget.db")))
+	require.NoError(t, err)
+
+	err = goose.SetDialect("sqlite3")
+	require.NoError(t, err)
+
+	err = goose.Up(db, "../../migrations")
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestSQLiteDSN(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		t.Setenv("SQLITE_BUSY_TIMEOUT", "")
+		t.Setenv("SQLITE_JOURNAL_MODE", "")
+
+		assert.Equal(t, "/data/budget.db?_busy_timeout=5000&_journal_mode=WAL&_txlock=immediate",
+			SQLiteDSN("/data/budget.db"))
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		t.Setenv("SQLITE_BUSY_TIMEOUT", "250")
+		t.Setenv("SQLITE_JOURNAL_MODE", "DELETE")
+
+		assert.Equal(t, "file:budget.db?mode=rwc&_busy_timeout=250&_journal_mode=DELETE&_txlock=immediate",
+			SQLiteDSN("file:budget.db?mode=rwc"))
+	})
+}
+
+func TestSQLiteDSN_ConnectionSettings(t *testing.T) {
+	db := setupFileTestDB(t)
+	defer db.Close()
+
+	var journalMode string
+	require.NoError(t, db.QueryRow("PRAGMA journal_mode").Scan(&journalMode))
+	assert.Equal(t, "wal", journalMode)
+
+	var foreignKeys, busyTimeout int
+	require.NoError(t, db.QueryRow("PRAGMA foreign_keys").Scan(&foreignKeys))
+	assert.Equal(t, 1, foreignKeys)
+	require.NoError(t, db.QueryRow("PRAGMA busy_timeout").Scan(&busyTimeout))
+	assert.Equal(t, 5000, busyTimeout)
+}
+
+func TestConcurrentWrites(t *testing.T) {
+	db := setupFileTestDB(t)
+	defer db.Close()
+
+	repo := NewRepository(db)
+	ctx := context.Background()
+	user, err := repo.CreateUser(ctx, CreateUserParams{Email: "writer@example.com", PwHash: "hash"})
+	require.NoError(t, err)
+	tag, err := repo.CreateTag(ctx, "concurrent")
+	require.NoError(t, err)
+
+	const writers, perWriter = 16, 100
+	var wg sync.WaitGroup
+	errs := make(chan error, writers*perWriter)
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				errs <- repo.WithTx(ctx, func(txRepo Repository) error {
+					// Read before writing, as the handlers do
+					if _, err := txRepo.GetTagByID(ctx, tag.ID); err != nil {
+						return err
+					}
+					txn, err := txRepo.CreateTransaction(ctx, CreateTransactionParams{
+						UserID:      user.ID,
+						AmountPence: int64(-(w*perWriter + i + 1)),
+						TDate:       time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC),
+						Note:        sql.NullString{String: fmt.Sprintf("writer %d #%d", w, i), Valid: true},
+					})
+					if err != nil {
+						return err
+					}
+					return txRepo.CreateTransactionTag(ctx, CreateTransactionTagParams{
+						TransactionID: txn.ID,
+						TagID:         tag.ID,
+					})
+				})
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	transactions, err := repo.GetTransactionsByTag(ctx, tag.ID)
+	require.NoError(t, err)
+	assert.Len(t, transactions, writers*perWriter)
+}
+
+func TestForeignKeys(t *testing.T) {
+	db := setupFileTestDB(t)
+	defer db.Close()
+
+	repo := NewRepository(db)
+	ctx := context.Background()
+	user, err := repo.CreateUser(ctx, CreateUserParams{Email: "cascade@example.com", PwHash: "hash"})
+	require.NoError(t, err)
+	tag, err := repo.CreateTag(ctx, "cascade")
+	require.NoError(t, err)
+
+	txn, err := repo.CreateTransaction(ctx, CreateTransactionParams{
+		UserID:      user.ID,
+		AmountPence: -100,
+		TDate:       time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateTransactionTag(ctx, CreateTransactionTagParams{TransactionID: txn.ID, TagID: tag.ID}))
+
+	// A linked tag can't be deleted until it is unlinked
+	err = repo.DeleteTag(ctx, tag.ID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "FOREIGN KEY constraint failed")
+	_, err = repo.DeleteTransactionTagsByTag(ctx, tag.ID)
+	require.NoError(t, err)
+	require.NoError(t, repo.DeleteRecurringTagsByTag(ctx, tag.ID))
+	require.NoError(t, repo.DeleteTag(ctx, tag.ID))
+
+	// Receipts are removed with the transaction
+	_, err = repo.CreateReceipt(ctx, CreateReceiptParams{TransactionID: txn.ID, Url: "https://example.com/r.pdf"})
+	require.NoError(t, err)
+	require.NoError(t, repo.HardDeleteTransaction(ctx, txn.ID))
+	receipts, err := repo.ListReceipts(ctx, txn.ID)
+	require.NoError(t, err)
+	assert.Empty(t, receipts)
+}
+
+func TestSQLiteDriver_EnforcesForeignKeys(t *testing.T) {
+	// The pragma is applied by the driver, even if the DSN turns it off
+	db, err := sql.Open(SQLiteDriver, "file::memory:?_foreign_keys=off")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	require.NoError(t, goose.SetDialect("sqlite3"))
+	require.NoError(t, goose.Up(db, "../../migrations"))
+
+	repo := NewRepository(db)
+	ctx := context.Background()
+	user, err := repo.CreateUser(ctx, CreateUserParams{Email: "fk@example.com", PwHash: "hash"})
+	require.NoError(t, err)
+	txn, err := repo.CreateTransaction(ctx, CreateTransactionParams{
+		UserID:      user.ID,
+		AmountPence: -100,
+		TDate:       time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	err = repo.CreateTransactionTag(ctx, CreateTransactionTagParams{TransactionID: txn.ID, TagID: 999999})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "FOREIGN KEY constraint failed")
+	assert.False(t, IsUniqueViolation(err))
+}
+
+func TestIsUniqueViolation(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	_, err := repo.CreateTag(ctx, "unique-violation")
+	require.NoError(t, err)
+	_, err = repo.CreateTag(ctx, "unique-violation")
+	require.Error(t, err)
+	assert.True(t, IsUniqueViolation(err))
+	assert.True(t, IsUniqueViolation(fmt.Errorf("wrapped: %w", err)))
+
+	assert.False(t, IsUniqueViolation(nil))
+	assert.False(t, IsUniqueViolation(sql.ErrNoRows))
+}
+
+func TestNewRepositoryWithQueryLog(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	t.Run("logs statements at debug level", func(t *testing.T) {
+		core, logs := observer.New(zapcore.DebugLevel)
+		repo := NewRepositoryWithQueryLog(db, zap.New(core))
+
+		_, err := repo.CreateUser(ctx, CreateUserParams{Email: "logged@example.com", PwHash: "secret-hash"})
+		require.NoError(t, err)
+
+		entries := logs.FilterMessage("sql query").All()
+		require.Len(t, entries, 1)
+		fields := entries[0].ContextMap()
+		assert.Equal(t, zapcore.DebugLevel, entries[0].Level)
+		assert.Equal(t, "CreateUser", fields["query"])
+		assert.Contains(t, fields["statement"], "INSERT INTO users")
+		assert.NotContains(t, fields["statement"], "-- name")
+		assert.Contains(t, fields, "duration")
+		assert.Equal(t, int64(3), fields["args"])
+		assert.NotContains(t, fmt.Sprint(fields), "secret-hash")
+	})
+
+	t.Run("logs statements inside transactions", func(t *testing.T) {
+		core, logs := observer.New(zapcore.DebugLevel)
+		repo := NewRepositoryWithQueryLog(db, zap.New(core))
+
+		err := repo.WithTx(ctx, func(txRepo Repository) error {
+			_, err := txRepo.GetUserByEmail(ctx, "logged@example.com")
+			return err
+		})
+		require.NoError(t, err)
+
+		entries := logs.FilterField(zap.String("query", "GetUserByEmail")).All()
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("logs failed statements with the error", func(t *testing.T) {
+		core, logs := observer.New(zapcore.DebugLevel)
+		logged := &loggingDBTX{db: db, logger: zap.New(core)}
+
+		_, err := logged.ExecContext(ctx, "DELETE FROM no_such_table")
+		require.Error(t, err)
+
+		entries := logs.FilterMessage("sql query").All()
+		require.Len(t, entries, 1)
+		assert.Contains(t, entries[0].ContextMap(), "error")
+	})
+
+	t.Run("off unless debug is enabled", func(t *testing.T) {
+		core, logs := observer.New(zapcore.InfoLevel)
+		repo := NewRepositoryWithQueryLog(db, zap.New(core))
+
+		_, err := repo.ListUsers(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, logs.Len())
+		assert.Nil(t, repo.(*RepositoryImpl).queryLogger)
+	})
+}
@@ -0,0 +1,66 @@
+package repo
+
+import (
+	"database/sql"
+	"errors"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// SQLiteDriver is the database/sql driver name for SQLite connections that
+// always enforce foreign keys. SQLite only does so when each connection turns
+// it on, so the pragma runs as every pooled connection is opened.
+const SQLiteDriver = "sqlite3_fk"
+
+func init() {
+	sql.Register(SQLiteDriver, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			_, err := conn.Exec("PRAGMA foreign_keys = ON", nil)
+			return err
+		},
+	})
+}
+
+// defaultBusyTimeoutMs is how long a connection waits on a locked database
+// before failing with "database is locked"
+const defaultBusyTimeoutMs = 5000
+
+// SQLiteDSN returns the go-sqlite3 data source name for the database at path.
+// Connections wait on locks instead of failing immediately, use WAL so the
+// scheduler and API can read while one of them writes, and take the write
+// lock when a transaction begins. These can be overridden with env variables
+// SQLITE_BUSY_TIMEOUT (milliseconds, default 5000) and SQLITE_JOURNAL_MODE
+// (default WAL). Foreign keys are enforced by SQLiteDriver.
+func SQLiteDSN(path string) string {
+	busyTimeout := defaultBusyTimeoutMs
+	if v, err := strconv.Atoi(os.Getenv("SQLITE_BUSY_TIMEOUT")); err == nil && v >= 0 {
+		busyTimeout = v
+	}
+	journalMode := "WAL"
+	if v := os.Getenv("SQLITE_JOURNAL_MODE"); v != "" {
+		journalMode = v
+	}
+
+	params := url.Values{}
+	params.Set("_busy_timeout", strconv.Itoa(busyTimeout))
+	params.Set("_journal_mode", journalMode)
+	params.Set("_txlock", "immediate")
+
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return path + separator + params.Encode()
+}
+
+// IsUniqueViolation reports whether err is SQLite rejecting a write that
+// breaks a UNIQUE constraint or index, as when a concurrent request inserted
+// the same row first
+func IsUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+}
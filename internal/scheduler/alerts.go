@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+	"go.uber.org/zap"
+)
+
+// TagAlertEvent describes a tag whose spend in a month reached its alert threshold
+type TagAlertEvent struct {
+	AlertID        int64
+	TagID          int64
+	TagName        string
+	YearMonth      string
+	SpendPence     int64
+	ThresholdPence int64
+}
+
+// AlertNotifier is told when a tag alert fires
+type AlertNotifier interface {
+	NotifyTagAlert(ctx context.Context, event TagAlertEvent) error
+}
+
+// EvaluateTagAlerts compares the user's spend on each alerted tag in month ym
+// (YYYY-MM) with the alert's threshold. Alerts that have reached it and have
+// not yet fired that month are sent to notifier, marked as fired and
+// returned. A failed delivery is logged and leaves the alert to fire on the
+// next evaluation. With a nil notifier the alerts that would fire are
+// returned without being marked.
+func EvaluateTagAlerts(ctx context.Context, r repo.Repository, userID int64, ym string, notifier AlertNotifier, logger *zap.Logger) ([]TagAlertEvent, error) {
+	alerts, err := r.ListTagAlerts(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []TagAlertEvent
+	for _, alert := range alerts {
+		if alert.LastNotifiedYm.Valid && alert.LastNotifiedYm.String == ym {
+			continue
+		}
+
+		spend, err := r.GetTagMonthlySpend(ctx, repo.GetTagMonthlySpendParams{
+			UserID: userID,
+			TagID:  alert.TagID,
+			Ym:     ym,
+		})
+		if err != nil {
+			return nil, err
+		}
+		var spendPence int64
+		if spend.Valid {
+			spendPence = int64(spend.Float64)
+		}
+		if spendPence < alert.ThresholdPence {
+			continue
+		}
+
+		tag, err := r.GetTagByID(ctx, alert.TagID)
+		if err != nil {
+			return nil, err
+		}
+		event := TagAlertEvent{
+			AlertID:        alert.ID,
+			TagID:          alert.TagID,
+			TagName:        tag.Name,
+			YearMonth:      ym,
+			SpendPence:     spendPence,
+			ThresholdPence: alert.ThresholdPence,
+		}
+
+		if notifier != nil {
+			if err := notifier.NotifyTagAlert(ctx, event); err != nil {
+				logger.Error("failed to send tag alert webhook", zap.Error(err), zap.Int64("alert_id", alert.ID))
+				continue
+			}
+			err = r.MarkTagAlertNotified(ctx, repo.MarkTagAlertNotifiedParams{
+				LastNotifiedYm: sql.NullString{String: ym, Valid: true},
+				ID:             alert.ID,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
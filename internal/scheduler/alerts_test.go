@@ -0,0 +1,91 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+)
+
+// recordingAlertNotifier keeps the tag alerts it is sent
+type recordingAlertNotifier struct {
+	events []TagAlertEvent
+}
+
+func (n *recordingAlertNotifier) NotifyTagAlert(ctx context.Context, event TagAlertEvent) error {
+	n.events = append(n.events, event)
+	return nil
+}
+
+func TestEvaluateTagAlerts(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	repository := repo.NewRepository(db)
+	ctx := context.Background()
+	userID := createTestUser(t, repository)
+
+	over, err := repository.CreateTag(ctx, "alert-over")
+	require.NoError(t, err)
+	under, err := repository.CreateTag(ctx, "alert-under")
+	require.NoError(t, err)
+
+	spend := func(tagID, amountPence int64, tDate time.Time) {
+		txn, err := repository.CreateTransaction(ctx, repo.CreateTransactionParams{
+			UserID:      userID,
+			AmountPence: amountPence,
+			TDate:       tDate,
+		})
+		require.NoError(t, err)
+		require.NoError(t, repository.CreateTransactionTag(ctx, repo.CreateTransactionTagParams{
+			TransactionID: txn.ID,
+			TagID:         tagID,
+		}))
+	}
+
+	june := time.Date(2031, 6, 10, 0, 0, 0, 0, time.UTC)
+	spend(over.ID, -6000, june)
+	spend(over.ID, -5000, june)
+	spend(under.ID, -3000, june)
+	// Income and other months don't count towards the spend
+	spend(under.ID, 9000, june)
+	spend(under.ID, -9000, time.Date(2031, 5, 10, 0, 0, 0, 0, time.UTC))
+
+	for _, tagID := range []int64{over.ID, under.ID} {
+		_, err := repository.CreateTagAlert(ctx, repo.CreateTagAlertParams{
+			UserID:         userID,
+			TagID:          tagID,
+			ThresholdPence: 10000,
+		})
+		require.NoError(t, err)
+	}
+
+	notifier := &recordingAlertNotifier{}
+	events, err := EvaluateTagAlerts(ctx, repository, userID, "2031-06", notifier, zap.NewNop())
+	require.NoError(t, err)
+
+	// Only the tag over its threshold fires
+	require.Len(t, notifier.events, 1)
+	assert.Equal(t, events, notifier.events)
+	assert.Equal(t, over.ID, events[0].TagID)
+	assert.Equal(t, "alert-over", events[0].TagName)
+	assert.Equal(t, "2031-06", events[0].YearMonth)
+	assert.Equal(t, int64(11000), events[0].SpendPence)
+	assert.Equal(t, int64(10000), events[0].ThresholdPence)
+
+	alert, err := repository.GetTagAlertByID(ctx, events[0].AlertID)
+	require.NoError(t, err)
+	assert.Equal(t, "2031-06", alert.LastNotifiedYm.String)
+
+	// An alert fires once a month
+	events, err = EvaluateTagAlerts(ctx, repository, userID, "2031-06", notifier, zap.NewNop())
+	require.NoError(t, err)
+	assert.Empty(t, events)
+	assert.Len(t, notifier.events, 1)
+}
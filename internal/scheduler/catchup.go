@@ -0,0 +1,38 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+	"go.uber.org/zap"
+)
+
+// maxCatchUpSetting is the setting capping how many transactions a single
+// run creates for one rule that has fallen behind
+const maxCatchUpSetting = "recurring_max_catchup"
+
+// defaultMaxCatchUp is the cap used when maxCatchUpSetting is not set. It is
+// well above any legitimate backlog, and only stops a misconfigured rule (say
+// a daily rule anchored decades ago) from flooding the ledger in one run.
+const defaultMaxCatchUp = 1000
+
+// loadMaxCatchUp returns the catch-up cap setting, or defaultMaxCatchUp when
+// it is not set. Values that are not positive integers are logged and ignored.
+func loadMaxCatchUp(ctx context.Context, r repo.Repository, logger *zap.Logger) (int, error) {
+	setting, err := r.GetSetting(ctx, maxCatchUpSetting)
+	if errors.Is(err, sql.ErrNoRows) {
+		return defaultMaxCatchUp, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(setting.Value)
+	if err != nil || n < 1 {
+		logger.Warn("invalid catch-up cap setting, using default", zap.String("value", setting.Value), zap.Int("default", defaultMaxCatchUp))
+		return defaultMaxCatchUp, nil
+	}
+	return n, nil
+}
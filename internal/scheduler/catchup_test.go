@@ -0,0 +1,196 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+)
+
+func TestRunCapsCatchUp(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	repository := repo.NewRepository(db)
+	ctx := context.Background()
+	userID := createTestUser(t, repository)
+
+	// A daily rule anchored years before the run is due thousands of times
+	anchor := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2031, 6, 1, 0, 0, 0, 0, time.UTC)
+	rule := createRecurringRule(t, repository, userID, anchor, "daily", 1, -100)
+	materialized := func() int {
+		transactions, err := repository.GetTransactionsByRecurringID(ctx, sql.NullInt64{Int64: rule.ID, Valid: true})
+		require.NoError(t, err)
+		return len(transactions)
+	}
+	cappedWarnings := func(logs *observer.ObservedLogs) []observer.LoggedEntry {
+		var entries []observer.LoggedEntry
+		for _, entry := range logs.FilterMessage("recurring catch-up capped").All() {
+			if entry.ContextMap()["recurring_id"] == rule.ID {
+				entries = append(entries, entry)
+			}
+		}
+		return entries
+	}
+
+	core, logs := observer.New(zap.WarnLevel)
+	_, err := Run(ctx, db, today, zap.New(core))
+	require.NoError(t, err)
+
+	assert.Equal(t, defaultMaxCatchUp, materialized())
+	assertRecurringNextDueDate(t, repository, rule.ID, anchor.AddDate(0, 0, defaultMaxCatchUp))
+	warnings := cappedWarnings(logs)
+	require.Len(t, warnings, 1)
+	assert.Equal(t, int64(defaultMaxCatchUp), warnings[0].ContextMap()["max_occurrences"])
+
+	// The setting lowers the cap; the next run picks up where the last stopped
+	_, err = repository.CreateSetting(ctx, repo.CreateSettingParams{Key: maxCatchUpSetting, Value: "10"})
+	require.NoError(t, err)
+
+	core, logs = observer.New(zap.WarnLevel)
+	_, err = Run(ctx, db, today, zap.New(core))
+	require.NoError(t, err)
+
+	assert.Equal(t, defaultMaxCatchUp+10, materialized())
+	assertRecurringNextDueDate(t, repository, rule.ID, anchor.AddDate(0, 0, defaultMaxCatchUp+10))
+	assert.Len(t, cappedWarnings(logs), 1)
+}
+
+func TestRunCatchesUpMissedOccurrences(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	repository := repo.NewRepository(db)
+	ctx := context.Background()
+	userID := createTestUser(t, repository)
+
+	anchor := time.Date(2031, 3, 1, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2031, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	// Catch up the seeded rules first so the summary only covers this one
+	_, err := Run(ctx, db, today, zap.NewNop())
+	require.NoError(t, err)
+	rule := createRecurringRule(t, repository, userID, anchor, "monthly", 1, -1000)
+
+	core, logs := observer.New(zap.WarnLevel)
+	summary, err := Run(ctx, db, today, zap.New(core))
+	require.NoError(t, err)
+
+	transactions, err := repository.GetTransactionsByRecurringID(ctx, sql.NullInt64{Int64: rule.ID, Valid: true})
+	require.NoError(t, err)
+	dates := make([]time.Time, 0, len(transactions))
+	for _, txn := range transactions {
+		dates = append(dates, txn.TDate)
+	}
+	assert.ElementsMatch(t, []time.Time{
+		anchor,
+		anchor.AddDate(0, 1, 0),
+		anchor.AddDate(0, 2, 0),
+		anchor.AddDate(0, 3, 0),
+	}, dates)
+	assert.Equal(t, 4, summary.Created)
+	assert.Equal(t, 1, summary.Processed)
+	assertRecurringNextDueDate(t, repository, rule.ID, time.Date(2031, 7, 1, 0, 0, 0, 0, time.UTC))
+	assert.Zero(t, logs.FilterMessage("recurring catch-up capped").Len())
+}
+
+func TestRunCatchesUpBeforeEndingRule(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	repository := repo.NewRepository(db)
+	ctx := context.Background()
+	userID := createTestUser(t, repository)
+
+	anchor := time.Date(2031, 3, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2031, 5, 15, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2031, 6, 15, 0, 0, 0, 0, time.UTC)
+	createRule := func() repo.Recurring {
+		rule, err := repository.CreateRecurring(ctx, repo.CreateRecurringParams{
+			UserID:       userID,
+			AmountPence:  -1000,
+			Description:  sql.NullString{String: "Test recurring rule", Valid: true},
+			Frequency:    "monthly",
+			IntervalN:    1,
+			FirstDueDate: anchor,
+			NextDueDate:  anchor,
+			EndDate:      sql.NullTime{Time: endDate, Valid: true},
+			Active:       true,
+		})
+		require.NoError(t, err)
+		return rule
+	}
+	materialized := func(rule repo.Recurring) []time.Time {
+		transactions, err := repository.GetTransactionsByRecurringID(ctx, sql.NullInt64{Int64: rule.ID, Valid: true})
+		require.NoError(t, err)
+		dates := make([]time.Time, 0, len(transactions))
+		for _, txn := range transactions {
+			dates = append(dates, txn.TDate)
+		}
+		return dates
+	}
+	missed := []time.Time{anchor, anchor.AddDate(0, 1, 0), anchor.AddDate(0, 2, 0)}
+
+	// The occurrences missed before the end date are created, then the rule ends
+	rule := createRule()
+	_, err := Run(ctx, db, today, zap.NewNop())
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, missed, materialized(rule))
+	updated, err := repository.GetRecurringByID(ctx, rule.ID)
+	require.NoError(t, err)
+	assert.False(t, updated.Active)
+
+	// A capped rule stays active until the remaining occurrences are created
+	_, err = repository.CreateSetting(ctx, repo.CreateSettingParams{Key: maxCatchUpSetting, Value: "2"})
+	require.NoError(t, err)
+	rule = createRule()
+
+	_, err = Run(ctx, db, today, zap.NewNop())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, missed[:2], materialized(rule))
+	updated, err = repository.GetRecurringByID(ctx, rule.ID)
+	require.NoError(t, err)
+	assert.True(t, updated.Active)
+	assertRecurringNextDueDate(t, repository, rule.ID, missed[2])
+
+	_, err = Run(ctx, db, today, zap.NewNop())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, missed, materialized(rule))
+	updated, err = repository.GetRecurringByID(ctx, rule.ID)
+	require.NoError(t, err)
+	assert.False(t, updated.Active)
+}
+
+func TestLoadMaxCatchUp(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	repository := repo.NewRepository(db)
+	ctx := context.Background()
+
+	n, err := loadMaxCatchUp(ctx, repository, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, defaultMaxCatchUp, n)
+
+	_, err = repository.CreateSetting(ctx, repo.CreateSettingParams{Key: maxCatchUpSetting, Value: "0"})
+	require.NoError(t, err)
+
+	core, logs := observer.New(zap.WarnLevel)
+	n, err = loadMaxCatchUp(ctx, repository, zap.New(core))
+	require.NoError(t, err)
+	assert.Equal(t, defaultMaxCatchUp, n)
+	assert.Equal(t, 1, logs.FilterMessage("invalid catch-up cap setting, using default").Len())
+}
@@ -0,0 +1,53 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+)
+
+// ErrAlreadyRunning is returned by Run while another run holds the lock
+var ErrAlreadyRunning = errors.New("scheduler already running")
+
+const (
+	// lockSetting is the setting a run holds while it works, valued with the
+	// time it started
+	lockSetting = "scheduler_lock"
+
+	// lockTimeout is how long a lock is honoured. A run that crashed without
+	// releasing its lock stops blocking the scheduler once it has passed.
+	lockTimeout = 15 * time.Minute
+
+	// lockTimeFormat is fixed width so lock times compare as strings
+	lockTimeFormat = "2006-01-02T15:04:05.000000000Z"
+)
+
+// acquireLock takes the scheduler lock as of now, returning the token that
+// releases it. It fails with ErrAlreadyRunning while another run holds a lock
+// taken within lockTimeout.
+func acquireLock(ctx context.Context, r repo.Repository, now time.Time) (string, error) {
+	token := now.UTC().Format(lockTimeFormat)
+	acquired, err := r.AcquireSettingLock(ctx, repo.AcquireSettingLockParams{
+		Key:         lockSetting,
+		Value:       token,
+		StaleBefore: now.Add(-lockTimeout).UTC().Format(lockTimeFormat),
+	})
+	if err != nil {
+		return "", err
+	}
+	if acquired == 0 {
+		return "", ErrAlreadyRunning
+	}
+	return token, nil
+}
+
+// releaseLock gives up the lock taken with token. A lock another run has
+// since taken over is left alone.
+func releaseLock(ctx context.Context, r repo.Repository, token string) error {
+	return r.ReleaseSettingLock(ctx, repo.ReleaseSettingLockParams{
+		Key:   lockSetting,
+		Value: token,
+	})
+}
@@ -0,0 +1,106 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+)
+
+func TestRunRefusesWhileAnotherRunHoldsTheLock(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	repository := repo.NewRepository(db)
+	ctx := context.Background()
+	userID := createTestUser(t, repository)
+
+	dueDate := time.Date(2031, 6, 1, 0, 0, 0, 0, time.UTC)
+	rule := createRecurringRule(t, repository, userID, dueDate, "monthly", 1, -1000)
+	materialized := func() int {
+		transactions, err := repository.GetTransactionsByRecurringID(ctx, sql.NullInt64{Int64: rule.ID, Valid: true})
+		require.NoError(t, err)
+		return len(transactions)
+	}
+
+	// A run in progress holds the lock while the second one starts
+	token, err := acquireLock(ctx, repository, dueDate)
+	require.NoError(t, err)
+
+	_, err = Run(ctx, db, dueDate, zap.NewNop())
+	require.ErrorIs(t, err, ErrAlreadyRunning)
+	assert.Equal(t, 0, materialized(), "the refused run must not materialize anything")
+	assertRecurringNextDueDate(t, repository, rule.ID, dueDate)
+
+	// Once the first run finishes the next one proceeds
+	require.NoError(t, releaseLock(ctx, repository, token))
+
+	_, err = Run(ctx, db, dueDate, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, 1, materialized())
+
+	_, err = repository.GetSetting(ctx, lockSetting)
+	assert.ErrorIs(t, err, sql.ErrNoRows, "a finished run releases its lock")
+
+	// Runs that follow one another both proceed
+	_, err = Run(ctx, db, dueDate, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, 1, materialized())
+}
+
+func TestRunExpiresLocksByItsOwnClock(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	repository := repo.NewRepository(db)
+	ctx := context.Background()
+	start := time.Date(2031, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	_, err := acquireLock(ctx, repository, start)
+	require.NoError(t, err)
+
+	_, err = Run(ctx, db, start.Add(lockTimeout-time.Second), zap.NewNop())
+	require.ErrorIs(t, err, ErrAlreadyRunning)
+
+	// A lock left behind by a crashed run has expired by the run's clock
+	_, err = Run(ctx, db, start.Add(lockTimeout+time.Second), zap.NewNop())
+	assert.NoError(t, err)
+}
+
+func TestAcquireLock(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	repository := repo.NewRepository(db)
+	ctx := context.Background()
+	start := time.Date(2031, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	token, err := acquireLock(ctx, repository, start)
+	require.NoError(t, err)
+
+	_, err = acquireLock(ctx, repository, start.Add(lockTimeout-time.Second))
+	assert.ErrorIs(t, err, ErrAlreadyRunning)
+
+	// A lock left behind by a crashed run expires
+	stolen, err := acquireLock(ctx, repository, start.Add(lockTimeout+time.Second))
+	require.NoError(t, err)
+
+	// Releasing the expired lock leaves the new holder's in place
+	require.NoError(t, releaseLock(ctx, repository, token))
+	setting, err := repository.GetSetting(ctx, lockSetting)
+	require.NoError(t, err)
+	assert.Equal(t, stolen, setting.Value)
+
+	require.NoError(t, releaseLock(ctx, repository, stolen))
+	_, err = acquireLock(ctx, repository, start.Add(lockTimeout+2*time.Second))
+	assert.NoError(t, err)
+}
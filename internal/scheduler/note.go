@@ -0,0 +1,41 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+)
+
+// noteTemplateSetting is the setting holding the template for the notes of
+// generated transactions, for example "{description} ({date})"
+const noteTemplateSetting = "recurring_note_template"
+
+// loadNoteTemplate returns the note template setting, or "" when it is not set
+func loadNoteTemplate(ctx context.Context, r repo.Repository) (string, error) {
+	setting, err := r.GetSetting(ctx, noteTemplateSetting)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return setting.Value, nil
+}
+
+// renderNote returns the note for the transaction rule generates on date.
+// {description} in template is replaced by the rule's description and {date}
+// by the date as YYYY-MM-DD. An empty template keeps the plain description.
+func renderNote(template string, rule repo.Recurring, date time.Time) sql.NullString {
+	if template == "" {
+		return rule.Description
+	}
+	replacer := strings.NewReplacer(
+		"{description}", rule.Description.String,
+		"{date}", date.Format("2006-01-02"),
+	)
+	return sql.NullString{String: replacer.Replace(template), Valid: true}
+}
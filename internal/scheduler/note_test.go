@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+)
+
+func TestRenderNote(t *testing.T) {
+	date := time.Date(2031, 6, 1, 0, 0, 0, 0, time.UTC)
+	rule := repo.Recurring{Description: sql.NullString{String: "Rent", Valid: true}}
+
+	assert.Equal(t, rule.Description, renderNote("", rule, date))
+	assert.Equal(t, sql.NullString{String: "Rent (2031-06-01)", Valid: true}, renderNote("{description} ({date})", rule, date))
+	assert.Equal(t, sql.NullString{String: "2031-06-01", Valid: true}, renderNote("{date}", repo.Recurring{}, date))
+	assert.Equal(t, sql.NullString{}, renderNote("", repo.Recurring{}, date))
+}
+
+func TestRunRendersNoteTemplate(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	repository := repo.NewRepository(db)
+	ctx := context.Background()
+	userID := createTestUser(t, repository)
+
+	first := time.Date(2031, 6, 1, 0, 0, 0, 0, time.UTC)
+	plain := createRecurringRule(t, repository, userID, first, "monthly", 1, -1000)
+
+	// Without the setting the description is copied verbatim
+	_, err := Run(ctx, db, first, zap.NewNop())
+	require.NoError(t, err)
+
+	_, err = repository.CreateSetting(ctx, repo.CreateSettingParams{
+		Key:   noteTemplateSetting,
+		Value: "{description} ({date})",
+	})
+	require.NoError(t, err)
+
+	second := time.Date(2031, 7, 1, 0, 0, 0, 0, time.UTC)
+	_, err = Run(ctx, db, second, zap.NewNop())
+	require.NoError(t, err)
+
+	transactions, err := repository.GetTransactionsByRecurringID(ctx, sql.NullInt64{Int64: plain.ID, Valid: true})
+	require.NoError(t, err)
+	notes := make(map[time.Time]string, len(transactions))
+	for _, txn := range transactions {
+		notes[txn.TDate] = txn.Note.String
+	}
+	require.Len(t, notes, 2)
+	assert.Equal(t, "Test recurring rule", notes[first])
+	assert.Equal(t, "Test recurring rule (2031-07-01)", notes[second])
+	assert.Contains(t, notes[second], "2031-07-01")
+}
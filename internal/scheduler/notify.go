@@ -0,0 +1,121 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// webhookTimeout bounds a webhook delivery so a slow receiver cannot hold up
+// the scheduler run that triggered it
+const webhookTimeout = 5 * time.Second
+
+// Notifier is told about each successful scheduler run and any tag alerts
+// that fire
+type Notifier interface {
+	Notify(ctx context.Context, summary Summary) error
+	AlertNotifier
+}
+
+// WebhookPayload is the JSON body posted to the webhook. Text is a one-line
+// summary so Slack-compatible incoming webhooks can display it as is.
+type WebhookPayload struct {
+	Event     string `json:"event"`
+	Date      string `json:"date"`
+	Processed int    `json:"processed"`
+	Created   int    `json:"created"`
+	Purged    int64  `json:"purged"`
+	Text      string `json:"text"`
+}
+
+// TagAlertPayload is the JSON body posted to the webhook when a tag alert fires
+type TagAlertPayload struct {
+	Event          string `json:"event"`
+	TagID          int64  `json:"tag_id"`
+	TagName        string `json:"tag_name"`
+	YearMonth      string `json:"year_month"`
+	SpendPence     int64  `json:"spend_pence"`
+	ThresholdPence int64  `json:"threshold_pence"`
+	Text           string `json:"text"`
+}
+
+// WebhookNotifier posts scheduler run summaries and tag alerts as JSON to a URL
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a WebhookNotifier posting to url
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// NotifierFromEnv returns a WebhookNotifier for env variable WEBHOOK_URL, or
+// nil when it is unset
+func NotifierFromEnv() Notifier {
+	url := os.Getenv("WEBHOOK_URL")
+	if url == "" {
+		return nil
+	}
+	return NewWebhookNotifier(url)
+}
+
+// Notify posts the summary, failing on any non-2xx response
+func (n *WebhookNotifier) Notify(ctx context.Context, summary Summary) error {
+	date := summary.Date.Format("2006-01-02")
+	return n.post(ctx, WebhookPayload{
+		Event:     "scheduler.run",
+		Date:      date,
+		Processed: summary.Processed,
+		Created:   summary.Created,
+		Purged:    summary.Purged,
+		Text: fmt.Sprintf("Scheduler run for %s: %d transactions created, %d rules processed, %d purged",
+			date, summary.Created, summary.Processed, summary.Purged),
+	})
+}
+
+// NotifyTagAlert posts the alert, failing on any non-2xx response
+func (n *WebhookNotifier) NotifyTagAlert(ctx context.Context, event TagAlertEvent) error {
+	return n.post(ctx, TagAlertPayload{
+		Event:          "tag_alert",
+		TagID:          event.TagID,
+		TagName:        event.TagName,
+		YearMonth:      event.YearMonth,
+		SpendPence:     event.SpendPence,
+		ThresholdPence: event.ThresholdPence,
+		Text: fmt.Sprintf("Spending on %s in %s has reached %d pence, over the %d pence alert",
+			event.TagName, event.YearMonth, event.SpendPence, event.ThresholdPence),
+	})
+}
+
+// post sends payload as JSON to the webhook URL
+func (n *WebhookNotifier) post(ctx context.Context, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	var received WebhookPayload
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		contentType = r.Header.Get("Content-Type")
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL)
+	err := notifier.Notify(context.Background(), Summary{
+		Date:      time.Date(2031, 3, 1, 0, 0, 0, 0, time.UTC),
+		Processed: 3,
+		Created:   2,
+		Purged:    5,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "application/json", contentType)
+	assert.Equal(t, WebhookPayload{
+		Event:     "scheduler.run",
+		Date:      "2031-03-01",
+		Processed: 3,
+		Created:   2,
+		Purged:    5,
+		Text:      "Scheduler run for 2031-03-01: 2 transactions created, 3 rules processed, 5 purged",
+	}, received)
+}
+
+func TestWebhookNotifierErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	err := NewWebhookNotifier(server.URL).Notify(context.Background(), Summary{})
+	assert.EqualError(t, err, "webhook returned status 500")
+}
+
+func TestNotifierFromEnv(t *testing.T) {
+	t.Setenv("WEBHOOK_URL", "")
+	assert.Nil(t, NotifierFromEnv())
+
+	t.Setenv("WEBHOOK_URL", "https://hooks.example.com/budget")
+	assert.NotNil(t, NotifierFromEnv())
+}
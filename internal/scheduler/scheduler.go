@@ -6,111 +6,166 @@ import (
 	"time"
 
 	"github.com/piotrzalecki/budget-api/internal/repo"
+	"github.com/piotrzalecki/budget-api/pkg/model"
 	"go.uber.org/zap"
 )
 
+// Summary describes what a scheduler run did
+type Summary struct {
+	Date      time.Time
+	Processed int   // rules materialized or deactivated
+	Created   int   // transactions created
+	Purged    int64 // soft-deleted transactions removed
+}
+
 // RunScheduler implements the scheduler logic from the specification
 // It materializes recurring rules, purges soft-deleted transactions, and optionally performs backup
-func RunScheduler(ctx context.Context, db *sql.DB, today time.Time, logger *zap.Logger) (int, error) {
+func RunScheduler(ctx context.Context, db *sql.DB, now time.Time, logger *zap.Logger) (int, error) {
+	summary, err := Run(ctx, db, now, logger)
+	return summary.Processed, err
+}
+
+// Run is RunScheduler, reporting everything the run did. Each user's rules
+// are evaluated against the date it is at now in that user's timezone.
+// It fails with ErrAlreadyRunning while another run is in progress.
+func Run(ctx context.Context, db *sql.DB, now time.Time, logger *zap.Logger) (Summary, error) {
 	// Create repository instance
 	repository := repo.NewRepository(db)
 	
+	// Only one run may materialize rules at a time
+	token, err := acquireLock(ctx, repository, now)
+	if err != nil {
+		return Summary{}, err
+	}
+	defer func() {
+		if err := releaseLock(context.WithoutCancel(ctx), repository, token); err != nil {
+			logger.Error("failed to release scheduler lock", zap.Error(err))
+		}
+	}()
+	
 	// Use transaction to ensure atomicity
+	today := LocalDate(now, time.UTC)
+	summary := Summary{Date: today}
 	var processed int
-	err := repository.WithTx(ctx, func(txRepo repo.Repository) error {
-		// Get rules due on or before today
-		rules, err := txRepo.GetRecurringDueOnDate(ctx, today)
+	err = repository.WithTx(ctx, func(txRepo repo.Repository) error {
+		users, err := txRepo.ListUsers(ctx)
+		if err != nil {
+			return err
+		}
+		
+		// Work out each user's local date, fetching rules up to the latest one
+		userToday := make(map[int64]time.Time, len(users))
+		latest := today
+		for _, user := range users {
+			loc, err := time.LoadLocation(user.Timezone)
+			if err != nil {
+				logger.Warn("invalid user timezone, using UTC", zap.Int64("user_id", user.ID), zap.String("timezone", user.Timezone))
+				loc = time.UTC
+			}
+			userToday[user.ID] = LocalDate(now, loc)
+			if userToday[user.ID].After(latest) {
+				latest = userToday[user.ID]
+			}
+		}
+		
+		// Generated notes follow the template setting, when there is one
+		noteTemplate, err := loadNoteTemplate(ctx, txRepo)
+		if err != nil {
+			return err
+		}
+		
+		// A rule that has fallen far behind is caught up over several runs
+		maxCatchUp, err := loadMaxCatchUp(ctx, txRepo, logger)
+		if err != nil {
+			return err
+		}
+		
+		// Get rules due on or before the latest local date
+		rules, err := txRepo.GetRecurringDueOnDate(ctx, latest)
 		if err != nil {
 			return err
 		}
 		
 		// Process each due rule
 		for _, rule := range rules {
-			// Check if rule has ended
-			if rule.EndDate.Valid && rule.EndDate.Time.Before(today) {
-				// Rule has ended, deactivate it
-				err := txRepo.ToggleRecurringActive(ctx, rule.ID)
-				if err != nil {
-					return err
-				}
-				processed++ // Count as processed (deactivated)
+			localToday, ok := userToday[rule.UserID]
+			if !ok {
+				// The rule outlived its user (foreign keys not enforced).
+				// Creating its transaction would orphan it or fail the run.
+				logger.Warn("skipping recurring rule of deleted user", zap.Int64("recurring_id", rule.ID), zap.Int64("user_id", rule.UserID))
+				continue
+			}
+			
+			// Skip rules that aren't due yet in the user's timezone
+			if rule.NextDueDate.After(localToday) {
 				continue
 			}
 			
-			// Check if transaction already exists for this rule and date
+			// Collect the dates this rule already has a transaction for
 			existingTransactions, err := txRepo.GetTransactionsByRecurringID(ctx, sql.NullInt64{Int64: rule.ID, Valid: true})
 			if err != nil {
 				return err
 			}
-			
-			// Check if a transaction already exists for this due date
-			transactionExists := false
+			existing := make(map[int64]bool, len(existingTransactions))
 			for _, tx := range existingTransactions {
-				if tx.TDate.Equal(rule.NextDueDate) {
-					transactionExists = true
-					break
-				}
-			}
-			
-			// Skip if transaction already exists
-			if transactionExists {
-				continue // Don't count as processed (skipped)
+				existing[tx.TDate.Unix()] = true
 			}
 			
-			// Create transaction from recurring rule
-			transactionParams := repo.CreateTransactionParams{
-				UserID:          rule.UserID,
-				AmountPence:     rule.AmountPence,
-				TDate:           rule.NextDueDate,
-				Note:            rule.Description,
-				SourceRecurring: sql.NullInt64{Int64: rule.ID, Valid: true},
-			}
-			
-			_, err = txRepo.CreateTransaction(ctx, transactionParams)
-			if err != nil {
-				return err
-			}
-			
-			// Copy tags from recurring rule to transaction
+			// Tags are copied from the recurring rule to every transaction
 			tags, err := txRepo.GetRecurringTags(ctx, rule.ID)
 			if err != nil {
 				return err
 			}
 			
-			// Get the transaction we just created to get its ID
-			// We'll need to get it by the recurring source and date
-			transactions, err := txRepo.GetTransactionsByRecurringID(ctx, sql.NullInt64{Int64: rule.ID, Valid: true})
-			if err != nil {
-				return err
-			}
-			
-			// Find the transaction we just created (should be the most recent one)
-			var transactionID int64
-			for _, tx := range transactions {
-				if tx.TDate.Equal(rule.NextDueDate) {
-					transactionID = tx.ID
+			// Catch up on every occurrence due by the user's local date, up to
+			// the cap, skipping dates that already have a transaction
+			dueDate := rule.NextDueDate
+			created := 0
+			capped := false
+			for !dueDate.After(localToday) {
+				if rule.EndDate.Valid && dueDate.After(rule.EndDate.Time) {
 					break
 				}
-			}
-			
-			// Add tags to the transaction
-			for _, tag := range tags {
-				tagParams := repo.CreateTransactionTagParams{
-					TransactionID: transactionID,
-					TagID:         tag.ID,
+				if created == maxCatchUp {
+					// Leave the rest for the next run rather than flooding the ledger
+					logger.Warn("recurring catch-up capped",
+						zap.Int64("recurring_id", rule.ID),
+						zap.Int64("user_id", rule.UserID),
+						zap.Int("max_occurrences", maxCatchUp),
+						zap.Time("next_due_date", dueDate))
+					capped = true
+					break
 				}
-				err := txRepo.CreateTransactionTag(ctx, tagParams)
-				if err != nil {
-					return err
+				
+				if !existing[dueDate.Unix()] {
+					err := createOccurrence(ctx, txRepo, rule, dueDate, renderNote(noteTemplate, rule, dueDate), tags)
+					if err != nil {
+						return err
+					}
+					created++
+				}
+				
+				nextDueDate := Advance(model.Frequency(rule.Frequency), dueDate, int(rule.IntervalN))
+				if !nextDueDate.After(dueDate) {
+					// The frequency or interval never moves the date forward
+					break
 				}
+				dueDate = nextDueDate
 			}
 			
-			// Calculate next due date
-			nextDueDate := calculateNextDueDate(rule, today)
+			// The rule has ended once its next occurrence falls after the end
+			// date. A capped rule still has occurrences left to catch up on.
+			ended := !capped && rule.EndDate.Valid && dueDate.After(rule.EndDate.Time)
+			
+			// Nothing to do when every due date already had a transaction
+			// and the rule could not move forward
+			if created == 0 && !ended && dueDate.Equal(rule.NextDueDate) {
+				continue // Don't count as processed (skipped)
+			}
 			
-			// Update recurring rule with new next due date
+			// Update recurring rule with the first occurrence not materialized
 			updateParams := repo.UpdateRecurringNextDueParams{
-				NextDueDate: nextDueDate,
+				NextDueDate: dueDate,
 				ID:          rule.ID,
 			}
 			err = txRepo.UpdateRecurringNextDue(ctx, updateParams)
@@ -118,89 +173,117 @@ func RunScheduler(ctx context.Context, db *sql.DB, today time.Time, logger *zap.
 				return err
 			}
 			
-			processed++ // Count as processed (transaction created)
+			if ended {
+				// Rule has ended, deactivate it
+				err := txRepo.ToggleRecurringActive(ctx, rule.ID)
+				if err != nil {
+					return err
+				}
+			}
+			
+			if created > 0 || ended {
+				processed++ // Count as processed (transactions created or deactivated)
+				summary.Created += created
+			}
 		}
 		
-		// Purge soft-deleted transactions older than 30 days
+		// Purge soft-deleted transactions older than 30 days, one user at a time
 		cutoffDate := today.AddDate(0, 0, -30)
-		purgeParams := sql.NullTime{Time: cutoffDate, Valid: true}
-		err = txRepo.PurgeSoftDeletedTransactions(ctx, purgeParams)
-		if err != nil {
-			return err
+		for _, user := range users {
+			purgeParams := repo.PurgeSoftDeletedTransactionsParams{
+				UserID:    user.ID,
+				DeletedAt: sql.NullTime{Time: cutoffDate, Valid: true},
+			}
+			if err := txRepo.PurgeSoftDeletedTransactionTags(ctx, repo.PurgeSoftDeletedTransactionTagsParams{
+				UserID:    purgeParams.UserID,
+				DeletedAt: purgeParams.DeletedAt,
+			}); err != nil {
+				return err
+			}
+			purged, err := txRepo.PurgeSoftDeletedTransactions(ctx, purgeParams)
+			if err != nil {
+				return err
+			}
+			summary.Purged += purged
 		}
 		
 		return nil
 	})
 	
 	if err != nil {
-		return 0, err
+		return Summary{}, err
 	}
+	summary.Processed = processed
 	
 	// Log the scheduler run
 	logger.Info("scheduler", zap.Int("processed", processed))
 	
-	return processed, nil
+	return summary, nil
 }
 
-// calculateNextDueDate calculates the next due date based on the recurring rule
-// It properly handles month-end edge cases like February 28th/29th
-func calculateNextDueDate(rule repo.Recurring, today time.Time) time.Time {
-	nextDue := rule.NextDueDate
-	
-	switch rule.Frequency {
-	case "daily":
-		nextDue = nextDue.AddDate(0, 0, int(rule.IntervalN))
-	case "weekly":
-		nextDue = nextDue.AddDate(0, 0, 7*int(rule.IntervalN))
-	case "monthly":
-		nextDue = addMonths(nextDue, int(rule.IntervalN))
-	case "yearly":
-		nextDue = addYears(nextDue, int(rule.IntervalN))
+// createOccurrence creates the transaction rule generates on date, tagged
+// with the rule's tags
+func createOccurrence(ctx context.Context, txRepo repo.Repository, rule repo.Recurring, date time.Time, note sql.NullString, tags []repo.Tag) error {
+	transaction, err := txRepo.CreateTransaction(ctx, repo.CreateTransactionParams{
+		UserID:          rule.UserID,
+		AmountPence:     rule.AmountPence,
+		TDate:           date,
+		Note:            note,
+		SourceRecurring: sql.NullInt64{Int64: rule.ID, Valid: true},
+	})
+	if err != nil {
+		return err
 	}
 	
-	return nextDue
+	for _, tag := range tags {
+		tagParams := repo.CreateTransactionTagParams{
+			TransactionID: transaction.ID,
+			TagID:         tag.ID,
+		}
+		if err := txRepo.CreateTransactionTag(ctx, tagParams); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// addMonths adds the specified number of months to a date, handling month-end edge cases
-func addMonths(date time.Time, months int) time.Time {
-	year, month, day := date.Date()
-	
-	// Calculate new year and month
-	newYear := year + (int(month)-1+months)/12
-	newMonth := time.Month((int(month)-1+months)%12 + 1)
-	
-	// Handle month-end edge cases
-	// If the original day is the last day of the month, keep it as the last day
-	// Otherwise, try to use the same day, but clamp to the last day of the target month
-	
-	// Get the last day of the target month
-	lastDayOfTargetMonth := time.Date(newYear, newMonth+1, 1, 0, 0, 0, 0, date.Location()).AddDate(0, 0, -1).Day()
-	
-	// If original day is greater than the last day of target month, use the last day
-	if day > lastDayOfTargetMonth {
-		day = lastDayOfTargetMonth
-	}
-	
-	return time.Date(newYear, newMonth, day, date.Hour(), date.Minute(), date.Second(), date.Nanosecond(), date.Location())
+// LocalDate returns the calendar date it is at now in loc, as midnight UTC
+// so it compares directly with stored due dates
+func LocalDate(now time.Time, loc *time.Location) time.Time {
+	year, month, day := now.In(loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
 }
 
-// addYears adds the specified number of years to a date, handling leap year edge cases
-func addYears(date time.Time, years int) time.Time {
-	year, month, day := date.Date()
-	newYear := year + years
-	
-	// Handle February 29th in leap years
-	if month == time.February && day == 29 {
-		// Check if the target year is a leap year
-		targetDate := time.Date(newYear, time.February, 29, 0, 0, 0, 0, date.Location())
-		if targetDate.Month() == time.February && targetDate.Day() == 29 {
-			// Target year is a leap year, February 29th is valid
-			return time.Date(newYear, month, day, date.Hour(), date.Minute(), date.Second(), date.Nanosecond(), date.Location())
-		} else {
-			// Target year is not a leap year, use February 28th
-			return time.Date(newYear, month, 28, date.Hour(), date.Minute(), date.Second(), date.Nanosecond(), date.Location())
+// NextDueDate returns the occurrence that follows rule.NextDueDate, advancing
+// the date exactly as the scheduler does after materializing a transaction
+func NextDueDate(rule repo.Recurring) time.Time {
+	return calculateNextDueDate(rule, rule.NextDueDate)
+}
+
+// NextDueAfter returns the first occurrence of rule strictly after date,
+// stepping from rule.NextDueDate. It returns false when the rule's frequency
+// or interval would never move the date forward.
+func NextDueAfter(rule repo.Recurring, date time.Time) (time.Time, bool) {
+	next := rule.NextDueDate
+	for !next.After(date) {
+		advanced := Advance(model.Frequency(rule.Frequency), next, int(rule.IntervalN))
+		if !advanced.After(next) {
+			return rule.NextDueDate, false
 		}
+		next = advanced
 	}
-	
-	return time.Date(newYear, month, day, date.Hour(), date.Minute(), date.Second(), date.Nanosecond(), date.Location())
-} 
\ No newline at end of file
+	return next, true
+}
+
+// calculateNextDueDate calculates the next due date based on the recurring rule
+// It properly handles month-end edge cases like February 28th/29th
+func calculateNextDueDate(rule repo.Recurring, today time.Time) time.Time {
+	return Advance(model.Frequency(rule.Frequency), rule.NextDueDate, int(rule.IntervalN))
+}
+
+// Advance returns the date n intervals of frequency after date, clamping to
+// the end of shorter months and to 28 February outside leap years. Unknown
+// frequencies leave the date unchanged.
+func Advance(frequency model.Frequency, date time.Time, n int) time.Time {
+	return frequency.Advance(date, n)
+}
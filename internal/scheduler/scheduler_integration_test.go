@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/piotrzalecki/budget-api/internal/repo"
 )
@@ -390,7 +391,7 @@ func TestSchedulerIntegration_RuleEndDate(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 1, processed) // Rule was processed (found as due)
 	
-	// Verify no transaction was created (rule was ended, not materialized)
+	// Verify the occurrence on the end date was still materialized
 	transactions, err := repository.ListTransactions(context.Background(), repo.ListTransactionsParams{
 		UserID:  userID,
 		TDate:   yesterday,
@@ -399,7 +400,7 @@ func TestSchedulerIntegration_RuleEndDate(t *testing.T) {
 		Column5: nil,
 	})
 	require.NoError(t, err)
-	assert.Len(t, transactions, 0, "No transaction should be created for ended rules")
+	assert.Len(t, transactions, 1, "The occurrence on the end date should be created")
 	
 	// Verify rule was deactivated
 	updatedRule, err := repository.GetRecurringByID(context.Background(), rule.ID)
@@ -491,4 +492,44 @@ func TestSchedulerIntegration_PurgeSoftDeleted(t *testing.T) {
 	_, err = repository.GetTransactionByID(context.Background(), txn.ID)
 	require.Error(t, err, "Transaction should have been purged")
 	require.Equal(t, sql.ErrNoRows, err, "Expected no rows error after purging")
-} 
\ No newline at end of file
+}
+
+func TestSchedulerSkipsRulesOfDeletedUsers(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	repository := repo.NewRepository(db)
+	ctx := context.Background()
+
+	userID := createTestUser(t, repository)
+	gone, err := repository.CreateUser(ctx, repo.CreateUserParams{
+		Email:  "gone@example.com",
+		PwHash: "hashedpassword",
+	})
+	require.NoError(t, err)
+
+	dueDate := time.Date(2031, 6, 1, 0, 0, 0, 0, time.UTC)
+	liveRule := createRecurringRule(t, repository, userID, dueDate, "monthly", 1, -1000)
+	danglingRule := createRecurringRule(t, repository, gone.ID, dueDate, "monthly", 1, -2000)
+
+	// Without foreign keys enforced the user's rule outlives it
+	require.NoError(t, repository.DeleteUser(ctx, gone.ID))
+
+	core, logs := observer.New(zap.WarnLevel)
+	_, err = Run(ctx, db, dueDate, zap.New(core))
+	require.NoError(t, err)
+
+	// The live rule still runs
+	assertTransactionExists(t, repository, userID, -1000, dueDate, liveRule.ID)
+
+	// The dangling one is skipped and left as it was
+	transactions, err := repository.GetTransactionsByRecurringID(ctx, sql.NullInt64{Int64: danglingRule.ID, Valid: true})
+	require.NoError(t, err)
+	assert.Empty(t, transactions)
+	assertRecurringNextDueDate(t, repository, danglingRule.ID, dueDate)
+
+	skipped := logs.FilterMessage("skipping recurring rule of deleted user").All()
+	require.Len(t, skipped, 1)
+	assert.Equal(t, danglingRule.ID, skipped[0].ContextMap()["recurring_id"])
+}
@@ -0,0 +1,78 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piotrzalecki/budget-api/internal/repo"
+)
+
+func TestLocalDate(t *testing.T) {
+	utcPlus12, err := time.LoadLocation("Etc/GMT-12")
+	require.NoError(t, err)
+
+	now := time.Date(2031, 6, 14, 12, 30, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2031, 6, 14, 0, 0, 0, 0, time.UTC), LocalDate(now, time.UTC))
+	assert.Equal(t, time.Date(2031, 6, 15, 0, 0, 0, 0, time.UTC), LocalDate(now, utcPlus12))
+}
+
+func TestRunUsesUserLocalDate(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	repository := repo.NewRepository(db)
+	ctx := context.Background()
+
+	utcUserID := createTestUser(t, repository)
+	eastUser, err := repository.CreateUser(ctx, repo.CreateUserParams{
+		Email:  "east@example.com",
+		PwHash: "hashedpassword",
+	})
+	require.NoError(t, err)
+	eastUser, err = repository.UpdateUser(ctx, repo.UpdateUserParams{
+		ID:       eastUser.ID,
+		Email:    eastUser.Email,
+		PwHash:   eastUser.PwHash,
+		Timezone: "Etc/GMT-12", // UTC+12
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Etc/GMT-12", eastUser.Timezone)
+
+	dueDate := time.Date(2031, 6, 15, 0, 0, 0, 0, time.UTC)
+	utcRule := createRecurringRule(t, repository, utcUserID, dueDate, "monthly", 1, -1000)
+	eastRule := createRecurringRule(t, repository, eastUser.ID, dueDate, "monthly", 1, -2000)
+
+	// 12:30 UTC on the 14th is already 00:30 on the 15th at UTC+12
+	now := time.Date(2031, 6, 14, 12, 30, 0, 0, time.UTC)
+	summary, err := Run(ctx, db, now, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2031, 6, 14, 0, 0, 0, 0, time.UTC), summary.Date)
+
+	eastTransactions, err := repository.GetTransactionsByRecurringID(ctx, sql.NullInt64{Int64: eastRule.ID, Valid: true})
+	require.NoError(t, err)
+	require.Len(t, eastTransactions, 1)
+	assert.True(t, eastTransactions[0].TDate.Equal(dueDate))
+
+	utcTransactions, err := repository.GetTransactionsByRecurringID(ctx, sql.NullInt64{Int64: utcRule.ID, Valid: true})
+	require.NoError(t, err)
+	assert.Empty(t, utcTransactions)
+
+	updatedEast, err := repository.GetRecurringByID(ctx, eastRule.ID)
+	require.NoError(t, err)
+	assert.True(t, updatedEast.NextDueDate.Equal(time.Date(2031, 7, 15, 0, 0, 0, 0, time.UTC)))
+
+	// Once the UTC day rolls over the UTC user's rule is due as well
+	_, err = Run(ctx, db, now.Add(12*time.Hour), zap.NewNop())
+	require.NoError(t, err)
+
+	utcTransactions, err = repository.GetTransactionsByRecurringID(ctx, sql.NullInt64{Int64: utcRule.ID, Valid: true})
+	require.NoError(t, err)
+	assert.Len(t, utcTransactions, 1)
+}
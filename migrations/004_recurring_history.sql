@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- pause/resume/skip actions taken on a recurring rule
+CREATE TABLE recurring_history (
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    recurring_id INTEGER NOT NULL REFERENCES recurring(id) ON DELETE CASCADE,
+    action       TEXT NOT NULL CHECK (action IN ('paused', 'resumed', 'skipped')),
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_recurring_history_recurring ON recurring_history(recurring_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE recurring_history;
+
+-- +goose StatementEnd
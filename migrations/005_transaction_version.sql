@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- incremented on every update so clients can detect concurrent edits
+ALTER TABLE transactions ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE transactions DROP COLUMN version;
+
+-- +goose StatementEnd
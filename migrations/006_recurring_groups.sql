@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- optional user-defined group for organising rules, e.g. "Streaming"
+ALTER TABLE recurring ADD COLUMN group_name TEXT;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE recurring DROP COLUMN group_name;
+
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- monthly spending threshold per tag; last_notified_ym (YYYY-MM) stops an
+-- alert firing more than once a month
+CREATE TABLE tag_alerts (
+    id               INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id          INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tag_id           INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    threshold_pence  INTEGER NOT NULL CHECK (threshold_pence > 0),
+    last_notified_ym TEXT,
+    created_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(user_id, tag_id)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE tag_alerts;
+
+-- +goose StatementEnd
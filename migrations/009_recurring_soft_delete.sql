@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- soft-deleted rules are hidden but kept so generated transactions still
+-- resolve their source_recurring
+ALTER TABLE recurring ADD COLUMN deleted_at TIMESTAMP;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DELETE FROM recurring WHERE deleted_at IS NOT NULL;
+ALTER TABLE recurring DROP COLUMN deleted_at;
+
+-- +goose StatementEnd
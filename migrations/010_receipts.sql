@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- receipt references attached to a transaction; the file itself is stored
+-- elsewhere and only its URL and metadata are kept here
+CREATE TABLE receipts (
+    id             INTEGER PRIMARY KEY AUTOINCREMENT,
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    url            TEXT NOT NULL,
+    content_type   TEXT,
+    uploaded_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_receipts_transaction_id ON receipts(transaction_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE receipts;
+
+-- +goose StatementEnd
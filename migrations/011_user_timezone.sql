@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- IANA timezone name used to decide which local day it is for the user
+ALTER TABLE users ADD COLUMN timezone TEXT NOT NULL DEFAULT 'UTC';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE users DROP COLUMN timezone;
+
+-- +goose StatementEnd
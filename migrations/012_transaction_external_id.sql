@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- reference from an external source (e.g. a bank export) used to skip
+-- transactions that were already imported
+ALTER TABLE transactions ADD COLUMN external_id TEXT;
+
+CREATE UNIQUE INDEX idx_transactions_user_external_id ON transactions(user_id, external_id)
+WHERE external_id IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX idx_transactions_user_external_id;
+ALTER TABLE transactions DROP COLUMN external_id;
+
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- set once a transaction has been matched against a bank statement
+ALTER TABLE transactions ADD COLUMN cleared BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE transactions DROP COLUMN cleared;
+
+-- +goose StatementEnd
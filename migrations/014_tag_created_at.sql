@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- when each tag was created; SQLite can't add a column defaulting to
+-- CURRENT_TIMESTAMP, so existing tags are stamped with the migration time and
+-- tags inserted without one are stamped by the trigger
+ALTER TABLE tags ADD COLUMN created_at TIMESTAMP;
+UPDATE tags SET created_at = CURRENT_TIMESTAMP;
+
+CREATE TRIGGER tags_created_at AFTER INSERT ON tags
+WHEN NEW.created_at IS NULL
+BEGIN
+    UPDATE tags SET created_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER tags_created_at;
+ALTER TABLE tags DROP COLUMN created_at;
+
+-- +goose StatementEnd
@@ -6,17 +6,21 @@ import (
 
 // CreateTransactionRequest represents the request body for creating a transaction
 type CreateTransactionRequest struct {
-	Amount  string  `json:"amount" validate:"required,currency"`
+	Amount  string  `json:"amount" validate:"required,currency,nonzero_amount"`
 	TDate   string  `json:"t_date" validate:"required,date"`
-	Note    *string `json:"note,omitempty"`
-	TagIDs  []int64 `json:"tag_ids,omitempty"`
+	Note    *string `json:"note,omitempty" validate:"omitempty,max=500,nocontrol"`
+	TagIDs  []int64 `json:"tag_ids,omitempty" validate:"omitempty,dive,gt=0"`
+	ExternalID *string `json:"external_id,omitempty" validate:"omitempty,max=255,nocontrol"`
 }
 
 // UpdateTransactionRequest represents the request body for updating a transaction
 type UpdateTransactionRequest struct {
 	Deleted *bool   `json:"deleted,omitempty"`
-	Note    *string `json:"note,omitempty"`
-	TagIDs  []int64 `json:"tag_ids,omitempty"`
+	Note    *string `json:"note,omitempty" validate:"omitempty,max=500,nocontrol"`
+	TagIDs  []int64 `json:"tag_ids,omitempty" validate:"omitempty,dive,gt=0"`
+	Cleared *bool   `json:"cleared,omitempty"`
+	// Version, when set, must match the stored version or the update is rejected with 409
+	Version *int64 `json:"version,omitempty" validate:"omitempty,min=1"`
 }
 
 // CreateTagRequest represents the request body for creating a tag
@@ -29,15 +33,75 @@ type UpdateTagRequest struct {
 	Name string `json:"name" validate:"required,min=1,max=100"`
 }
 
+// CreateReceiptRequest represents a receipt reference attached to a transaction
+type CreateReceiptRequest struct {
+	URL         string  `json:"url" validate:"required,url,max=2048"`
+	ContentType *string `json:"content_type,omitempty" validate:"omitempty,max=100"`
+}
+
+// ReceiptResponse represents a transaction's receipt reference in API responses
+type ReceiptResponse struct {
+	ID            int64     `json:"id"`
+	TransactionID int64     `json:"transaction_id"`
+	URL           string    `json:"url"`
+	ContentType   *string   `json:"content_type,omitempty"`
+	UploadedAt    time.Time `json:"uploaded_at"`
+}
+
+// CreateTagAlertRequest represents the request body for creating a tag spending alert
+type CreateTagAlertRequest struct {
+	TagID     int64  `json:"tag_id" validate:"required,min=1"`
+	Threshold string `json:"threshold" validate:"required,currency"`
+}
+
+// UpdateTagAlertRequest represents the request body for changing a tag alert's threshold
+type UpdateTagAlertRequest struct {
+	Threshold string `json:"threshold" validate:"required,currency"`
+}
+
+// TagAlertResponse represents a tag spending alert in API responses.
+// LastNotified is the month (YYYY-MM) the alert last fired.
+type TagAlertResponse struct {
+	ID             int64   `json:"id"`
+	TagID          int64   `json:"tag_id"`
+	Threshold      string  `json:"threshold"`
+	ThresholdPence int64   `json:"threshold_pence"`
+	LastNotified   *string `json:"last_notified"`
+}
+
+// TagAlertEventResponse represents a tag whose monthly spend reached its alert threshold
+type TagAlertEventResponse struct {
+	TagID          int64  `json:"tag_id"`
+	TagName        string `json:"tag_name"`
+	YearMonth      string `json:"year_month"`
+	Spend          string `json:"spend"`
+	SpendPence     int64  `json:"spend_pence"`
+	Threshold      string `json:"threshold"`
+	ThresholdPence int64  `json:"threshold_pence"`
+}
+
+// ReassignTagRequest represents the request body for moving a tag's transactions to another tag
+type ReassignTagRequest struct {
+	ToTagID int64 `json:"to_tag_id" validate:"required,min=1"`
+}
+
+// ReassignTagResponse reports how many transaction associations were moved.
+// Skipped counts transactions that already carried the target tag.
+type ReassignTagResponse struct {
+	Moved   int64 `json:"moved"`
+	Skipped int64 `json:"skipped"`
+}
+
 // CreateRecurringRequest represents the request body for creating a recurring rule
 type CreateRecurringRequest struct {
 	Amount        string   `json:"amount" validate:"required,currency"`
 	Description   string   `json:"description" validate:"required,min=1,max=255"`
-	Frequency     string   `json:"frequency" validate:"required,oneof=daily weekly monthly yearly"`
+	Frequency     string   `json:"frequency" validate:"required,frequency"`
 	IntervalN     int      `json:"interval_n" validate:"required,min=1,max=365"`
 	FirstDueDate  string   `json:"first_due_date" validate:"required,date"`
 	EndDate       *string  `json:"end_date,omitempty" validate:"omitempty,date"`
-	TagIDs        []int64  `json:"tag_ids,omitempty"`
+	TagIDs        []int64  `json:"tag_ids,omitempty" validate:"omitempty,dive,gt=0"`
+	Group         *string  `json:"group,omitempty" validate:"omitempty,max=100"`
 }
 
 // UpdateRecurringRequest represents the request body for updating a recurring rule
@@ -45,11 +109,43 @@ type UpdateRecurringRequest struct {
 	Active        *bool    `json:"active,omitempty"`
 	Amount        *string  `json:"amount,omitempty" validate:"omitempty,currency"`
 	Description   *string  `json:"description,omitempty" validate:"omitempty,min=1,max=255"`
-	Frequency     *string  `json:"frequency,omitempty" validate:"omitempty,oneof=daily weekly monthly yearly"`
+	Frequency     *string  `json:"frequency,omitempty" validate:"omitempty,frequency"`
 	IntervalN     *int     `json:"interval_n,omitempty" validate:"omitempty,min=1,max=365"`
 	FirstDueDate  *string  `json:"first_due_date,omitempty" validate:"omitempty,date"`
 	EndDate       *string  `json:"end_date,omitempty" validate:"omitempty,date"`
-	TagIDs        []int64  `json:"tag_ids,omitempty"`
+	TagIDs        []int64  `json:"tag_ids,omitempty" validate:"omitempty,dive,gt=0"`
+	Group         *string  `json:"group,omitempty" validate:"omitempty,max=100"`
+}
+
+// CloneRecurringRequest represents optional overrides applied when cloning a recurring rule
+type CloneRecurringRequest struct {
+	Amount       *string `json:"amount,omitempty" validate:"omitempty,currency"`
+	Description  *string `json:"description,omitempty" validate:"omitempty,min=1,max=255"`
+	Frequency    *string `json:"frequency,omitempty" validate:"omitempty,frequency"`
+	IntervalN    *int    `json:"interval_n,omitempty" validate:"omitempty,min=1,max=365"`
+	FirstDueDate *string `json:"first_due_date,omitempty" validate:"omitempty,date"`
+	EndDate      *string `json:"end_date,omitempty" validate:"omitempty,date"`
+	TagIDs       []int64 `json:"tag_ids,omitempty" validate:"omitempty,dive,gt=0"`
+	Group        *string `json:"group,omitempty" validate:"omitempty,max=100"`
+}
+
+// RescheduleRecurringRequest represents a new next due date for a recurring rule.
+// Without one the rule restarts from its first due date.
+type RescheduleRecurringRequest struct {
+	NextDueDate *string `json:"next_due_date,omitempty" validate:"omitempty,date"`
+}
+
+// MakeRecurringRequest represents the schedule for a recurring rule created from a transaction
+type MakeRecurringRequest struct {
+	Frequency    string  `json:"frequency" validate:"required,frequency"`
+	IntervalN    int     `json:"interval_n" validate:"required,min=1,max=365"`
+	FirstDueDate string  `json:"first_due_date" validate:"required,date"`
+	EndDate      *string `json:"end_date,omitempty" validate:"omitempty,date"`
+}
+
+// ListMeta describes the rows a list response returned
+type ListMeta struct {
+	Count int `json:"count"`
 }
 
 // TransactionResponse represents a transaction in API responses
@@ -61,41 +157,258 @@ type TransactionResponse struct {
 	CreatedAt      time.Time `json:"created_at"`
 	SourceRecurring *int64   `json:"source_recurring,omitempty"`
 	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
-	TagIDs         []int64   `json:"tag_ids,omitempty"`
+	TagIDs         []int64   `json:"tag_ids"`
+	Version        int64     `json:"version"`
+	ExternalID     *string   `json:"external_id,omitempty"`
+	Cleared        bool      `json:"cleared"`
+}
+
+// TagTransactionsGroup represents the transactions carrying one tag. TagID is
+// nil for the group of untagged transactions.
+type TagTransactionsGroup struct {
+	TagID        *int64                `json:"tag_id"`
+	TagName      string                `json:"tag_name"`
+	Transactions []TransactionResponse `json:"transactions"`
+}
+
+// DailyActivity represents a day with at least one transaction: how many and
+// their net amount
+type DailyActivity struct {
+	Date             string `json:"date"`
+	TransactionCount int64  `json:"transaction_count"`
+	Net              string `json:"net"`
+	NetPence         int64  `json:"net_pence"`
+}
+
+// ActivityResponse represents the days with transactions in a date range.
+// Days without transactions are left out.
+type ActivityResponse struct {
+	From string          `json:"from"`
+	To   string          `json:"to"`
+	Days []DailyActivity `json:"days"`
 }
 
 // TagResponse represents a tag in API responses
 type TagResponse struct {
-	ID   int64  `json:"id"`
-	Name string `json:"name"`
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 // RecurringResponse represents a recurring rule in API responses
 type RecurringResponse struct {
-	ID            int64     `json:"id"`
-	Amount        string    `json:"amount"`
-	Description   string    `json:"description"`
-	Frequency     string    `json:"frequency"`
-	IntervalN     int       `json:"interval_n"`
-	FirstDueDate  string    `json:"first_due_date"`
-	NextDueDate   string    `json:"next_due_date"`
-	EndDate       *string   `json:"end_date,omitempty"`
-	Active        bool      `json:"active"`
-	CreatedAt     time.Time `json:"created_at"`
-	TagIDs        []int64   `json:"tag_ids,omitempty"`
+	ID            int64      `json:"id"`
+	Amount        string     `json:"amount"`
+	Description   string     `json:"description"`
+	Frequency     string     `json:"frequency"`
+	IntervalN     int        `json:"interval_n"`
+	FirstDueDate  string     `json:"first_due_date"`
+	NextDueDate   string     `json:"next_due_date"`
+	EndDate       *string    `json:"end_date,omitempty"`
+	Active        bool       `json:"active"`
+	CreatedAt     time.Time  `json:"created_at"`
+	TagIDs        []int64    `json:"tag_ids"`
+	Group         *string    `json:"group,omitempty"`
+	DeletedAt     *time.Time `json:"deleted_at,omitempty"`
+}
+
+// RecurringGroupSummary represents the active rules in one recurring group.
+// Group is nil for rules that have not been assigned a group.
+type RecurringGroupSummary struct {
+	Group             *string `json:"group"`
+	Count             int     `json:"count"`
+	MonthlyTotal      string  `json:"monthly_total"`
+	MonthlyTotalPence int64   `json:"monthly_total_pence"`
+}
+
+// UpcomingOccurrence represents one projected due date of a recurring rule.
+// RunningTotal is the sum of all occurrences up to and including this one.
+type UpcomingOccurrence struct {
+	RecurringID       int64  `json:"recurring_id"`
+	Description       string `json:"description"`
+	DueDate           string `json:"due_date"`
+	Amount            string `json:"amount"`
+	AmountPence       int64  `json:"amount_pence"`
+	RunningTotal      string `json:"running_total"`
+	RunningTotalPence int64  `json:"running_total_pence"`
+}
+
+// UpcomingRecurringResponse represents the projected recurring occurrences in a window
+type UpcomingRecurringResponse struct {
+	From        string               `json:"from"`
+	To          string               `json:"to"`
+	Occurrences []UpcomingOccurrence `json:"occurrences"`
+	Total       string               `json:"total"`
+	TotalPence  int64                `json:"total_pence"`
+}
+
+// StaleRecurring represents an active recurring rule that has not generated a
+// transaction recently. LastGeneratedDate and DaysSinceLast are null when the
+// rule has never generated one.
+type StaleRecurring struct {
+	RecurringID       int64   `json:"recurring_id"`
+	Description       string  `json:"description"`
+	Amount            string  `json:"amount"`
+	AmountPence       int64   `json:"amount_pence"`
+	Frequency         string  `json:"frequency"`
+	NextDueDate       string  `json:"next_due_date"`
+	LastGeneratedDate *string `json:"last_generated_date"`
+	DaysSinceLast     *int    `json:"days_since_last"`
+}
+
+// StaleRecurringResponse represents the rules with no generated transaction
+// on or after Cutoff
+type StaleRecurringResponse struct {
+	Days   int              `json:"days"`
+	Cutoff string           `json:"cutoff"`
+	Rules  []StaleRecurring `json:"rules"`
+}
+
+// RecurringHistoryEntry represents a pause/resume/skip action on a recurring rule
+type RecurringHistoryEntry struct {
+	ID        int64     `json:"id"`
+	Action    string    `json:"action"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RecurringHistoryResponse represents a page of recurring rule history
+type RecurringHistoryResponse struct {
+	Entries []RecurringHistoryEntry `json:"entries"`
+	Total   int64                   `json:"total"`
+	Limit   int64                   `json:"limit"`
+	Offset  int64                   `json:"offset"`
+}
+
+// RecurringTransactionsResponse represents the transactions generated by a
+// recurring rule together with their count and sum
+type RecurringTransactionsResponse struct {
+	Transactions []TransactionResponse `json:"transactions"`
+	Count        int                   `json:"count"`
+	Total        string                `json:"total"`
+	TotalPence   int64                 `json:"total_pence"`
 }
 
 // MonthlyReportResponse represents the monthly report response
 type MonthlyReportResponse struct {
-	TotalIn  string                    `json:"total_in"`
-	TotalOut string                    `json:"total_out"`
-	ByTag    map[string]TagReportEntry `json:"by_tag"`
+	Currency      string                    `json:"currency"`
+	TotalIn       string                    `json:"total_in"`
+	TotalOut      string                    `json:"total_out"`
+	TotalInPence  int64                     `json:"total_in_pence"`
+	TotalOutPence int64                     `json:"total_out_pence"`
+	ByTag         map[string]TagReportEntry `json:"by_tag"`
+}
+
+// MonthlyComparisonResponse represents a month's report next to the previous
+// month's. Delta and DeltaByTag are this month minus the previous month.
+type MonthlyComparisonResponse struct {
+	Currency          string                    `json:"currency"`
+	YearMonth         string                    `json:"year_month"`
+	PreviousYearMonth string                    `json:"previous_year_month"`
+	Current           MonthlyReportResponse     `json:"current"`
+	Previous          MonthlyReportResponse     `json:"previous"`
+	Delta             TagReportEntry            `json:"delta"`
+	DeltaByTag        map[string]TagReportEntry `json:"delta_by_tag"`
+}
+
+// BalancePoint represents the running balance at the end of a day with transactions
+type BalancePoint struct {
+	Date         string `json:"date"`
+	Change       string `json:"change"`
+	ChangePence  int64  `json:"change_pence"`
+	Balance      string `json:"balance"`
+	BalancePence int64  `json:"balance_pence"`
+}
+
+// BalanceReportResponse represents the running balance series for a date range.
+// OpeningBalance is the sum of all transactions dated before From.
+type BalanceReportResponse struct {
+	From                string         `json:"from"`
+	To                  string         `json:"to"`
+	OpeningBalance      string         `json:"opening_balance"`
+	OpeningBalancePence int64          `json:"opening_balance_pence"`
+	ClosingBalance      string         `json:"closing_balance"`
+	ClosingBalancePence int64          `json:"closing_balance_pence"`
+	Series              []BalancePoint `json:"series"`
+}
+
+// PeriodNet represents a period's income, spending and net (in minus out).
+// Period labels a month as YYYY-MM, an ISO week as YYYY-Www and a day as
+// YYYY-MM-DD; Start and End are the first and last days of the period.
+type PeriodNet struct {
+	Period        string `json:"period"`
+	Start         string `json:"start"`
+	End           string `json:"end"`
+	TotalIn       string `json:"total_in"`
+	TotalOut      string `json:"total_out"`
+	Net           string `json:"net"`
+	TotalInPence  int64  `json:"total_in_pence"`
+	TotalOutPence int64  `json:"total_out_pence"`
+	NetPence      int64  `json:"net_pence"`
+}
+
+// NetByPeriodResponse represents the net per month, week or day for a range.
+// Every period in the range is listed, with zeros for periods without
+// activity.
+type NetByPeriodResponse struct {
+	Currency string      `json:"currency"`
+	GroupBy  string      `json:"group_by"`
+	From     string      `json:"from"`
+	To       string      `json:"to"`
+	Periods  []PeriodNet `json:"periods"`
+}
+
+// TopTagEntry represents a tag's outgoing spend in a month
+type TopTagEntry struct {
+	TagID            int64  `json:"tag_id"`
+	TagName          string `json:"tag_name"`
+	Spend            string `json:"spend"`
+	SpendPence       int64  `json:"spend_pence"`
+	TransactionCount int64  `json:"transaction_count"`
+}
+
+// TopTagsResponse represents the tags with the highest spend in a month, highest first
+type TopTagsResponse struct {
+	Currency  string        `json:"currency"`
+	YearMonth string        `json:"year_month"`
+	Tags      []TopTagEntry `json:"tags"`
+}
+
+// ForecastTotals represents income, spending and net (in minus out) for part
+// of a forecast
+type ForecastTotals struct {
+	TotalIn       string `json:"total_in"`
+	TotalOut      string `json:"total_out"`
+	Net           string `json:"net"`
+	TotalInPence  int64  `json:"total_in_pence"`
+	TotalOutPence int64  `json:"total_out_pence"`
+	NetPence      int64  `json:"net_pence"`
+}
+
+// ForecastResponse represents a month's end-of-month estimate: the actual
+// transactions booked so far plus the recurring occurrences still due between
+// ProjectedFrom and the end of the month
+type ForecastResponse struct {
+	Currency      string               `json:"currency"`
+	YearMonth     string               `json:"year_month"`
+	ProjectedFrom string               `json:"projected_from"`
+	Actual        ForecastTotals       `json:"actual"`
+	Projected     ForecastTotals       `json:"projected"`
+	Estimated     ForecastTotals       `json:"estimated"`
+	Occurrences   []UpcomingOccurrence `json:"occurrences"`
 }
 
 // TagReportEntry represents spending/income for a specific tag
 type TagReportEntry struct {
-	TotalIn  string `json:"total_in"`
-	TotalOut string `json:"total_out"`
+	TotalIn       string `json:"total_in"`
+	TotalOut      string `json:"total_out"`
+	TotalInPence  int64  `json:"total_in_pence"`
+	TotalOutPence int64  `json:"total_out_pence"`
+}
+
+// RepairRecurringResponse represents the result of repairing overdue next due dates
+type RepairRecurringResponse struct {
+	Checked  int `json:"checked"`
+	Repaired int `json:"repaired"`
 }
 
 // SchedulerResponse represents the scheduler run response
@@ -103,6 +416,53 @@ type SchedulerResponse struct {
 	Processed int `json:"processed"`
 }
 
+// FrequencyExample shows the next due date a frequency produces
+type FrequencyExample struct {
+	From      string `json:"from"`
+	IntervalN int    `json:"interval_n"`
+	Next      string `json:"next"`
+}
+
+// FrequencyResponse describes a supported recurring rule frequency
+type FrequencyResponse struct {
+	Name        string           `json:"name"`
+	Unit        string           `json:"unit"`
+	MaxInterval int              `json:"max_interval"`
+	Example     FrequencyExample `json:"example"`
+}
+
+// AppliedMigration represents a schema migration that has been applied
+type AppliedMigration struct {
+	Version   int64     `json:"version"`
+	AppliedAt time.Time `json:"applied_at"`
+}
+
+// MigrationStatusResponse represents the database schema version
+type MigrationStatusResponse struct {
+	Version int64              `json:"version"`
+	Applied []AppliedMigration `json:"applied"`
+}
+
+// MigrateResponse represents the result of running pending migrations.
+// Applied lists the versions applied by this run.
+type MigrateResponse struct {
+	Version int64   `json:"version"`
+	Applied []int64 `json:"applied"`
+}
+
+// DBStatsResponse represents the database connection pool statistics
+type DBStatsResponse struct {
+	MaxOpenConnections int   `json:"max_open_connections"`
+	OpenConnections    int   `json:"open_connections"`
+	InUse              int   `json:"in_use"`
+	Idle               int   `json:"idle"`
+	WaitCount          int64 `json:"wait_count"`
+	WaitDurationMs     int64 `json:"wait_duration_ms"`
+	MaxIdleClosed      int64 `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64 `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64 `json:"max_lifetime_closed"`
+}
+
 // APIResponse represents the standard API response envelope
 type APIResponse struct {
 	Data  interface{} `json:"data"`
@@ -120,6 +480,43 @@ type PurgeTransactionsRequest struct {
 	CutoffDate string `json:"cutoff_date" validate:"required,date"`
 }
 
+// ClearTransactionsRequest represents the request body for clearing all of a user's transactions
+type ClearTransactionsRequest struct {
+	Confirm bool `json:"confirm" validate:"required"`
+}
+
+// ClearTransactionsResponse represents the response for clearing all of a user's transactions
+type ClearTransactionsResponse struct {
+	Cleared int64 `json:"cleared"`
+}
+
+// ReconcileTransactionsRequest represents the request body for marking many transactions cleared or uncleared at once
+type ReconcileTransactionsRequest struct {
+	TransactionIDs []int64 `json:"transaction_ids" validate:"required,min=1,max=500,dive,gt=0"`
+	Cleared        *bool   `json:"cleared" validate:"required"`
+}
+
+// ReconcileTransactionsResponse represents the response for a bulk reconcile
+type ReconcileTransactionsResponse struct {
+	Updated int64 `json:"updated"`
+	Cleared bool  `json:"cleared"`
+}
+
+// BulkDeleteTransactionsRequest represents the request body for soft deleting many transactions at once
+type BulkDeleteTransactionsRequest struct {
+	TransactionIDs []int64 `json:"transaction_ids" validate:"required,min=1,max=500,dive,gt=0"`
+}
+
+// BulkDeleteTransactionsResponse represents the response for a bulk soft delete
+type BulkDeleteTransactionsResponse struct {
+	Deleted int64 `json:"deleted"`
+}
+
+// EmptyTrashResponse represents the response for permanently deleting all soft-deleted transactions
+type EmptyTrashResponse struct {
+	Purged int64 `json:"purged"`
+}
+
 // LoginRequest represents the request body for user login
 type LoginRequest struct {
 	Email    string `json:"email" validate:"required,email"`
@@ -134,17 +531,39 @@ type LoginResponse struct {
 	Email     string     `json:"email"`
 }
 
+// RegisterRequest represents the request body for user self-registration.
+// Unlike CreateUserRequest it cannot create service users.
+type RegisterRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
 // CreateUserRequest represents the request body for creating a user
 type CreateUserRequest struct {
 	Email     string `json:"email" validate:"required,email"`
-	Password  string `json:"password" validate:"required,min=8"`
+	Password  string `json:"password" validate:"required"`
 	IsService bool   `json:"is_service"`
 }
 
 // UpdateUserRequest represents the request body for updating a user
 type UpdateUserRequest struct {
 	Email    *string `json:"email,omitempty" validate:"omitempty,email"`
-	Password *string `json:"password,omitempty" validate:"omitempty,min=8"`
+	Password *string `json:"password,omitempty"`
+	Timezone *string `json:"timezone,omitempty" validate:"omitempty,timezone"`
+}
+
+// UpdateProfileRequest represents the request body for updating the
+// authenticated user's own account. CurrentPassword confirms the change.
+type UpdateProfileRequest struct {
+	Email           *string `json:"email,omitempty" validate:"omitempty,email"`
+	Password        *string `json:"password,omitempty"`
+	CurrentPassword string  `json:"current_password" validate:"required"`
+}
+
+// DeleteAccountRequest represents the request body for deleting the
+// authenticated user's account
+type DeleteAccountRequest struct {
+	Password string `json:"password" validate:"required"`
 }
 
 // UserResponse represents a user in API responses
@@ -152,5 +571,6 @@ type UserResponse struct {
 	ID        int64      `json:"id"`
 	Email     string     `json:"email"`
 	IsService bool       `json:"is_service"`
+	Timezone  string     `json:"timezone"`
 	CreatedAt *time.Time `json:"created_at,omitempty"`
 } 
\ No newline at end of file
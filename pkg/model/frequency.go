@@ -0,0 +1,136 @@
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+// Frequency is how often a recurring rule repeats. The request validator, the
+// recurring list filters and the scheduler all use it, so a new frequency only
+// needs an entry in frequencySpecs.
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "daily"
+	FrequencyWeekly  Frequency = "weekly"
+	FrequencyMonthly Frequency = "monthly"
+	FrequencyYearly  Frequency = "yearly"
+)
+
+// frequencySpec describes a supported frequency
+type frequencySpec struct {
+	unit        string  // what one step of interval_n is
+	maxInterval int     // largest sensible interval_n
+	perYear     float64 // repeats a year at interval_n 1
+	advance     func(date time.Time, n int) time.Time
+}
+
+// frequencySpecs holds every supported frequency. Intervals above
+// maxInterval are almost certainly mistakes: a yearly rule with interval_n
+// 365 would run every 365 years.
+var frequencySpecs = map[Frequency]frequencySpec{
+	FrequencyDaily: {
+		unit:        "day",
+		maxInterval: 365,
+		perYear:     365,
+		advance:     func(date time.Time, n int) time.Time { return date.AddDate(0, 0, n) },
+	},
+	FrequencyWeekly: {
+		unit:        "week",
+		maxInterval: 52,
+		perYear:     52,
+		advance:     func(date time.Time, n int) time.Time { return date.AddDate(0, 0, 7*n) },
+	},
+	FrequencyMonthly: {
+		unit:        "month",
+		maxInterval: 24,
+		perYear:     12,
+		advance:     addMonths,
+	},
+	FrequencyYearly: {
+		unit:        "year",
+		maxInterval: 10,
+		perYear:     1,
+		advance:     addYears,
+	},
+}
+
+// Frequencies returns every supported frequency, shortest first
+func Frequencies() []Frequency {
+	frequencies := make([]Frequency, 0, len(frequencySpecs))
+	for f := range frequencySpecs {
+		frequencies = append(frequencies, f)
+	}
+	sort.Slice(frequencies, func(i, j int) bool {
+		return frequencySpecs[frequencies[i]].perYear > frequencySpecs[frequencies[j]].perYear
+	})
+	return frequencies
+}
+
+// Valid reports whether f is a supported frequency
+func (f Frequency) Valid() bool {
+	_, ok := frequencySpecs[f]
+	return ok
+}
+
+// Unit returns what one step of a rule's interval_n is
+func (f Frequency) Unit() string {
+	return frequencySpecs[f].unit
+}
+
+// MaxInterval returns the largest sensible interval_n
+func (f Frequency) MaxInterval() int {
+	return frequencySpecs[f].maxInterval
+}
+
+// PerYear returns how many times a year a rule with interval_n 1 repeats
+func (f Frequency) PerYear() float64 {
+	return frequencySpecs[f].perYear
+}
+
+// Advance returns the date n intervals of f after date, clamping to the end
+// of shorter months and to 28 February outside leap years. Unknown
+// frequencies leave the date unchanged.
+func (f Frequency) Advance(date time.Time, n int) time.Time {
+	spec, ok := frequencySpecs[f]
+	if !ok {
+		return date
+	}
+	return spec.advance(date, n)
+}
+
+// addMonths adds the specified number of months to a date, clamping the day
+// to the last day of shorter months
+func addMonths(date time.Time, months int) time.Time {
+	year, month, day := date.Date()
+
+	// Calculate new year and month
+	newYear := year + (int(month)-1+months)/12
+	newMonth := time.Month((int(month)-1+months)%12 + 1)
+
+	// Use the same day, clamped to the last day of the target month
+	lastDayOfTargetMonth := time.Date(newYear, newMonth+1, 1, 0, 0, 0, 0, date.Location()).AddDate(0, 0, -1).Day()
+	if day > lastDayOfTargetMonth {
+		day = lastDayOfTargetMonth
+	}
+
+	return time.Date(newYear, newMonth, day, date.Hour(), date.Minute(), date.Second(), date.Nanosecond(), date.Location())
+}
+
+// addYears adds the specified number of years to a date, moving 29 February
+// to the 28th in years that aren't leap years
+func addYears(date time.Time, years int) time.Time {
+	year, month, day := date.Date()
+	newYear := year + years
+
+	// Handle February 29th in leap years
+	if month == time.February && day == 29 {
+		targetDate := time.Date(newYear, time.February, 29, 0, 0, 0, 0, date.Location())
+		if targetDate.Month() != time.February {
+			// Target year is not a leap year, use February 28th
+			day = 28
+		}
+	}
+
+	return time.Date(newYear, month, day, date.Hour(), date.Minute(), date.Second(), date.Nanosecond(), date.Location())
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrequencySpecs(t *testing.T) {
+	from := time.Date(2031, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	assert.Len(t, Frequencies(), len(frequencySpecs))
+	for f, spec := range frequencySpecs {
+		assert.True(t, f.Valid(), f)
+		assert.Contains(t, Frequencies(), f)
+		assert.NotEmpty(t, spec.unit, f)
+		assert.Positive(t, spec.maxInterval, f)
+		assert.Positive(t, spec.perYear, f)
+		assert.True(t, f.Advance(from, 1).After(from), "%s doesn't advance", f)
+		assert.True(t, f.Advance(from, 2).After(f.Advance(from, 1)), "%s doesn't advance by interval", f)
+	}
+
+	// Shortest first
+	assert.Equal(t, []Frequency{FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly}, Frequencies())
+
+	unknown := Frequency("fortnightly")
+	assert.False(t, unknown.Valid())
+	assert.Empty(t, unknown.Unit())
+	assert.Zero(t, unknown.MaxInterval())
+	assert.Zero(t, unknown.PerYear())
+	assert.Equal(t, from, unknown.Advance(from, 1))
+}
+
+func TestFrequencyAdvanceClampsToMonthEnd(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	assert.Equal(t, date(2031, 2, 28), FrequencyMonthly.Advance(date(2031, 1, 31), 1))
+	assert.Equal(t, date(2032, 2, 29), FrequencyMonthly.Advance(date(2032, 1, 31), 1))
+	assert.Equal(t, date(2032, 1, 31), FrequencyMonthly.Advance(date(2031, 12, 31), 1))
+	assert.Equal(t, date(2033, 2, 28), FrequencyYearly.Advance(date(2032, 2, 29), 1))
+	assert.Equal(t, date(2036, 2, 29), FrequencyYearly.Advance(date(2032, 2, 29), 4))
+}
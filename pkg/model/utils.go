@@ -32,6 +32,42 @@ func PenceToCurrency(pence int64) string {
 	return strconv.FormatFloat(amount, 'f', 2, 64)
 }
 
+// currencySymbol is how an amount in a currency is marked for display
+type currencySymbol struct {
+	symbol string
+	suffix bool // written after the amount, separated by a space
+}
+
+// currencySymbols lists the currencies PenceToDisplay knows a symbol for
+var currencySymbols = map[string]currencySymbol{
+	"GBP": {symbol: "£"},
+	"USD": {symbol: "$"},
+	"EUR": {symbol: "€"},
+	"PLN": {symbol: "zł", suffix: true},
+}
+
+// PenceToDisplay formats pence for people to read, with the currency's symbol
+// in its usual place (e.g., "£12.34", "-£12.34" or "12.34 zł"). Currencies
+// without a known symbol are followed by their code. JSON responses keep the
+// bare PenceToCurrency string.
+func PenceToDisplay(pence int64, currency string) string {
+	sign := ""
+	if pence < 0 {
+		sign = "-"
+		pence = -pence
+	}
+	amount := PenceToCurrency(pence)
+
+	cs, ok := currencySymbols[strings.ToUpper(currency)]
+	if !ok {
+		return sign + amount + " " + strings.ToUpper(currency)
+	}
+	if cs.suffix {
+		return sign + amount + " " + cs.symbol
+	}
+	return sign + cs.symbol + amount
+}
+
 // ParseDate parses a date string in YYYY-MM-DD format
 func ParseDate(dateStr string) (time.Time, error) {
 	return time.Parse("2006-01-02", dateStr)
@@ -50,6 +86,15 @@ func StringToSQLNullString(s *string) sql.NullString {
 	return sql.NullString{String: *s, Valid: true}
 }
 
+// TrimStringPtr trims leading and trailing whitespace from an optional string
+func TrimStringPtr(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*s)
+	return &trimmed
+}
+
 // SQLNullStringToString converts sql.NullString to a string pointer
 func SQLNullStringToString(ns sql.NullString) *string {
 	if !ns.Valid {
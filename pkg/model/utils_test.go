@@ -0,0 +1,31 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPenceToDisplay(t *testing.T) {
+	tests := []struct {
+		name     string
+		pence    int64
+		currency string
+		expected string
+	}{
+		{"GBP", 1234, "GBP", "£12.34"},
+		{"USD", 1234, "USD", "$12.34"},
+		{"negative GBP", -1234, "GBP", "-£12.34"},
+		{"negative USD under a pound", -5, "USD", "-$0.05"},
+		{"zero", 0, "GBP", "£0.00"},
+		{"lower case code", 1234, "gbp", "£12.34"},
+		{"symbol after the amount", -1234, "PLN", "-12.34 zł"},
+		{"unknown currency", 1234, "CHF", "12.34 CHF"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, PenceToDisplay(tt.pence, tt.currency))
+		})
+	}
+}
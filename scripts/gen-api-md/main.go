@@ -305,15 +305,13 @@ func main() {
 	w("}\n")
 	w("```\n\n")
 	w("> `\"amount\": \"-1050\"` = £10.50 expense. Positive values are income.\n\n")
-	w("**Response** `200 OK`\n\n")
+	w("**Response** `201 Created`\n\n")
 	w("```json\n")
 	w("{\n")
-	w("  \"id\": 42,\n")
-	w("  \"amount\": \"-1050\",\n")
-	w("  \"t_date\": \"2026-03-07\",\n")
-	w("  \"note\": \"Coffee\",\n")
-	w("  \"tag_ids\": [1, 3],\n")
-	w("  \"created_at\": \"2026-03-07T10:15:30Z\"\n")
+	w("  \"data\": {\n")
+	w("    \"id\": 42\n")
+	w("  },\n")
+	w("  \"error\": null\n")
 	w("}\n")
 	w("```\n")
 